@@ -86,7 +86,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := pkg.Shutdown(ctx, srv); err != nil {
 		logger.Error("Server forced to shutdown", "error", err)
 	}
 
@@ -25,6 +25,18 @@ type (
 		Operation string
 		Err       error
 	}
+
+	// StorageFullError is returned when a resource limit is reached and new resources are rejected
+	StorageFullError struct {
+		Limit int
+	}
+
+	// VersionConflictError is returned when a conditional update expects another version of the resource
+	VersionConflictError struct {
+		ID       string
+		Expected int
+		Actual   int
+	}
 )
 
 func (e *NotFoundError) Error() string {
@@ -46,6 +58,14 @@ func (e *StorageError) Error() string {
 	return fmt.Sprintf("storage error during %s: %v", e.Operation, e.Err)
 }
 
+func (e *StorageFullError) Error() string {
+	return fmt.Sprintf("storage full: limit of %d resources reached", e.Limit)
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("resource with ID %s is at version %d, expected %d", e.ID, e.Actual, e.Expected)
+}
+
 // NewNotFoundError creates a new NotFoundError with the given ID and path.
 func NewNotFoundError(id, path string) error {
 	return &NotFoundError{ID: id, Path: path}
@@ -65,3 +85,13 @@ func NewInvalidRequestError(reason string) error {
 func NewStorageError(operation string, err error) error {
 	return &StorageError{Operation: operation, Err: err}
 }
+
+// NewStorageFullError creates a new StorageFullError with the given resource limit.
+func NewStorageFullError(limit int) error {
+	return &StorageFullError{Limit: limit}
+}
+
+// NewVersionConflictError creates a new VersionConflictError with the given ID and versions.
+func NewVersionConflictError(id string, expected, actual int) error {
+	return &VersionConflictError{ID: id, Expected: expected, Actual: actual}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// tryHandleArrayPOST creates one resource per element of a JSON array body in array_mode sections,
+// as bulk-create endpoints do, and answers 201 with {"locations": [...]} listing them in order.
+// Creation is all or nothing: when an element fails, the resources created before it are removed.
+// It returns nil when the section or request does not call for array mode.
+func (h *UniHandler) tryHandleArrayPOST(
+	ctx context.Context, req *http.Request, section *config.Section, sectionName string,
+) *http.Response {
+	if !section.ArrayMode || !strings.Contains(req.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+	body, err := h.readAndRestoreRequestBody(req)
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		return nil
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(body, &elements); err != nil {
+		return h.errorResponse(http.StatusBadRequest, "invalid request: failed to parse JSON body")
+	}
+	if len(elements) == 0 {
+		return h.errorResponse(http.StatusBadRequest, "invalid request: empty array")
+	}
+
+	created := make([]model.UniData, 0, len(elements))
+	for _, element := range elements {
+		elementReq := req.Clone(ctx)
+		elementReq.Body = io.NopCloser(bytes.NewReader(element))
+		elementReq.ContentLength = int64(len(element))
+
+		_, mockData, errResp := h.preparePostData(ctx, elementReq, section, sectionName)
+		if errResp == nil {
+			mockData, errResp = h.processPostRequest(ctx, elementReq, mockData, section, sectionName)
+		}
+		if errResp != nil {
+			h.removeCreated(ctx, created, section, sectionName)
+			return errResp
+		}
+		created = append(created, mockData)
+	}
+	return h.buildArrayPOSTResponse(created)
+}
+
+// removeCreated rolls back the resources created by an array POST that failed part way
+func (h *UniHandler) removeCreated(
+	ctx context.Context, created []model.UniData, section *config.Section, sectionName string,
+) {
+	for _, data := range created {
+		if err := h.service.DeleteResource(ctx, sectionName, section.StrictPath, data.IDs[0]); err != nil {
+			h.logger.Error("failed to roll back array POST", errorLogKey, err)
+		}
+	}
+}
+
+// buildArrayPOSTResponse lists the locations of the resources created by an array POST
+func (h *UniHandler) buildArrayPOSTResponse(created []model.UniData) *http.Response {
+	locations := make([]string, 0, len(created))
+	for _, data := range created {
+		locations = append(locations, data.Location)
+	}
+	body, err := json.Marshal(map[string][]string{"locations": locations})
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	return &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArrayModeHandler(arrayMode bool) *handler.UniHandler {
+	return newSectionHandler("users", config.Section{
+		PathPattern:   "/users/*",
+		BodyIDPaths:   []string{"/id"},
+		SequentialIDs: true,
+		ArrayMode:     arrayMode,
+	})
+}
+
+func TestUniHandler_ArrayMode(t *testing.T) {
+	uniHandler := newArrayModeHandler(true)
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users",
+		`[{"id":"a","name":"Ann"},{"id":"b","name":"Bob"},{"name":"Cid"}]`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"locations":["/users/a","/users/b","/users/1"]}`, w.Body.String())
+
+	for path, body := range map[string]string{
+		"/users/a": `{"id":"a","name":"Ann"}`,
+		"/users/b": `{"id":"b","name":"Bob"}`,
+		"/users/1": `{"name":"Cid"}`,
+	} {
+		w := serveRequest(uniHandler, http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, w.Code, path)
+		assert.JSONEq(t, body, w.Body.String(), path)
+	}
+}
+
+func TestUniHandler_ArrayMode_ConflictCreatesNothing(t *testing.T) {
+	uniHandler := newArrayModeHandler(true)
+	require.Equal(t, http.StatusCreated,
+		serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"b","name":"Bob"}`).Code)
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `[{"id":"a"},{"id":"b"}]`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/users/a", "").Code,
+		"elements created before the conflict are rolled back")
+	w = serveRequest(uniHandler, http.MethodGet, "/users/b", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"b","name":"Bob"}`, w.Body.String())
+}
+
+func TestUniHandler_ArrayMode_Invalid(t *testing.T) {
+	uniHandler := newArrayModeHandler(true)
+
+	assert.Equal(t, http.StatusBadRequest, serveRequest(uniHandler, http.MethodPost, "/users", `[]`).Code)
+	assert.Equal(t, http.StatusBadRequest, serveRequest(uniHandler, http.MethodPost, "/users", `[{"id":`).Code)
+}
+
+func TestUniHandler_ArrayMode_ObjectBody(t *testing.T) {
+	uniHandler := newArrayModeHandler(true)
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"a"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/users/a", w.Header().Get("Location"))
+}
+
+func TestUniHandler_ArrayMode_Disabled(t *testing.T) {
+	uniHandler := newArrayModeHandler(false)
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `[{"id":"a"},{"id":"b"}]`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/users/1", w.Header().Get("Location"), "without array mode the array is a single resource")
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/users/b", "").Code)
+}
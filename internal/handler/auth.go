@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authRealm is the realm advertised in WWW-Authenticate challenges
+const authRealm = "unimock"
+
+// checkAuth enforces the matched section's auth block. It returns a 401 response when the
+// request lacks valid credentials, or nil when the request may proceed. OPTIONS requests are
+// exempt so that preflight checks keep working.
+func (h *UniHandler) checkAuth(req *http.Request) *http.Response {
+	if req.Method == http.MethodOptions {
+		return nil
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.Auth == nil {
+		return nil
+	}
+
+	auth := section.Auth
+	if auth.IsBasic() {
+		username, password, ok := req.BasicAuth()
+		if ok && secureEqual(username, auth.Username) && secureEqual(password, auth.Password) {
+			return nil
+		}
+		return h.unauthorizedResponse(`Basic realm="` + authRealm + `"`)
+	}
+
+	header := req.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return h.unauthorizedResponse(`Bearer realm="` + authRealm + `"`)
+	}
+	if !secureEqual(strings.TrimPrefix(header, bearerPrefix), auth.BearerToken) {
+		return h.unauthorizedResponse(`Bearer realm="` + authRealm + `", error="invalid_token"`)
+	}
+	return nil
+}
+
+// unauthorizedResponse builds a 401 response carrying the given WWW-Authenticate challenge
+func (h *UniHandler) unauthorizedResponse(challenge string) *http.Response {
+	resp := h.errorResponse(http.StatusUnauthorized, "unauthorized")
+	resp.Header.Set("WWW-Authenticate", challenge)
+	return resp
+}
+
+// secureEqual compares credentials in constant time
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// tryCanonicalRedirect answers GET/HEAD requests for non-canonical paths of sections with
+// redirect_to_canonical with a 301 to the canonical path. It returns nil when no redirect applies.
+func (h *UniHandler) tryCanonicalRedirect(req *http.Request) *http.Response {
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || h.uniCfg == nil {
+		return nil
+	}
+
+	collapsed := collapseSlashes(req.URL.Path)
+	_, section, err := h.uniCfg.MatchPath(strings.ToLower(collapsed))
+	if err != nil || section == nil || !section.RedirectToCanonical {
+		return nil
+	}
+
+	canonical := canonicalPath(section.PathPattern, collapsed)
+	if canonical == req.URL.Path {
+		return nil
+	}
+
+	location := canonical
+	if req.URL.RawQuery != "" {
+		location += "?" + req.URL.RawQuery
+	}
+	resp := &http.Response{
+		StatusCode: http.StatusMovedPermanently,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	resp.Header.Set("Location", location)
+	return resp
+}
+
+// collapseSlashes replaces runs of slashes with a single slash and drops a trailing slash
+func collapseSlashes(p string) string {
+	segments := strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+	return "/" + strings.Join(segments, "/")
+}
+
+// canonicalPath spells the literal segments of reqPath as the pattern does (e.g. /Users/123 becomes
+// /users/123 for /users/*), keeping wildcard segments such as IDs unchanged
+func canonicalPath(pattern, reqPath string) string {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(reqPath, "/"), "/")
+
+	for i := range segments {
+		if i >= len(patternSegments) || patternSegments[i] == config.RecursiveWildcard {
+			break
+		}
+		if patternSegments[i] != "*" && strings.EqualFold(patternSegments[i], segments[i]) {
+			segments[i] = patternSegments[i]
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
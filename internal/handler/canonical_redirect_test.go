@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniHandler_RedirectToCanonical(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:         "/users/*",
+		BodyIDPaths:         []string{"/id"},
+		RedirectToCanonical: true,
+	})
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"AbC"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name: "uppercase and duplicate slashes", method: http.MethodGet, path: "/Users//123",
+			wantStatus: http.StatusMovedPermanently, wantLocation: "/users/123",
+		},
+		{
+			name: "query string is preserved", method: http.MethodGet, path: "//users/123?fields=id",
+			wantStatus: http.StatusMovedPermanently, wantLocation: "/users/123?fields=id",
+		},
+		{
+			name: "HEAD is redirected", method: http.MethodHead, path: "/USERS/123",
+			wantStatus: http.StatusMovedPermanently, wantLocation: "/users/123",
+		},
+		{
+			name: "ID case is kept", method: http.MethodGet, path: "/Users/AbC",
+			wantStatus: http.StatusMovedPermanently, wantLocation: "/users/AbC",
+		},
+		{
+			name: "canonical path is served", method: http.MethodGet, path: "/users/AbC",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "writes are not redirected", method: http.MethodDelete, path: "/Users/AbC",
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRequest(uniHandler, tt.method, tt.path, "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantLocation != "" {
+				assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestUniHandler_RedirectToCanonical_Disabled(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern: "/users/*",
+		BodyIDPaths: []string{"/id"},
+	})
+
+	w := serveRequest(uniHandler, http.MethodGet, "/Users//123", "")
+
+	assert.NotEqual(t, http.StatusMovedPermanently, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+)
+
+// WriteChunked writes body to w, flushing at each of the given byte offsets so that a chunked
+// response is split exactly there. Offsets outside the body or not increasing are ignored.
+func WriteChunked(w io.Writer, body []byte, boundaries []int) error {
+	flusher, _ := w.(http.Flusher)
+	start := 0
+	for _, offset := range boundaries {
+		if offset <= start || offset >= len(body) {
+			continue
+		}
+		if _, err := w.Write(body[start:offset]); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		start = offset
+	}
+	_, err := w.Write(body[start:])
+	return err
+}
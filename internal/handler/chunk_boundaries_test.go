@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readRawChunks sends a GET over a plain TCP connection and returns the chunks of the
+// chunked response body exactly as they arrived on the wire
+func readRawChunks(t *testing.T, addr, path string) []string {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", path, addr)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	chunked := false
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if strings.EqualFold(strings.TrimSpace(line), "Transfer-Encoding: chunked") {
+			chunked = true
+		}
+		if line == "\r\n" {
+			break
+		}
+	}
+	require.True(t, chunked, "response is not chunked")
+
+	var chunks []string
+	for {
+		sizeLine, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		size, err := strconv.ParseInt(strings.TrimSpace(sizeLine), 16, 64)
+		require.NoError(t, err)
+		if size == 0 {
+			return chunks
+		}
+		chunk := make([]byte, size+2) // chunk data followed by CRLF
+		_, err = io.ReadFull(reader, chunk)
+		require.NoError(t, err)
+		chunks = append(chunks, string(chunk[:size]))
+	}
+}
+
+func TestUniHandler_ChunkBoundaries(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:     "/users/*",
+		BodyIDPaths:     []string{"/id"},
+		ChunkBoundaries: []int{5, 12, 1000},
+	})
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"streaming parser"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	server := httptest.NewServer(uniHandler)
+	defer server.Close()
+
+	chunks := readRawChunks(t, server.Listener.Addr().String(), "/users/1")
+
+	body := strings.Join(chunks, "")
+	require.Len(t, chunks, 3)
+	assert.Equal(t, body[:5], chunks[0])
+	assert.Equal(t, body[5:12], chunks[1])
+	assert.Equal(t, body[12:], chunks[2])
+	assert.JSONEq(t, `{"id":"1","name":"streaming parser"}`, body)
+}
@@ -0,0 +1,17 @@
+package handler
+
+import "net/http"
+
+// closeConnection adds Connection: close to responses of sections with close_connection, which makes
+// the server close the connection after writing the response
+func (h *UniHandler) closeConnection(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || !section.CloseConnection {
+		return resp
+	}
+	resp.Header.Set("Connection", "close")
+	return resp
+}
@@ -0,0 +1,19 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniHandler_CloseConnection(t *testing.T) {
+	closing := newSectionHandler("users", config.Section{PathPattern: "/users/*", CloseConnection: true})
+	keepAlive := newSectionHandler("users", config.Section{PathPattern: "/users/*"})
+
+	for _, path := range []string{"/users", "/users/missing"} {
+		assert.Equal(t, "close", serveRequest(closing, http.MethodGet, path, "").Header().Get("Connection"), path)
+		assert.Empty(t, serveRequest(keepAlive, http.MethodGet, path, "").Header().Get("Connection"), path)
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// coalescedHeader marks responses shared from another in-flight identical request
+const coalescedHeader = "X-Coalesced"
+
+// coalesceKeyHeaders are request headers that change the GET response and therefore the coalescing key
+var coalesceKeyHeaders = []string{"Accept", "Accept-Language", "Authorization", authScopeHeader}
+
+// coalescedCall is an in-flight request whose response is shared with identical concurrent requests
+type coalescedCall struct {
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// requestCoalescer runs identical concurrent requests once and shares the result (singleflight-style)
+type requestCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// newRequestCoalescer creates an empty requestCoalescer
+func newRequestCoalescer() *requestCoalescer {
+	return &requestCoalescer{calls: make(map[string]*coalescedCall)}
+}
+
+// do runs fn for the first caller of key; callers arriving while it is in flight wait and receive
+// a copy of its response marked with the X-Coalesced header
+func (c *requestCoalescer) do(key string, fn func() (*http.Response, error)) (*http.Response, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.response(true)
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.capture(fn())
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.response(false)
+}
+
+// capture stores the response so that it can be replayed for every waiting caller
+func (call *coalescedCall) capture(resp *http.Response, err error) {
+	if err != nil || resp == nil {
+		call.err = err
+		return
+	}
+	call.status = resp.StatusCode
+	call.header = resp.Header.Clone()
+	if resp.Body != nil {
+		call.body, call.err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+}
+
+// response rebuilds an independent copy of the captured response
+func (call *coalescedCall) response(shared bool) (*http.Response, error) {
+	if call.err != nil {
+		return nil, call.err
+	}
+	header := call.header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	if shared {
+		header.Set(coalescedHeader, "true")
+	}
+	return &http.Response{
+		StatusCode: call.status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(call.body)),
+	}, nil
+}
+
+// coalesceKey identifies requests that would produce identical GET responses
+func coalesceKey(req *http.Request) string {
+	var key strings.Builder
+	key.WriteString(req.Method + " " + req.URL.RequestURI())
+	for _, name := range coalesceKeyHeaders {
+		key.WriteString("\n" + name + ":" + req.Header.Get(name))
+	}
+	return key.String()
+}
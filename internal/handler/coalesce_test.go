@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSlowReportHandler(coalesce bool, computations *atomic.Int32) http.Handler {
+	slowTransform := func(data model.UniData) (model.UniData, error) {
+		computations.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		return data, nil
+	}
+	uniHandler := newSectionHandler("reports", config.Section{
+		PathPattern:      "/reports/*",
+		BodyIDPaths:      []string{"/id"},
+		CoalesceRequests: coalesce,
+		Transformations: &config.TransformationConfig{
+			ResponseTransforms: []config.ResponseTransformFunc{slowTransform},
+		},
+	})
+	serveRequest(uniHandler, http.MethodPost, "/reports", `{"id":"1","total":42}`)
+	return uniHandler
+}
+
+func TestUniHandler_CoalesceRequests_ComputesOnce(t *testing.T) {
+	var computations atomic.Int32
+	uniHandler := newSlowReportHandler(true, &computations)
+	computations.Store(0)
+
+	const requests = 20
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	var coalesced atomic.Int32
+	codes := make([]int, requests)
+	bodies := make([]string, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			w := serveRequest(uniHandler, http.MethodGet, "/reports/1", "")
+			codes[i], bodies[i] = w.Code, w.Body.String()
+			if w.Header().Get("X-Coalesced") == "true" {
+				coalesced.Add(1)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), computations.Load())
+	assert.Equal(t, int32(requests-1), coalesced.Load())
+	for i := 0; i < requests; i++ {
+		require.Equal(t, http.StatusOK, codes[i])
+		assert.JSONEq(t, `{"id":"1","total":42}`, bodies[i])
+	}
+}
+
+func TestUniHandler_CoalesceRequests_DisabledByDefault(t *testing.T) {
+	var computations atomic.Int32
+	uniHandler := newSlowReportHandler(false, &computations)
+	computations.Store(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := serveRequest(uniHandler, http.MethodGet, "/reports/1", "")
+			assert.Empty(t, w.Header().Get("X-Coalesced"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), computations.Load())
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// compositeIDSeparator joins the path segments that form a composite ID
+const compositeIDSeparator = "/"
+
+// hasCompositeIDs reports whether resources of the section are keyed by several path segments
+func hasCompositeIDs(section *config.Section) bool {
+	return len(section.PathIDSegments) > 0
+}
+
+// compositePathID builds a composite ID from the configured path segments of the request path.
+// Segments beyond the end of the request path are filled with leafID (e.g. an ID taken from the body).
+// It returns an empty string when a segment value is missing.
+func compositePathID(section *config.Section, reqPath, leafID string) string {
+	pathSegments := strings.Split(strings.Trim(reqPath, "/"), "/")
+	parts := make([]string, 0, len(section.PathIDSegments))
+	for _, idx := range section.PathIDSegments {
+		switch {
+		case idx >= 0 && idx < len(pathSegments) && pathSegments[idx] != "":
+			parts = append(parts, pathSegments[idx])
+		case leafID != "":
+			parts = append(parts, leafID)
+			leafID = ""
+		default:
+			return ""
+		}
+	}
+	return strings.Join(parts, compositeIDSeparator)
+}
+
+// extractCompositePathIDs returns the composite ID addressed by a full-length resource path
+func extractCompositePathIDs(section *config.Section, reqPath string) []string {
+	pathSegments := strings.Split(strings.Trim(reqPath, "/"), "/")
+	patternSegments := strings.Split(strings.Trim(section.PathPattern, "/"), "/")
+	if len(pathSegments) != len(patternSegments) {
+		return nil
+	}
+	if id := compositePathID(section, reqPath, ""); id != "" {
+		return []string{id}
+	}
+	return nil
+}
+
+// compositeBodyIDs prefixes IDs extracted for a POST with the parent ID segments from the request path
+func compositeBodyIDs(section *config.Section, reqPath string, ids []string) []string {
+	composite := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if compositeID := compositePathID(section, reqPath, id); compositeID != "" {
+			composite = append(composite, compositeID)
+		}
+	}
+	return composite
+}
+
+// leafID returns the last component of a composite ID
+func leafID(id string) string {
+	return id[strings.LastIndex(id, compositeIDSeparator)+1:]
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTenantUsersHandler(t *testing.T) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("tenant_users", config.Section{
+		PathPattern:    "/tenants/*/users/*",
+		BodyIDPaths:    []string{"/id"},
+		PathIDSegments: []int{1, 3},
+	})
+	for _, tc := range []struct{ path, body string }{
+		{"/tenants/t1/users", `{"id":"u1","name":"Alice"}`},
+		{"/tenants/t2/users", `{"id":"u1","name":"Bob"}`},
+		{"/tenants/t1/users", `{"id":"u2","name":"Carol"}`},
+	} {
+		w := serveRequest(uniHandler, http.MethodPost, tc.path, tc.body)
+		require.Equal(t, http.StatusCreated, w.Code, tc.body)
+	}
+	return uniHandler
+}
+
+func TestUniHandler_CompositeIDs_SameLeafIDInDifferentParents(t *testing.T) {
+	uniHandler := newTenantUsersHandler(t)
+
+	t1 := serveRequest(uniHandler, http.MethodGet, "/tenants/t1/users/u1", "")
+	t2 := serveRequest(uniHandler, http.MethodGet, "/tenants/t2/users/u1", "")
+
+	assert.Equal(t, http.StatusOK, t1.Code)
+	assert.JSONEq(t, `{"id":"u1","name":"Alice"}`, t1.Body.String())
+	assert.Equal(t, http.StatusOK, t2.Code)
+	assert.JSONEq(t, `{"id":"u1","name":"Bob"}`, t2.Body.String())
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/tenants/t3/users/u1", "").Code)
+}
+
+func TestUniHandler_CompositeIDs_CollectionScopedToParent(t *testing.T) {
+	uniHandler := newTenantUsersHandler(t)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/tenants/t1/users", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var users []map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &users))
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user["name"])
+	}
+	assert.ElementsMatch(t, []string{"Alice", "Carol"}, names)
+}
+
+func TestUniHandler_CompositeIDs_PutAndDelete(t *testing.T) {
+	uniHandler := newTenantUsersHandler(t)
+
+	put := serveRequest(uniHandler, http.MethodPut, "/tenants/t2/users/u1", `{"id":"u1","name":"Bobby"}`)
+	require.Equal(t, http.StatusOK, put.Code)
+	assert.JSONEq(t, `{"id":"u1","name":"Bobby"}`,
+		serveRequest(uniHandler, http.MethodGet, "/tenants/t2/users/u1", "").Body.String())
+	assert.JSONEq(t, `{"id":"u1","name":"Alice"}`,
+		serveRequest(uniHandler, http.MethodGet, "/tenants/t1/users/u1", "").Body.String())
+
+	del := serveRequest(uniHandler, http.MethodDelete, "/tenants/t1/users/u1", "")
+	require.Equal(t, http.StatusNoContent, del.Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/tenants/t1/users/u1", "").Code)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/tenants/t2/users/u1", "").Code)
+}
+
+func TestUniHandler_CompositeIDs_LocationUsesLeafID(t *testing.T) {
+	uniHandler := newSectionHandler("tenant_users", config.Section{
+		PathPattern:    "/tenants/*/users/*",
+		BodyIDPaths:    []string{"/id"},
+		PathIDSegments: []int{1, 3},
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/tenants/t1/users", `{"id":"u9"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/tenants/t1/users/u9", w.Header().Get("Location"))
+}
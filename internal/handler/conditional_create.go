@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// createOnly reports whether a PUT asks to create the resource only if it does not exist yet
+// by sending If-None-Match: *
+func createOnly(req *http.Request) bool {
+	return req.Method == http.MethodPut && strings.TrimSpace(req.Header.Get(ifNoneMatchHeader)) == "*"
+}
+
+// executeResourceCreate stores the resource of a create-only PUT with 201 Created. The existence
+// check of checkPreconditions is repeated atomically by the create, so a resource created in the
+// meantime by a concurrent request answers 412 Precondition Failed instead of being overwritten.
+func (h *UniHandler) executeResourceCreate(
+	ctx context.Context, id string, data model.UniData, section *config.Section, sectionName string,
+) (*http.Response, error) {
+	err := h.service.CreateResource(ctx, sectionName, section.StrictPath, []string{id}, data)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return h.errorResponse(http.StatusPreconditionFailed, "precondition failed: If-None-Match"), nil
+		}
+		h.logger.Error("failed to create resource for PUT", "error", err)
+		if resp := h.storageFullResponse(err); resp != nil {
+			return resp, nil
+		}
+		return h.errorResponse(http.StatusInternalServerError, "failed to create resource"), nil
+	}
+
+	responseData, err := h.applyResponseTransformations(data, section, sectionName)
+	if err != nil {
+		h.logger.Error("response transformation failed for PUT", "error", err)
+		return h.errorResponse(http.StatusInternalServerError, "response transformation failed"), nil
+	}
+	resp := h.buildPUTResponse(responseData, section)
+	resp.StatusCode = http.StatusCreated
+	return resp, nil
+}
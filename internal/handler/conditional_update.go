@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// ifMatchVersion returns the resource version a PUT expects when its If-Match header is a bare positive
+// integer, e.g. If-Match: 3. Entity tags are quoted, so version and ETag preconditions never overlap.
+func ifMatchVersion(req *http.Request) (int, bool) {
+	if req.Method != http.MethodPut {
+		return 0, false
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(req.Header.Get(ifMatchHeader)))
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// executeVersionedUpdate updates the resource of a PUT carrying an If-Match version. The version is
+// compared atomically by the update, so when concurrent requests expect the same version only the
+// first succeeds and the others answer 412 Precondition Failed, as do requests for missing resources.
+func (h *UniHandler) executeVersionedUpdate(
+	ctx context.Context, id string, version int, data model.UniData, section *config.Section, sectionName string,
+) (*http.Response, error) {
+	err := h.service.UpdateResourceIfVersion(ctx, sectionName, section.StrictPath, id, version, data)
+	if err != nil {
+		var conflict *unimockerrors.VersionConflictError
+		var notFound *unimockerrors.NotFoundError
+		switch {
+		case errors.As(err, &conflict):
+			return h.errorResponse(http.StatusPreconditionFailed,
+				"precondition failed: resource is at version "+strconv.Itoa(conflict.Actual)), nil
+		case errors.As(err, &notFound):
+			return h.errorResponse(http.StatusPreconditionFailed, "precondition failed: resource not found"), nil
+		}
+		h.logger.Error("failed to update resource", "error", err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to update resource"), nil
+	}
+
+	responseData, err := h.applyResponseTransformations(data, section, sectionName)
+	if err != nil {
+		h.logger.Error("response transformation failed for PUT", "error", err)
+		return h.errorResponse(http.StatusInternalServerError, "response transformation failed"), nil
+	}
+	return h.buildPUTResponse(responseData, section), nil
+}
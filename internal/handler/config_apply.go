@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// handleConfigApply replaces or merges the sections and scenarios with those of the YAML
+// configuration in the request body, as selected by the mode query parameter (default: replace).
+// Invalid configurations are answered with 400 Bad Request listing every problem and leave the
+// current configuration in place. Stored resources are kept in either mode. ${VAR} references are
+// not expanded and fixture references are rejected, so clients cannot read the server's environment
+// or files or make it fetch URLs.
+func (h *TechHandler) handleConfigApply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.uniCfg == nil {
+		http.Error(w, "no configuration to apply to", http.StatusInternalServerError)
+		return
+	}
+	mode := r.URL.Query().Get("mode")
+	if err := config.ValidateApplyMode(mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if mode == "" {
+		mode = config.ApplyReplace
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	update, err := config.ParseYAML(data, h.uniCfg.BaseDir(),
+		config.WithoutValidation(), config.WithoutEnvExpansion(), config.WithoutFixtures())
+	if err != nil {
+		http.Error(w, "invalid configuration: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if problems := update.ValidationErrors(); len(problems) > 0 {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, problem.Error())
+		}
+		http.Error(w, "invalid configuration: "+strings.Join(messages, "; "), http.StatusBadRequest)
+		return
+	}
+
+	scenarios := make([]model.Scenario, 0, len(update.Scenarios))
+	for _, sc := range update.Scenarios {
+		scenarios = append(scenarios, sc.ToModelScenario(update.GetFixtureResolver()))
+	}
+	if h.scenarioService != nil {
+		if err := h.scenarioService.ApplyScenarios(r.Context(), scenarios, mode == config.ApplyReplace); err != nil {
+			http.Error(w, fmt.Sprintf("invalid configuration: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	h.uniCfg.Apply(update, mode)
+	h.logger.Info("configuration applied", "mode", mode,
+		"sections", len(update.Sections), "scenarios", len(update.Scenarios))
+
+	scenarioCount := 0
+	if h.scenarioService != nil {
+		scenarioCount = h.scenarioService.CountScenarios(r.Context())
+	}
+	h.writeJSONResponse(w, map[string]any{
+		"mode":           mode,
+		"section_count":  h.uniCfg.SectionCount(),
+		"scenario_count": scenarioCount,
+	})
+}
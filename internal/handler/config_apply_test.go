@@ -0,0 +1,155 @@
+package handler_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appliedConfig = `
+sections:
+  orders:
+    path_pattern: "/orders/*"
+    body_id_paths: ["/id"]
+scenarios:
+  - method: GET
+    path: /orders/special
+    status_code: 418
+`
+
+func newConfigApplyHandler(t *testing.T) (*handler.TechHandler, *config.UniConfig, *service.ScenarioService) {
+	t.Helper()
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	_, err := scenarioService.CreateScenario(context.Background(), model.Scenario{
+		UUID: "existing", RequestPath: "GET /users/1", StatusCode: http.StatusOK,
+	})
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return handler.NewTechHandler(service.NewTechService(time.Now()), nil, scenarioService, logger, cfg),
+		cfg, scenarioService
+}
+
+func applyConfig(techHandler http.Handler, query, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	techHandler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/_uni/config"+query, strings.NewReader(body)))
+	return w
+}
+
+func TestTechHandler_ConfigApply_Replace(t *testing.T) {
+	techHandler, cfg, scenarioService := newConfigApplyHandler(t)
+
+	w := applyConfig(techHandler, "", appliedConfig)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"mode":"replace","section_count":1,"scenario_count":1}`, w.Body.String())
+	assert.NotContains(t, cfg.Sections, "users")
+	_, section, err := cfg.MatchPath("/orders/1")
+	require.NoError(t, err)
+	assert.Equal(t, "/orders/*", section.PathPattern)
+	scenarios := scenarioService.ListScenarios(context.Background())
+	require.Len(t, scenarios, 1)
+	assert.Equal(t, "GET /orders/special", scenarios[0].RequestPath)
+}
+
+func TestTechHandler_ConfigApply_Merge(t *testing.T) {
+	techHandler, cfg, scenarioService := newConfigApplyHandler(t)
+
+	w := applyConfig(techHandler, "?mode=merge", appliedConfig)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, cfg.Sections, "users")
+	assert.Contains(t, cfg.Sections, "orders")
+	assert.Len(t, scenarioService.ListScenarios(context.Background()), 2)
+}
+
+func TestTechHandler_ConfigApply_InvalidKeepsConfig(t *testing.T) {
+	techHandler, cfg, scenarioService := newConfigApplyHandler(t)
+
+	w := applyConfig(techHandler, "", `
+sections:
+  orders:
+    path_pattern: "orders/*"
+scenarios:
+  - method: FETCH
+    path: /orders/1
+`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "must start with /")
+	assert.Contains(t, w.Body.String(), "FETCH", "every problem is reported")
+	assert.Contains(t, cfg.Sections, "users")
+	assert.Len(t, scenarioService.ListScenarios(context.Background()), 1)
+
+	assert.Equal(t, http.StatusBadRequest, applyConfig(techHandler, "?mode=upsert", appliedConfig).Code)
+
+	w = httptest.NewRecorder()
+	techHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/_uni/config", http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestTechHandler_ConfigApply_DoesNotExpandEnv(t *testing.T) {
+	t.Setenv("UNIMOCK_TEST_SECRET", "s3cr3t")
+	techHandler, _, scenarioService := newConfigApplyHandler(t)
+
+	w := applyConfig(techHandler, "", `
+scenarios:
+  - method: GET
+    path: /leak
+    data: '{"secret":"${UNIMOCK_TEST_SECRET}"}'
+`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	scenarios := scenarioService.ListScenarios(context.Background())
+	require.Len(t, scenarios, 1)
+	assert.Equal(t, `{"secret":"${UNIMOCK_TEST_SECRET}"}`, scenarios[0].Data)
+}
+
+func TestTechHandler_ConfigApply_RejectsFixtures(t *testing.T) {
+	fetched := false
+	fixtureServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetched = true
+		_, _ = w.Write([]byte(`{"secret":true}`))
+	}))
+	defer fixtureServer.Close()
+
+	for _, data := range []string{
+		"@" + fixtureServer.URL + "/secret.json",
+		"@config_apply_test.go",
+		"< ./config_apply_test.go",
+		`{"inline": <@ ./config_apply_test.go}`,
+	} {
+		techHandler, _, scenarioService := newConfigApplyHandler(t)
+
+		w := applyConfig(techHandler, "", `
+scenarios:
+  - method: GET
+    path: /leak
+    data: '`+data+`'
+`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, data)
+		assert.Contains(t, w.Body.String(), "fixture references are not allowed", data)
+		scenarios := scenarioService.ListScenarios(context.Background())
+		require.Len(t, scenarios, 1, data)
+		assert.Equal(t, "existing", scenarios[0].UUID, data)
+	}
+	assert.False(t, fetched, "URL fixtures are not fetched")
+}
@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTechHandler_ConfigExport_RoundTrip(t *testing.T) {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {
+				PathPattern: "/users/*",
+				BodyIDPaths: []string{"/id"},
+				ReturnBody:  true,
+			},
+		},
+	}
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	_, err := scenarioService.CreateScenario(context.Background(), model.Scenario{
+		UUID:        "fixture-scenario",
+		RequestPath: "GET /users/42",
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json",
+		Data:        `{"id":"42","source":"fixture"}`,
+	})
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	techHandler := handler.NewTechHandler(service.NewTechService(time.Now()), nil, scenarioService, logger, cfg)
+
+	w := httptest.NewRecorder()
+	techHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/_uni/config/export", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+
+	exportPath := filepath.Join(t.TempDir(), "export.yaml")
+	require.NoError(t, os.WriteFile(exportPath, w.Body.Bytes(), 0600))
+	loaded, err := config.LoadFromYAML(exportPath)
+	require.NoError(t, err)
+
+	require.Contains(t, loaded.Sections, "users")
+	assert.Equal(t, "/users/*", loaded.Sections["users"].PathPattern)
+	assert.True(t, loaded.Sections["users"].ReturnBody)
+	require.Len(t, loaded.Scenarios, 1)
+	scenario := loaded.Scenarios[0].ToModelScenario(loaded.GetFixtureResolver())
+	assert.Equal(t, "GET /users/42", scenario.RequestPath)
+	assert.Equal(t, "fixture-scenario", scenario.UUID)
+	assert.JSONEq(t, `{"id":"42","source":"fixture"}`, scenario.Data)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// dispositionQuoter escapes the characters that would end or break a quoted filename parameter
+var dispositionQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// renderContentDisposition renders a section's filename template for the resource ID ({{.ID}}) and the
+// path's wildcard captures ({{.Path1}}, ...) and returns the Content-Disposition header value marking
+// the response as an attachment
+func renderContentDisposition(filenameTemplate, id string, captures []string) (string, error) {
+	tmpl, err := template.New("content_disposition").Parse(filenameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid content disposition template: %w", err)
+	}
+
+	fields := config.PathCaptureFields(captures)
+	fields["ID"] = id
+	var filename strings.Builder
+	if err := tmpl.Execute(&filename, fields); err != nil {
+		return "", fmt.Errorf("failed to render content disposition: %w", err)
+	}
+	return `attachment; filename="` + dispositionQuoter.Replace(filename.String()) + `"`, nil
+}
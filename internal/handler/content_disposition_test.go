@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInvoiceHandler(filenameTemplate string) http.Handler {
+	return newSectionHandler("invoices", config.Section{
+		PathPattern:        "/invoices/*",
+		BodyIDPaths:        []string{"/id"},
+		ContentDisposition: filenameTemplate,
+	})
+}
+
+func TestUniHandler_ContentDisposition_RendersFilename(t *testing.T) {
+	uniHandler := newInvoiceHandler("invoice-{{.ID}}.pdf")
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/invoices", `{"id":"42"}`).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/invoices/42", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="invoice-42.pdf"`, w.Header().Get("Content-Disposition"))
+}
+
+func TestUniHandler_ContentDisposition_QuotesFilename(t *testing.T) {
+	uniHandler := newInvoiceHandler(`{{.ID}} "final".pdf`)
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/invoices", `{"id":"7"}`).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/invoices/7", "")
+	assert.Equal(t, `attachment; filename="7 \"final\".pdf"`, w.Header().Get("Content-Disposition"))
+}
+
+func TestUniHandler_ContentDisposition_OnlyIndividualResources(t *testing.T) {
+	uniHandler := newInvoiceHandler("invoice-{{.ID}}.pdf")
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/invoices", `{"id":"42"}`).Code)
+
+	assert.Empty(t, serveRequest(uniHandler, http.MethodGet, "/invoices", "").Header().Get("Content-Disposition"))
+	assert.Empty(t, serveRequest(uniHandler, http.MethodGet, "/invoices/missing", "").Header().Get("Content-Disposition"))
+}
+
+func TestUniHandler_ContentDisposition_NotSetByDefault(t *testing.T) {
+	uniHandler := newInvoiceHandler("")
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/invoices", `{"id":"42"}`).Code)
+
+	assert.Empty(t, serveRequest(uniHandler, http.MethodGet, "/invoices/42", "").Header().Get("Content-Disposition"))
+}
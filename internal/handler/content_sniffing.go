@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// EnableContentTypeSniffing makes the handler infer the content type of request bodies sent without
+// a Content-Type header, so their IDs are extracted and they are stored as JSON or XML
+func (h *UniHandler) EnableContentTypeSniffing() {
+	h.sniffContentType = true
+}
+
+// sniffRequestContentType sets the Content-Type of an untyped request body when sniffing is enabled:
+// a body starting with { or [ is JSON and one starting with < is XML. Other bodies stay untyped.
+func (h *UniHandler) sniffRequestContentType(req *http.Request) {
+	if !h.sniffContentType || req.Body == nil || req.Header.Get(contentTypeHeader) != "" {
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		h.logger.Warn("failed to read request body for content type sniffing", errorLogKey, err)
+		return
+	}
+	if contentType := sniffContentType(body); contentType != "" {
+		req.Header.Set(contentTypeHeader, contentType)
+	}
+}
+
+// sniffContentType returns the content type the body looks like, or "" if it is neither JSON nor XML
+func sniffContentType(body []byte) string {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	if len(trimmed) == 0 {
+		return ""
+	}
+	switch trimmed[0] {
+	case '{', '[':
+		return "application/json"
+	case '<':
+		return "application/xml"
+	default:
+		return ""
+	}
+}
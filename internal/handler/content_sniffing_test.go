@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postUntyped posts a body without a Content-Type header
+func postUntyped(h *handler.UniHandler, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestUniHandler_ContentTypeSniffing(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id", "//id"}})
+	h.EnableContentTypeSniffing()
+
+	tests := []struct {
+		name            string
+		body            string
+		wantLocation    string
+		wantContentType string
+	}{
+		{name: "JSON object", body: ` {"id": "ann"}`, wantLocation: "/users/ann", wantContentType: "application/json"},
+		{name: "XML", body: `<user><id>bob</id></user>`, wantLocation: "/users/bob", wantContentType: "application/xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postUntyped(h, "/users", tt.body)
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+
+			stored := serveRequest(h, http.MethodGet, tt.wantLocation, "")
+			require.Equal(t, http.StatusOK, stored.Code)
+			assert.Equal(t, tt.wantContentType, stored.Header().Get("Content-Type"))
+		})
+	}
+
+	t.Run("other bodies stay untyped", func(t *testing.T) {
+		w := postUntyped(h, "/users", "id=carl")
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.NotEqual(t, "/users/carl", w.Header().Get("Location"))
+	})
+}
+
+func TestUniHandler_ContentTypeSniffingDisabled(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+
+	w := postUntyped(h, "/users", `{"id": "ann"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEqual(t, "/users/ann", w.Header().Get("Location"), "untyped bodies get a generated ID")
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"path"
+)
+
+// extensionContentTypes covers extensions missing from the system MIME tables on minimal hosts
+var extensionContentTypes = map[string]string{
+	".csv": "text/csv; charset=utf-8",
+}
+
+// contentTypeFromExtension sets the Content-Type of successful GET and HEAD responses of sections with
+// content_type_from_extension from the extension of the request path, e.g. text/csv for
+// /files/report.csv, regardless of the stored type. Paths without a known extension keep theirs.
+func (h *UniHandler) contentTypeFromExtension(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices ||
+		(req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || !section.ContentTypeFromExtension {
+		return resp
+	}
+	if contentType := typeByExtension(path.Ext(req.URL.Path)); contentType != "" {
+		resp.Header.Set(contentTypeHeader, contentType)
+	}
+	return resp
+}
+
+// typeByExtension looks the extension up in the system MIME tables, then in extensionContentTypes
+func typeByExtension(ext string) string {
+	if ext == "" {
+		return ""
+	}
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+	return extensionContentTypes[ext]
+}
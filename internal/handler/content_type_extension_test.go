@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func storeFile(t *testing.T, h http.Handler, path, contentType, body string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Less(t, w.Code, 300, w.Body.String())
+}
+
+func TestUniHandler_ContentTypeFromExtension(t *testing.T) {
+	h := newSectionHandler("files", config.Section{PathPattern: "/files/*", ContentTypeFromExtension: true})
+	storeFile(t, h, "/files/report.csv", "application/octet-stream", "id,name\n1,Ann\n")
+	storeFile(t, h, "/files/notes", "text/plain", "no extension")
+
+	w := serveRequest(h, http.MethodGet, "/files/report.csv", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", mediaType)
+	assert.Equal(t, "id,name\n1,Ann\n", w.Body.String())
+
+	w = serveRequest(h, http.MethodHead, "/files/report.csv", "")
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv"), "HEAD gets the same type")
+
+	w = serveRequest(h, http.MethodGet, "/files/notes", "")
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"), "paths without an extension keep the stored type")
+
+	w = serveRequest(h, http.MethodGet, "/files/missing.csv", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.False(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv"), "errors keep their type")
+}
+
+func TestUniHandler_ContentTypeFromExtension_Disabled(t *testing.T) {
+	h := newSectionHandler("files", config.Section{PathPattern: "/files/*"})
+	storeFile(t, h, "/files/report.csv", "application/octet-stream", "id,name\n")
+
+	w := serveRequest(h, http.MethodGet, "/files/report.csv", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"cmp"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+const (
+	// cursorQueryParam carries the opaque cursor returned with the previous page
+	cursorQueryParam = "cursor"
+	// limitQueryParam overrides the section's page size
+	limitQueryParam = "limit"
+	// nextCursorHeader carries the cursor of the next page; absent on the last page
+	nextCursorHeader = "X-Next-Cursor"
+	// defaultPageSize is used when the section does not configure page_size
+	defaultPageSize = 20
+)
+
+// getCursorPage returns the page of resources following the request's cursor.
+// Resources are ordered by their storage creation sequence and the cursor encodes the last sequence
+// served, so the next page starts after it even if earlier resources were deleted in the meantime,
+// and resources created while paging only ever show up on later pages.
+func (h *UniHandler) getCursorPage(
+	req *http.Request, resources []model.UniData, section *config.Section, sectionName string,
+) *http.Response {
+	query := req.URL.Query()
+
+	limit, err := pageLimit(query.Get(limitQueryParam), section.PageSize)
+	if err != nil {
+		return h.errorResponse(http.StatusBadRequest, "invalid limit")
+	}
+	afterSeq, err := decodeCursor(query.Get(cursorQueryParam))
+	if err != nil {
+		return h.errorResponse(http.StatusBadRequest, "invalid cursor")
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Seq != resources[j].Seq {
+			return resources[i].Seq < resources[j].Seq
+		}
+		return compareIDs(resourceID(resources[i]), resourceID(resources[j])) < 0
+	})
+
+	start := sort.Search(len(resources), func(i int) bool {
+		return resources[i].Seq > afterSeq
+	})
+	end := min(start+limit, len(resources))
+	page := resources[start:end]
+
+	transformed, err := h.transformResourceCollection(page, section, sectionName)
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "response transformation failed")
+	}
+	resp := h.buildCollectionResponse(transformed, section)
+
+	if end < len(resources) {
+		cursor := encodeCursor(page[len(page)-1].Seq)
+		next := url.Values{cursorQueryParam: {cursor}, limitQueryParam: {strconv.Itoa(limit)}}
+		resp.Header.Set(nextCursorHeader, cursor)
+		resp.Header.Set("Link", "<"+req.URL.Path+"?"+next.Encode()+`>; rel="next"`)
+	}
+	return resp
+}
+
+// pageLimit parses the limit query parameter, falling back to the section or default page size
+func pageLimit(raw string, pageSize int) (int, error) {
+	if raw == "" {
+		if pageSize > 0 {
+			return pageSize, nil
+		}
+		return defaultPageSize, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return limit, nil
+}
+
+// resourceID returns the primary ID pagination orders by
+func resourceID(resource model.UniData) string {
+	if len(resource.IDs) == 0 {
+		return resource.Location
+	}
+	return resource.IDs[0]
+}
+
+// compareIDs orders integer IDs numerically and other IDs lexically; integers sort first
+func compareIDs(a, b string) int {
+	ai, aErr := strconv.ParseInt(a, 10, 64)
+	bi, bErr := strconv.ParseInt(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(ai, bi)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+// encodeCursor makes an opaque cursor from the creation sequence of the last resource served
+func encodeCursor(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(seq, 10)))
+}
+
+// decodeCursor returns the sequence encoded in a cursor; an empty cursor starts from the beginning
+func decodeCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(raw), 10, 64)
+}
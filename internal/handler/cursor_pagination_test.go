@@ -0,0 +1,151 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaginatedHandler(t *testing.T, count int) *handler.UniHandler {
+	t.Helper()
+	uniHandler := newSectionHandler("items", config.Section{
+		PathPattern:      "/items/*",
+		BodyIDPaths:      []string{"/id"},
+		CursorPagination: true,
+		PageSize:         3,
+	})
+	for i := 1; i <= count; i++ {
+		w := serveRequest(uniHandler, http.MethodPost, "/items", fmt.Sprintf(`{"id":"%d"}`, i))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	return uniHandler
+}
+
+// getPage fetches one page and returns the item IDs and the next page's cursor
+func getPage(t *testing.T, uniHandler http.Handler, path string) ([]string, string) {
+	t.Helper()
+	w := serveRequest(uniHandler, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var items []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids, w.Header().Get("X-Next-Cursor")
+}
+
+func TestUniHandler_CursorPagination(t *testing.T) {
+	uniHandler := newPaginatedHandler(t, 11)
+
+	var seen []string
+	path := "/items"
+	for {
+		ids, cursor := getPage(t, uniHandler, path)
+		seen = append(seen, ids...)
+		if cursor == "" {
+			break
+		}
+		path = "/items?cursor=" + cursor
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}, seen)
+}
+
+func TestUniHandler_CursorPagination_DeletionMidScan(t *testing.T) {
+	uniHandler := newPaginatedHandler(t, 9)
+
+	firstPage, cursor := getPage(t, uniHandler, "/items")
+	require.Equal(t, []string{"1", "2", "3"}, firstPage)
+
+	// Delete an already served item and an upcoming one between page requests
+	for _, id := range []string{"2", "5"} {
+		w := serveRequest(uniHandler, http.MethodDelete, "/items/"+id, "")
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	seen := firstPage
+	for cursor != "" {
+		var ids []string
+		ids, cursor = getPage(t, uniHandler, "/items?cursor="+cursor)
+		seen = append(seen, ids...)
+	}
+
+	// No item is skipped or repeated; only the deleted upcoming item is missing
+	assert.Equal(t, []string{"1", "2", "3", "4", "6", "7", "8", "9"}, seen)
+}
+
+func TestUniHandler_CursorPagination_CreationMidScan(t *testing.T) {
+	const existing, created = 30, 60
+	uniHandler := newPaginatedHandler(t, existing)
+
+	// Negative IDs sort before the existing ones, so only creation order keeps them off served pages
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= created; i++ {
+			serveRequest(uniHandler, http.MethodPost, "/items", fmt.Sprintf(`{"id":"-%d"}`, i))
+		}
+	}()
+
+	var seen []string
+	path := "/items"
+	for {
+		ids, cursor := getPage(t, uniHandler, path)
+		seen = append(seen, ids...)
+		if cursor == "" {
+			break
+		}
+		path = "/items?cursor=" + cursor
+	}
+	wg.Wait()
+
+	// Every existing item is served once, in creation order; new items only follow them
+	counts := make(map[string]int, len(seen))
+	for _, id := range seen {
+		counts[id]++
+	}
+	for id, count := range counts {
+		assert.Equal(t, 1, count, "item %s served more than once", id)
+	}
+	require.GreaterOrEqual(t, len(seen), existing)
+	for i := 0; i < existing; i++ {
+		assert.Equal(t, strconv.Itoa(i+1), seen[i])
+	}
+	for i, id := range seen[existing:] {
+		assert.Equal(t, strconv.Itoa(-(i + 1)), id)
+	}
+}
+
+func TestUniHandler_CursorPagination_LimitAndLink(t *testing.T) {
+	uniHandler := newPaginatedHandler(t, 5)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/items?limit=2", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1"},{"id":"2"}]`, w.Body.String())
+	cursor := w.Header().Get("X-Next-Cursor")
+	require.NotEmpty(t, cursor)
+	assert.Equal(t, `</items?cursor=`+cursor+`&limit=2>; rel="next"`, w.Header().Get("Link"))
+}
+
+func TestUniHandler_CursorPagination_InvalidParams(t *testing.T) {
+	uniHandler := newPaginatedHandler(t, 2)
+
+	for _, path := range []string{"/items?cursor=not*base64", "/items?cursor=YWJj", "/items?limit=0", "/items?limit=abc"} {
+		w := serveRequest(uniHandler, http.MethodGet, path, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
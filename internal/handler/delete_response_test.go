@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_DeleteResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		returnBody     bool
+		deleteResponse *config.DeleteResponse
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "default is 204 without extra headers",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "202 for asynchronous deletes",
+			deleteResponse: &config.DeleteResponse{StatusCode: http.StatusAccepted},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "200 with the return_body body",
+			returnBody:     true,
+			deleteResponse: &config.DeleteResponse{StatusCode: http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "{}",
+		},
+		{
+			name:           "304 never carries a body",
+			returnBody:     true,
+			deleteResponse: &config.DeleteResponse{StatusCode: http.StatusNotModified},
+			expectedStatus: http.StatusNotModified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newSectionHandler("items", config.Section{
+				PathPattern:    "/items/*",
+				BodyIDPaths:    []string{"/id"},
+				ReturnBody:     tt.returnBody,
+				DeleteResponse: tt.deleteResponse,
+			})
+			w := serveRequest(uniHandler, http.MethodPut, "/items/1", `{"id":"1"}`)
+			require.Less(t, w.Code, 300)
+
+			w = serveRequest(uniHandler, http.MethodDelete, "/items/1", "")
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestUniHandler_DeleteResponse_Headers(t *testing.T) {
+	uniHandler := newSectionHandler("items", config.Section{
+		PathPattern: "/items/*",
+		BodyIDPaths: []string{"/id"},
+		DeleteResponse: &config.DeleteResponse{
+			Headers: map[string]string{"X-Deleted-Count": "1"},
+		},
+	})
+	serveRequest(uniHandler, http.MethodPut, "/items/1", `{"id":"1"}`)
+
+	w := serveRequest(uniHandler, http.MethodDelete, "/items/1", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Deleted-Count"))
+
+	w = serveRequest(uniHandler, http.MethodDelete, "/items/1", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("X-Deleted-Count"), "failed deletes get no extra headers")
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+)
+
+// checkDependency answers requests to a section whose depends_on_resource_at resource does not
+// exist yet with 503 Service Unavailable, simulating a backend waiting for its dependency
+func (h *UniHandler) checkDependency(ctx context.Context, req *http.Request) *http.Response {
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.DependsOnResourceAt == "" || h.resourceExists(ctx, section.DependsOnResourceAt) {
+		return nil
+	}
+	h.logger.Debug("dependency not ready", pathLogKey, req.URL.Path, "dependency", section.DependsOnResourceAt)
+	return h.errorResponse(http.StatusServiceUnavailable,
+		"service unavailable: waiting for "+section.DependsOnResourceAt)
+}
+
+// resourceExists reports whether a resource is stored at the path: an individual resource addressed
+// by its ID, or any resource when the path names a collection
+func (h *UniHandler) resourceExists(ctx context.Context, resourcePath string) bool {
+	section, sectionName, err := h.findSection(resourcePath)
+	if err != nil {
+		return false
+	}
+	if id := h.extractLastPathSegment(resourcePath); id != "" && id != sectionName {
+		if _, err := h.service.GetResource(ctx, sectionName, section.StrictPath, id); err == nil {
+			return true
+		}
+	}
+	resources, err := h.service.GetResourcesByPath(ctx, resourcePath)
+	return err == nil && len(resources) > 0
+}
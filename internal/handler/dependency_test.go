@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDependencyHandler(dependsOn string) *handler.UniHandler {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"databases": {
+				PathPattern: "/databases/*",
+				BodyIDPaths: []string{"/id"},
+			},
+			"orders": {
+				PathPattern:         "/orders/*",
+				BodyIDPaths:         []string{"/id"},
+				DependsOnResourceAt: dependsOn,
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	return handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+}
+
+func TestUniHandler_DependsOnResourceAt(t *testing.T) {
+	uniHandler := newDependencyHandler("/databases/primary")
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "/databases/primary")
+	assert.Equal(t, http.StatusServiceUnavailable, serveRequest(uniHandler, http.MethodGet, "/orders/1", "").Code)
+
+	// Another resource in the dependency's section does not satisfy it
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/databases", `{"id":"replica"}`).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1"}`).Code)
+
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/databases", `{"id":"primary"}`).Code)
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1"}`).Code)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/orders/1", "").Code)
+
+	// Removing the dependency makes the section unavailable again
+	require.Equal(t, http.StatusNoContent, serveRequest(uniHandler, http.MethodDelete, "/databases/primary", "").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveRequest(uniHandler, http.MethodGet, "/orders/1", "").Code)
+}
+
+func TestUniHandler_DependsOnCollection(t *testing.T) {
+	uniHandler := newDependencyHandler("/databases")
+
+	assert.Equal(t, http.StatusServiceUnavailable, serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1"}`).Code)
+
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/databases", `{"id":"any"}`).Code)
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1"}`).Code)
+}
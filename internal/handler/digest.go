@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+)
+
+const (
+	digestHeader = "Digest"
+
+	// digestAlgorithm is the RFC 5843 name of the algorithm used in the Digest header
+	digestAlgorithm = "sha-256"
+)
+
+// addDigest adds an RFC 3230 Digest header with the SHA-256 of the response body to sections with
+// digest_header enabled. HEAD and 304 responses carry no body and are left alone.
+func (h *UniHandler) addDigest(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || req.Method == http.MethodHead || resp.StatusCode == http.StatusNotModified {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || !section.DigestHeader {
+		return resp
+	}
+
+	body, err := bufferResponseBody(resp)
+	if err != nil {
+		h.logger.Error("failed to read response body for digest", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "response digest failed")
+	}
+
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set(digestHeader, computeDigest(body))
+	return resp
+}
+
+// computeDigest returns the Digest header value for body, e.g. "sha-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+func computeDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return digestAlgorithm + "=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// bufferResponseBody reads the whole response body and replaces it with an in-memory copy,
+// so headers computed over the body cover exactly what is served
+func bufferResponseBody(resp *http.Response) ([]byte, error) {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectedDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestUniHandler_DigestHeader(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:  "/users/*",
+		BodyIDPaths:  []string{"/id"},
+		DigestHeader: true,
+	})
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Alice"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "resource", path: "/users/1", wantStatus: http.StatusOK},
+		{name: "collection", path: "/users", wantStatus: http.StatusOK},
+		{name: "error body", path: "/users/404", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRequest(uniHandler, http.MethodGet, tt.path, "")
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.NotEmpty(t, w.Body.Bytes())
+			assert.Equal(t, expectedDigest(w.Body.Bytes()), w.Header().Get("Digest"))
+		})
+	}
+}
+
+func TestUniHandler_DigestHeader_Disabled(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern: "/users/*",
+		BodyIDPaths: []string{"/id"},
+	})
+	serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+
+	assert.Empty(t, w.Header().Get("Digest"))
+}
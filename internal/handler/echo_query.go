@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// echoQueryField is the JSON field that carries the echoed query parameters
+const echoQueryField = "_query"
+
+// echoQueryParams adds the request's query parameters to the JSON response object, or to each object
+// of a collection, under "_query". Parameters given once become strings, repeated ones arrays.
+func (h *UniHandler) echoQueryParams(resp *http.Response, req *http.Request, section *config.Section) *http.Response {
+	if !section.EchoQueryInBody {
+		return resp
+	}
+
+	query := make(map[string]any)
+	for name, values := range req.URL.Query() {
+		if len(values) == 1 {
+			query[name] = values[0]
+		} else {
+			query[name] = values
+		}
+	}
+	return h.rewriteResponseObjects(resp, func(obj map[string]any) {
+		obj[echoQueryField] = query
+	})
+}
@@ -0,0 +1,45 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoQueryHandler(t *testing.T, echo bool) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:     "/users/*",
+		BodyIDPaths:     []string{"/id"},
+		EchoQueryInBody: echo,
+	})
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`).Code)
+	return uniHandler
+}
+
+func TestUniHandler_EchoQueryInBody_Resource(t *testing.T) {
+	uniHandler := newEchoQueryHandler(t, true)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1?expand=true&tag=a&tag=b", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","_query":{"expand":"true","tag":["a","b"]}}`, w.Body.String())
+}
+
+func TestUniHandler_EchoQueryInBody_Collection(t *testing.T) {
+	uniHandler := newEchoQueryHandler(t, true)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users?page=2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1","_query":{"page":"2"}}]`, w.Body.String())
+}
+
+func TestUniHandler_EchoQueryInBody_Disabled(t *testing.T) {
+	uniHandler := newEchoQueryHandler(t, false)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1?expand=true", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+}
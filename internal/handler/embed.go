@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// embedQueryParam names the query parameter listing sub-collections to embed
+const embedQueryParam = "embed"
+
+// embedSubResources embeds the requested sub-collections (e.g. /users/123/orders for ?embed=orders)
+// into an individual resource response. Only sub-resources listed in section.Embeddable are embedded.
+func (h *UniHandler) embedSubResources(
+	ctx context.Context, resp *http.Response, req *http.Request, section *config.Section,
+) *http.Response {
+	names := requestedEmbeds(req, section)
+	if len(names) == 0 {
+		return resp
+	}
+
+	embedded := make(map[string][]json.RawMessage, len(names))
+	for _, name := range names {
+		embedded[name] = h.loadSubCollection(ctx, path.Join(req.URL.Path, name))
+	}
+
+	return h.rewriteResponseObjects(resp, func(obj map[string]any) {
+		for name, items := range embedded {
+			obj[name] = items
+		}
+	})
+}
+
+// requestedEmbeds returns the embeddable sub-resource names requested via the embed query parameter
+func requestedEmbeds(req *http.Request, section *config.Section) []string {
+	if len(section.Embeddable) == 0 {
+		return nil
+	}
+	var names []string
+	for _, value := range req.URL.Query()[embedQueryParam] {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			for _, allowed := range section.Embeddable {
+				if name == allowed {
+					names = append(names, name)
+					break
+				}
+			}
+		}
+	}
+	return names
+}
+
+// loadSubCollection returns the JSON bodies of resources stored at the collection path
+func (h *UniHandler) loadSubCollection(ctx context.Context, collectionPath string) []json.RawMessage {
+	items := []json.RawMessage{}
+	resources, err := h.service.GetResourcesByPath(ctx, collectionPath)
+	if err != nil {
+		h.logger.Debug("failed to load sub-collection for embedding", pathLogKey, collectionPath, errorLogKey, err)
+		return items
+	}
+	for _, resource := range resources {
+		if json.Valid(resource.Body) {
+			items = append(items, json.RawMessage(resource.Body))
+		}
+	}
+	return items
+}
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEmbedHandler(t *testing.T) http.Handler {
+	t.Helper()
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {
+				PathPattern: "/users/*",
+				BodyIDPaths: []string{"/id"},
+				Embeddable:  []string{"orders"},
+			},
+			"user_orders": {
+				PathPattern:    "/users/*/orders/*",
+				BodyIDPaths:    []string{"/id"},
+				PathIDSegments: []int{1, 3},
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+
+	for _, tc := range []struct{ path, body string }{
+		{"/users", `{"id":"123","name":"Alice"}`},
+		{"/users/123/orders", `{"id":"o1","total":10}`},
+	} {
+		w := serveRequest(uniHandler, http.MethodPost, tc.path, tc.body)
+		require.Equal(t, http.StatusCreated, w.Code, tc.path)
+	}
+	return uniHandler
+}
+
+func TestUniHandler_Embed_IncludesRequestedSubCollection(t *testing.T) {
+	uniHandler := newEmbedHandler(t)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/123?embed=orders", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"123","name":"Alice","orders":[{"id":"o1","total":10}]}`, w.Body.String())
+}
+
+func TestUniHandler_Embed_AbsentWhenNotRequested(t *testing.T) {
+	uniHandler := newEmbedHandler(t)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/123", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"123","name":"Alice"}`, w.Body.String())
+}
+
+func TestUniHandler_Embed_IgnoresNonEmbeddable(t *testing.T) {
+	uniHandler := newEmbedHandler(t)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/123?embed=payments", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"123","name":"Alice"}`, w.Body.String())
+}
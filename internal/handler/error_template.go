@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// applyErrorTemplate reformats plain-text error responses (as built by errorResponse, which sets no
+// Content-Type) using the matched section's error template. Other responses pass through unchanged.
+func (h *UniHandler) applyErrorTemplate(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest || req.Method == http.MethodHead ||
+		resp.Header.Get(contentTypeHeader) != "" {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.ErrorTemplate == nil {
+		return resp
+	}
+
+	var message []byte
+	if resp.Body != nil {
+		message, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			h.logger.Error("failed to read error response body", errorLogKey, err)
+		}
+	}
+
+	contentType := section.ErrorTemplate.ContentType
+	if contentType == "" {
+		contentType = applicationJSON
+	}
+	body := renderErrorTemplate(section.ErrorTemplate, contentType, resp.StatusCode, string(message))
+
+	resp.Header.Set(contentTypeHeader, contentType)
+	resp.Body = io.NopCloser(strings.NewReader(body))
+	return resp
+}
+
+// renderErrorTemplate interpolates the status, status code name and message into the template body
+func renderErrorTemplate(tmpl *config.ErrorTemplate, contentType string, status int, message string) string {
+	return strings.NewReplacer(
+		config.ErrorPlaceholderStatus, strconv.Itoa(status),
+		config.ErrorPlaceholderCode, statusCodeName(status),
+		config.ErrorPlaceholderMessage, escapeForContentType(contentType, message),
+	).Replace(tmpl.Body)
+}
+
+// statusCodeName converts the status text to upper snake case, e.g. 404 to NOT_FOUND
+func statusCodeName(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// escapeForContentType escapes the message for embedding in a JSON string or XML text
+func escapeForContentType(contentType, message string) string {
+	switch {
+	case strings.Contains(contentType, "json"):
+		quoted, err := json.Marshal(message)
+		if err != nil {
+			return message
+		}
+		return string(quoted[1 : len(quoted)-1])
+	case strings.Contains(contentType, "xml"):
+		var buf bytes.Buffer
+		if err := xml.EscapeText(&buf, []byte(message)); err != nil {
+			return message
+		}
+		return buf.String()
+	default:
+		return message
+	}
+}
@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniHandler_ErrorTemplate(t *testing.T) {
+	tests := []struct {
+		name            string
+		template        *config.ErrorTemplate
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "plain text by default",
+			wantContentType: "",
+			wantBody:        "resource not found",
+		},
+		{
+			name: "JSON envelope",
+			template: &config.ErrorTemplate{
+				Body: `{"error": {"status": {{status}}, "code": "{{code}}", "message": "{{message}}"}}`,
+			},
+			wantContentType: "application/json",
+			wantBody:        `{"error": {"status": 404, "code": "NOT_FOUND", "message": "resource not found"}}`,
+		},
+		{
+			name: "XML envelope",
+			template: &config.ErrorTemplate{
+				ContentType: "application/xml",
+				Body:        `<error><code>{{code}}</code><message>{{message}}</message></error>`,
+			},
+			wantContentType: "application/xml",
+			wantBody:        `<error><code>NOT_FOUND</code><message>resource not found</message></error>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newSectionHandler("users", config.Section{
+				PathPattern:   "/users/*",
+				BodyIDPaths:   []string{"/id"},
+				ErrorTemplate: tt.template,
+			})
+
+			w := serveRequest(uniHandler, http.MethodGet, "/users/404", "")
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestUniHandler_ErrorTemplate_BadRequest(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:   "/users/*",
+		BodyIDPaths:   []string{"/id"},
+		ErrorTemplate: &config.ErrorTemplate{Body: `{"message": "{{message}}"}`},
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id": "1"`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"message": "invalid request: failed to parse JSON body"}`, w.Body.String())
+}
+
+func TestUniHandler_ErrorTemplate_SuccessUnchanged(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:   "/users/*",
+		BodyIDPaths:   []string{"/id"},
+		ErrorTemplate: &config.ErrorTemplate{Body: `{"error": "{{message}}"}`},
+	})
+	serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+}
+
+func TestUniHandler_ErrorTemplate_MethodNotAllowed(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:   "/users/*",
+		BodyIDPaths:   []string{"/id"},
+		ErrorTemplate: &config.ErrorTemplate{Body: `{"code": "{{code}}", "message": "{{message}}"}`},
+	})
+
+	w := serveRequest(uniHandler, http.MethodPatch, "/users/1", `{"name": "x"}`)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, handler.AllowedMethods, w.Header().Get("Allow"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"code": "METHOD_NOT_ALLOWED", "message": "method not allowed"}`, w.Body.String())
+}
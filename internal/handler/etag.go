@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+const (
+	etagHeader        = "ETag"
+	versionHeader     = "X-Resource-Version"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+
+	// weakETagPrefix marks weak entity tags (RFC 9110 section 8.8.3)
+	weakETagPrefix = "W/"
+
+	// strongETagBytes is how many bytes of the body's SHA-256 a strong ETag carries
+	strongETagBytes = 16
+)
+
+// resourceETag returns the entity tag of a stored resource in the given strength.
+// Strong tags hash the stored bytes; weak tags derive from the modification time and size,
+// falling back to the byte hash for resources without a recorded write time.
+func resourceETag(resource model.UniData, strength string) string {
+	sum := sha256.Sum256(resource.Body)
+	strong := `"` + hex.EncodeToString(sum[:strongETagBytes]) + `"`
+	if strength == config.ETagStrong {
+		return strong
+	}
+	if resource.WrittenAt.IsZero() {
+		return weakETagPrefix + strong
+	}
+	return fmt.Sprintf(`%s"%x-%x"`, weakETagPrefix, resource.WrittenAt.UnixNano(), len(resource.Body))
+}
+
+// currentState returns the ETag and version of the individual resource addressed by the request,
+// or "" and 0 when the request does not address an existing resource
+func (h *UniHandler) currentState(ctx context.Context, req *http.Request) (etag string, version int) {
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil {
+		return "", 0
+	}
+
+	id := h.extractLastPathSegment(req.URL.Path)
+	if hasCompositeIDs(section) {
+		id = ""
+		if ids := extractCompositePathIDs(section, req.URL.Path); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	if id == "" || id == sectionName {
+		return "", 0
+	}
+
+	sourceSection, sourceName := h.readSource(section, sectionName)
+	resource, err := h.service.GetResource(ctx, sourceName, sourceSection.StrictPath, id)
+	if err != nil || !readVisible(resource, section.ReadDelay) {
+		return "", 0
+	}
+	return resourceETag(resource, section.ETagStrength), resource.Version
+}
+
+// checkPreconditions evaluates conditional request headers against the resource's current ETag.
+// GET/HEAD with a matching If-None-Match get 304 Not Modified; PUT/DELETE get 412 Precondition Failed
+// when If-Match does not strongly match or If-None-Match matches. If-Match versions of PUT are left to
+// the conditional update (see ifMatchVersion). It returns nil when the request proceeds.
+func (h *UniHandler) checkPreconditions(req *http.Request, etag string) *http.Response {
+	ifMatch := req.Header.Get(ifMatchHeader)
+	ifNoneMatch := req.Header.Get(ifNoneMatchHeader)
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		if ifNoneMatch != "" && etagListMatches(ifNoneMatch, etag, false) {
+			return notModifiedResponse(etag)
+		}
+	case http.MethodPut, http.MethodDelete:
+		if _, versioned := ifMatchVersion(req); ifMatch != "" && !versioned && !etagListMatches(ifMatch, etag, true) {
+			return h.errorResponse(http.StatusPreconditionFailed, "precondition failed: If-Match")
+		}
+		if ifNoneMatch != "" && etagListMatches(ifNoneMatch, etag, false) {
+			return h.errorResponse(http.StatusPreconditionFailed, "precondition failed: If-None-Match")
+		}
+	}
+	return nil
+}
+
+// etagListMatches reports whether the comma-separated entity tag list of a conditional header
+// matches etag. "*" matches any existing resource. Strong comparison fails for weak tags;
+// weak comparison ignores the W/ prefix.
+func etagListMatches(list, etag string, strongComparison bool) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strongComparison {
+			if candidate == etag && !strings.HasPrefix(etag, weakETagPrefix) {
+				return true
+			}
+			continue
+		}
+		if strings.TrimPrefix(candidate, weakETagPrefix) == strings.TrimPrefix(etag, weakETagPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedResponse builds a body-less 304 response carrying the current ETag
+func notModifiedResponse(etag string) *http.Response {
+	resp := &http.Response{
+		StatusCode: http.StatusNotModified,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	resp.Header.Set(etagHeader, etag)
+	return resp
+}
+
+// setResourceState adds the resource's ETag and X-Resource-Version to successful GET, HEAD and PUT
+// responses; PUT reports the tag and version of the newly written representation
+func (h *UniHandler) setResourceState(
+	ctx context.Context, req *http.Request, resp *http.Response, etag string, version int,
+) {
+	if resp == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+	switch req.Method {
+	case http.MethodPut:
+		etag, version = h.currentState(ctx, req)
+	case http.MethodGet, http.MethodHead:
+	default:
+		return
+	}
+	if etag != "" {
+		resp.Header.Set(etagHeader, etag)
+	}
+	if version > 0 {
+		resp.Header.Set(versionHeader, strconv.Itoa(version))
+	}
+}
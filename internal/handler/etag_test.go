@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveConditional sends a request with a single conditional header to the handler
+func serveConditional(
+	uniHandler *handler.UniHandler, method, path, body, header, value string,
+) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(header, value)
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	return w
+}
+
+func newETagHandler(t *testing.T, strength string) *handler.UniHandler {
+	t.Helper()
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:  "/users/*",
+		BodyIDPaths:  []string{"/id"},
+		ETagStrength: strength,
+	})
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Alice"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	return uniHandler
+}
+
+func TestUniHandler_ETagFormat(t *testing.T) {
+	tests := []struct {
+		strength        string
+		wantFormat      *regexp.Regexp
+		stableOnRewrite bool
+	}{
+		{strength: "", wantFormat: regexp.MustCompile(`^W/"[0-9a-f]+-[0-9a-f]+"$`)},
+		{strength: config.ETagWeak, wantFormat: regexp.MustCompile(`^W/"[0-9a-f]+-[0-9a-f]+"$`)},
+		{strength: config.ETagStrong, wantFormat: regexp.MustCompile(`^"[0-9a-f]{32}"$`), stableOnRewrite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run("strength "+tt.strength, func(t *testing.T) {
+			uniHandler := newETagHandler(t, tt.strength)
+
+			etag := serveRequest(uniHandler, http.MethodGet, "/users/1", "").Header().Get("ETag")
+			assert.Regexp(t, tt.wantFormat, etag)
+			assert.Equal(t, etag, serveRequest(uniHandler, http.MethodHead, "/users/1", "").Header().Get("ETag"))
+
+			// Rewriting identical bytes keeps a strong tag but moves the modification time of a weak one
+			time.Sleep(time.Millisecond)
+			put := serveRequest(uniHandler, http.MethodPut, "/users/1", `{"id":"1","name":"Alice"}`)
+			require.Equal(t, http.StatusOK, put.Code)
+			rewritten := serveRequest(uniHandler, http.MethodGet, "/users/1", "").Header().Get("ETag")
+			assert.Equal(t, rewritten, put.Header().Get("ETag"))
+			assert.Equal(t, tt.stableOnRewrite, rewritten == etag)
+		})
+	}
+}
+
+func TestUniHandler_ETagConditionalRequests(t *testing.T) {
+	tests := []struct {
+		strength string
+		// ifMatchOwnTag is the PUT status when If-Match carries the current tag:
+		// strong comparison never matches a weak tag
+		ifMatchOwnTag int
+	}{
+		{strength: config.ETagWeak, ifMatchOwnTag: http.StatusPreconditionFailed},
+		{strength: config.ETagStrong, ifMatchOwnTag: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strength, func(t *testing.T) {
+			uniHandler := newETagHandler(t, tt.strength)
+			etag := serveRequest(uniHandler, http.MethodGet, "/users/1", "").Header().Get("ETag")
+			body := `{"id":"1","name":"Bob"}`
+
+			notModified := serveConditional(uniHandler, http.MethodGet, "/users/1", "", "If-None-Match", etag)
+			assert.Equal(t, http.StatusNotModified, notModified.Code)
+			assert.Empty(t, notModified.Body.String())
+			assert.Equal(t, etag, notModified.Header().Get("ETag"))
+
+			// If-None-Match uses weak comparison, so the opaque tag matches with or without W/
+			opaque := strings.TrimPrefix(etag, "W/")
+			assert.Equal(t, http.StatusNotModified,
+				serveConditional(uniHandler, http.MethodGet, "/users/1", "", "If-None-Match", "W/"+opaque).Code)
+			assert.Equal(t, http.StatusOK,
+				serveConditional(uniHandler, http.MethodGet, "/users/1", "", "If-None-Match", `"other"`).Code)
+
+			assert.Equal(t, http.StatusPreconditionFailed,
+				serveConditional(uniHandler, http.MethodPut, "/users/1", body, "If-None-Match", "*").Code)
+			assert.Equal(t, http.StatusPreconditionFailed,
+				serveConditional(uniHandler, http.MethodDelete, "/users/1", "", "If-Match", `"other"`).Code)
+			assert.Equal(t, tt.ifMatchOwnTag,
+				serveConditional(uniHandler, http.MethodPut, "/users/1", body, "If-Match", etag).Code)
+			assert.Equal(t, http.StatusOK,
+				serveConditional(uniHandler, http.MethodPut, "/users/1", body, "If-Match", "*").Code)
+			assert.Equal(t, http.StatusPreconditionFailed,
+				serveConditional(uniHandler, http.MethodPut, "/users/2", body, "If-Match", "*").Code)
+		})
+	}
+}
+
+func TestUniHandler_PutIfNoneMatchCreatesOnlyIfAbsent(t *testing.T) {
+	uniHandler := newETagHandler(t, config.ETagStrong)
+
+	created := serveConditional(uniHandler, http.MethodPut, "/users/2", `{"id":"2","name":"Bob"}`, "If-None-Match", "*")
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	assert.NotEmpty(t, created.Header().Get("ETag"))
+	stored := serveRequest(uniHandler, http.MethodGet, "/users/2", "")
+	require.Equal(t, http.StatusOK, stored.Code)
+	assert.JSONEq(t, `{"id":"2","name":"Bob"}`, stored.Body.String())
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		w := serveConditional(uniHandler, http.MethodPut, path, `{"name":"Mallory"}`, "If-None-Match", "*")
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, path)
+	}
+	assert.JSONEq(t, `{"id":"2","name":"Bob"}`, serveRequest(uniHandler, http.MethodGet, "/users/2", "").Body.String(),
+		"the existing resource is left untouched")
+}
+
+func TestUniHandler_PutIfMatchVersionUpdatesOnlyAtVersion(t *testing.T) {
+	uniHandler := newETagHandler(t, config.ETagStrong)
+	assert.Equal(t, "1", serveRequest(uniHandler, http.MethodGet, "/users/1", "").Header().Get("X-Resource-Version"))
+
+	updated := serveConditional(uniHandler, http.MethodPut, "/users/1", `{"id":"1","name":"Bob"}`, "If-Match", "1")
+	require.Equal(t, http.StatusOK, updated.Code, updated.Body.String())
+	assert.Equal(t, "2", updated.Header().Get("X-Resource-Version"), "PUT reports the new version")
+
+	stale := serveConditional(uniHandler, http.MethodPut, "/users/1", `{"id":"1","name":"Mallory"}`, "If-Match", "1")
+	assert.Equal(t, http.StatusPreconditionFailed, stale.Code)
+	assert.Contains(t, stale.Body.String(), "version 2")
+	assert.JSONEq(t, `{"id":"1","name":"Bob"}`, serveRequest(uniHandler, http.MethodGet, "/users/1", "").Body.String(),
+		"the newer version is left untouched")
+
+	current := serveConditional(uniHandler, http.MethodPut, "/users/1", `{"id":"1","name":"Carol"}`, "If-Match", "2")
+	assert.Equal(t, http.StatusOK, current.Code)
+	assert.Equal(t, "3", serveRequest(uniHandler, http.MethodGet, "/users/1", "").Header().Get("X-Resource-Version"))
+
+	missing := serveConditional(uniHandler, http.MethodPut, "/users/2", `{"id":"2","name":"Dave"}`, "If-Match", "1")
+	assert.Equal(t, http.StatusPreconditionFailed, missing.Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/users/2", "").Code,
+		"conditional updates never create resources")
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// FailureHandler handles endpoints for managing forced failures
+type FailureHandler struct {
+	prefix  string
+	service *service.FailureService
+	logger  *slog.Logger
+}
+
+// NewFailureHandler creates a new instance of FailureHandler
+func NewFailureHandler(failureSvc *service.FailureService, logger *slog.Logger) *FailureHandler {
+	return &FailureHandler{
+		prefix:  "/_uni/failures",
+		service: failureSvc,
+		logger:  logger,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *FailureHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("failure endpoint request",
+		"method", r.Method,
+		"path", r.URL.Path)
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, h.prefix), "/")
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		h.writeJSON(w, h.service.ListFailures(r.Context()), http.StatusOK)
+	case r.Method == http.MethodPost && id == "":
+		h.handleCreate(w, r)
+	case r.Method == http.MethodDelete && id != "":
+		h.handleDelete(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *FailureHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(strings.ToLower(r.Header.Get(contentTypeHeader)), applicationJSON) {
+		http.Error(w, "Unsupported Media Type: Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var failure model.Failure
+	if err := json.NewDecoder(r.Body).Decode(&failure); err != nil {
+		h.logger.Error("failed to unmarshal failure", errorLogKey, err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateFailure(r.Context(), failure)
+	if err != nil {
+		h.logger.Error("failed to create failure", errorLogKey, err, "id", failure.ID)
+		if strings.Contains(err.Error(), "already exists") {
+			http.Error(w, "Failure already exists", http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.Header().Set("Location", h.prefix+"/"+created.ID)
+	h.writeJSON(w, created, http.StatusCreated)
+}
+
+func (h *FailureHandler) handleDelete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.service.DeleteFailure(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete failure", errorLogKey, err, "id", id)
+		http.Error(w, "Failure not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes data as JSON response
+func (h *FailureHandler) writeJSON(w http.ResponseWriter, data any, statusCode int) {
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to write failure response", errorLogKey, err)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+const (
+	// authScopeHeader carries the caller's auth scopes when no bearer token is used
+	authScopeHeader = "X-Auth-Scope"
+	// bearerPrefix is the Authorization header prefix of bearer tokens
+	bearerPrefix = "Bearer "
+	// defaultScope names the scope used when the request carries no configured scope
+	defaultScope = "default"
+)
+
+// projectFieldScopes limits JSON response fields to those visible for the request's auth scopes
+func (h *UniHandler) projectFieldScopes(
+	resp *http.Response,
+	req *http.Request,
+	section *config.Section,
+) *http.Response {
+	if len(section.FieldScopes) == 0 {
+		return resp
+	}
+	visible := visibleFields(section.FieldScopes, requestScopes(req))
+	return h.rewriteResponseObjects(resp, func(obj map[string]any) {
+		for field := range obj {
+			if !visible[field] {
+				delete(obj, field)
+			}
+		}
+	})
+}
+
+// requestScopes returns the auth scopes carried by the request
+func requestScopes(req *http.Request) []string {
+	raw := req.Header.Get(authScopeHeader)
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, bearerPrefix) {
+		raw += " " + strings.TrimPrefix(auth, bearerPrefix)
+	}
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
+// visibleFields returns the union of fields visible for the given scopes
+func visibleFields(fieldScopes map[string][]string, scopes []string) map[string]bool {
+	visible := make(map[string]bool)
+	matched := false
+	for _, scope := range scopes {
+		fields, ok := fieldScopes[scope]
+		if !ok {
+			continue
+		}
+		matched = true
+		for _, field := range fields {
+			visible[field] = true
+		}
+	}
+	if !matched {
+		for _, field := range fieldScopes[defaultScope] {
+			visible[field] = true
+		}
+	}
+	return visible
+}
@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFieldScopesHandler(t *testing.T) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern: "/users/*",
+		BodyIDPaths: []string{"/id"},
+		FieldScopes: map[string][]string{
+			"admin": {"id", "name", "ssn"},
+			"basic": {"id", "name"},
+		},
+	})
+	created := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Alice","ssn":"123-45-6789"}`)
+	require.Equal(t, http.StatusCreated, created.Code)
+	return uniHandler
+}
+
+func getWithHeader(uniHandler http.Handler, path, header, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	req.Header.Set(header, value)
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	return w
+}
+
+func TestUniHandler_FieldScopes_AdminSeesRestrictedFields(t *testing.T) {
+	uniHandler := newFieldScopesHandler(t)
+
+	w := getWithHeader(uniHandler, "/users/1", "Authorization", "Bearer admin")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","name":"Alice","ssn":"123-45-6789"}`, w.Body.String())
+}
+
+func TestUniHandler_FieldScopes_BasicHidesRestrictedFields(t *testing.T) {
+	uniHandler := newFieldScopesHandler(t)
+
+	w := getWithHeader(uniHandler, "/users/1", "X-Auth-Scope", "basic")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","name":"Alice"}`, w.Body.String())
+}
+
+func TestUniHandler_FieldScopes_Collection(t *testing.T) {
+	uniHandler := newFieldScopesHandler(t)
+
+	w := getWithHeader(uniHandler, "/users", "Authorization", "Bearer basic")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1","name":"Alice"}]`, w.Body.String())
+}
+
+func TestUniHandler_FieldScopes_UnknownScopeSeesNoFields(t *testing.T) {
+	uniHandler := newFieldScopesHandler(t)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{}`, w.Body.String())
+}
@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_GraphQLResponse_GET(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:     "/users/*",
+		BodyIDPaths:     []string{"/id"},
+		GraphQLResponse: "user",
+	})
+	created := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Alice"}`)
+	require.Equal(t, http.StatusCreated, created.Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":{"user":{"id":"1","name":"Alice"}}}`, w.Body.String())
+}
+
+func TestUniHandler_GraphQLResponse_Collection(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:     "/users/*",
+		BodyIDPaths:     []string{"/id"},
+		GraphQLResponse: "users",
+	})
+	serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"users":[{"id":"1"}]}}`, w.Body.String())
+}
+
+func TestUniHandler_GraphQLResponse_NotFoundHasErrors(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:     "/users/*",
+		GraphQLResponse: "user",
+	})
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/missing", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"data":{"user":null},"errors":[{"message":"resource not found"}]}`, w.Body.String())
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// groupByQueryParam names the query parameter carrying the field to group a collection by
+const groupByQueryParam = "groupBy"
+
+// getGroupedCollection returns the collection as a JSON object mapping each value of the top-level
+// field to the resources carrying it. String values are used as is and other values by their JSON
+// text, while resources without the field are grouped under the empty key.
+func (h *UniHandler) getGroupedCollection(
+	resources []model.UniData, field string, section *config.Section, sectionName string,
+) *http.Response {
+	transformed, err := h.transformResourceCollection(resources, section, sectionName)
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "response transformation failed")
+	}
+	items := h.extractJSONItems(transformed)
+	if section.DisableHTMLEscape {
+		items = h.unescapeHTMLItems(items)
+	}
+
+	groups := make(map[string][]json.RawMessage)
+	for _, item := range items {
+		key := groupKey(item, field)
+		groups[key] = append(groups[key], json.RawMessage(item))
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	encoder.SetEscapeHTML(!section.DisableHTMLEscape)
+	if err := encoder.Encode(groups); err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(bytes.TrimSuffix(body.Bytes(), []byte("\n")))),
+	}
+}
+
+// groupKey returns the group of a JSON item by its top-level field
+func groupKey(item []byte, field string) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(item, &fields); err != nil {
+		return ""
+	}
+	raw, ok := fields[field]
+	if !ok || string(raw) == "null" {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+	return string(raw)
+}
@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGroupByHandler(t *testing.T, allowGroupBy bool) *handler.UniHandler {
+	t.Helper()
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:  "/users/*",
+		BodyIDPaths:  []string{"/id"},
+		AllowGroupBy: allowGroupBy,
+	})
+	for _, body := range []string{
+		`{"id":"1","department":"engineering","level":2}`,
+		`{"id":"2","department":"sales","level":1}`,
+		`{"id":"3","department":"engineering","level":1}`,
+		`{"id":"4","level":3}`,
+	} {
+		w := serveRequest(uniHandler, http.MethodPost, "/users", body)
+		require.Equal(t, http.StatusCreated, w.Code, body)
+	}
+	return uniHandler
+}
+
+func TestUniHandler_GroupBy(t *testing.T) {
+	uniHandler := newGroupByHandler(t, true)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users?groupBy=department", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"engineering": [
+			{"id":"1","department":"engineering","level":2},
+			{"id":"3","department":"engineering","level":1}
+		],
+		"sales": [{"id":"2","department":"sales","level":1}],
+		"": [{"id":"4","level":3}]
+	}`, w.Body.String())
+}
+
+func TestUniHandler_GroupBy_NonStringValues(t *testing.T) {
+	uniHandler := newGroupByHandler(t, true)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users?groupBy=level", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"1": [
+			{"id":"2","department":"sales","level":1},
+			{"id":"3","department":"engineering","level":1}
+		],
+		"2": [{"id":"1","department":"engineering","level":2}],
+		"3": [{"id":"4","level":3}]
+	}`, w.Body.String())
+}
+
+func TestUniHandler_GroupBy_Disabled(t *testing.T) {
+	uniHandler := newGroupByHandler(t, false)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users?groupBy=department", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, byte('['), w.Body.Bytes()[0], "sections without allow_group_by return a plain array")
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+const (
+	// grpcWebContentType is the binary gRPC-Web media type with JSON-encoded messages
+	grpcWebContentType = "application/grpc-web+json"
+	// grpcWebTextPrefix marks the base64-encoded gRPC-Web variant used by browsers
+	grpcWebTextPrefix = "application/grpc-web-text"
+
+	// grpcWebFrameHeaderSize is the flag byte and the 4-byte big-endian message length of a frame
+	grpcWebFrameHeaderSize = 5
+	// grpcWebDataFrame and grpcWebTrailerFrame are the flags of message and trailer frames
+	grpcWebDataFrame    byte = 0x00
+	grpcWebTrailerFrame byte = 0x80
+
+	// grpcStatusInternal is answered to calls whose framing cannot be decoded
+	grpcStatusInternal = 13
+)
+
+// tryServeGRPCWeb answers unary gRPC-Web calls to sections with protocol grpc-web with the configured
+// response message and trailers. It returns nil when the request does not target a grpc-web section.
+func (h *UniHandler) tryServeGRPCWeb(req *http.Request) *http.Response {
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.Protocol != config.ProtocolGRPCWeb {
+		return nil
+	}
+	if req.Method != http.MethodPost {
+		resp := h.errorResponse(http.StatusMethodNotAllowed, "gRPC-Web calls must use POST")
+		resp.Header.Set("Allow", http.MethodPost)
+		return resp
+	}
+
+	contentType := req.Header.Get(contentTypeHeader)
+	isText := strings.HasPrefix(contentType, grpcWebTextPrefix)
+	if !isText {
+		contentType = grpcWebContentType
+	}
+
+	reply := config.GRPCWebConfig{}
+	if section.GRPCWeb != nil {
+		reply = *section.GRPCWeb
+	}
+	if _, err := readGRPCWebMessage(req.Body, isText); err != nil {
+		h.logger.Warn("malformed gRPC-Web request", pathLogKey, req.URL.Path, errorLogKey, err)
+		reply = config.GRPCWebConfig{Status: grpcStatusInternal, Message: err.Error()}
+	}
+
+	body := encodeGRPCWebResponse(reply)
+	if isText {
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{contentTypeHeader: []string{contentType}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+// readGRPCWebMessage decodes the single length-prefixed message of a unary gRPC-Web request
+func readGRPCWebMessage(body io.Reader, isText bool) ([]byte, error) {
+	if body == nil {
+		return nil, errors.New("missing request message")
+	}
+	if isText {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request message: %w", err)
+	}
+	if len(raw) < grpcWebFrameHeaderSize || raw[0] != grpcWebDataFrame {
+		return nil, errors.New("request is not a gRPC-Web message frame")
+	}
+	length := binary.BigEndian.Uint32(raw[1:grpcWebFrameHeaderSize])
+	message := raw[grpcWebFrameHeaderSize:]
+	if uint64(len(message)) != uint64(length) {
+		return nil, fmt.Errorf("message frame announces %d bytes but carries %d", length, len(message))
+	}
+	return message, nil
+}
+
+// encodeGRPCWebResponse frames the response message, if the status is OK, followed by the trailers
+func encodeGRPCWebResponse(reply config.GRPCWebConfig) []byte {
+	var body bytes.Buffer
+	if reply.Status == 0 {
+		message := reply.Response
+		if message == "" {
+			message = "{}"
+		}
+		writeGRPCWebFrame(&body, grpcWebDataFrame, []byte(message))
+	}
+
+	var trailers strings.Builder
+	trailers.WriteString("grpc-status:" + strconv.Itoa(reply.Status) + "\r\n")
+	if reply.Message != "" {
+		trailers.WriteString("grpc-message:" + url.PathEscape(reply.Message) + "\r\n")
+	}
+	names := make([]string, 0, len(reply.Trailers))
+	for name := range reply.Trailers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		trailers.WriteString(strings.ToLower(name) + ":" + reply.Trailers[name] + "\r\n")
+	}
+	writeGRPCWebFrame(&body, grpcWebTrailerFrame, []byte(trailers.String()))
+	return body.Bytes()
+}
+
+// writeGRPCWebFrame appends a frame with the flag and the big-endian length prefix
+func writeGRPCWebFrame(w *bytes.Buffer, flag byte, payload []byte) {
+	var header [grpcWebFrameHeaderSize]byte
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	w.Write(header[:])
+	w.Write(payload)
+}
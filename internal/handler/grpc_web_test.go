@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grpcWebFrame is one decoded gRPC-Web frame
+type grpcWebFrame struct {
+	flag    byte
+	payload string
+}
+
+func encodeGRPCWebFrame(flag byte, payload string) []byte {
+	frame := make([]byte, 5, 5+len(payload))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+func decodeGRPCWebFrames(t *testing.T, body []byte) []grpcWebFrame {
+	t.Helper()
+	var frames []grpcWebFrame
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), 5, "truncated frame header")
+		length := int(binary.BigEndian.Uint32(body[1:5]))
+		require.GreaterOrEqual(t, len(body)-5, length, "truncated frame payload")
+		frames = append(frames, grpcWebFrame{flag: body[0], payload: string(body[5 : 5+length])})
+		body = body[5+length:]
+	}
+	return frames
+}
+
+func callGRPCWeb(handler http.Handler, method, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/acme.Greeter/SayHello", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func newGRPCWebHandler(reply *config.GRPCWebConfig) http.Handler {
+	return newSectionHandler("greeter", config.Section{
+		PathPattern: "/acme.Greeter/*",
+		Protocol:    config.ProtocolGRPCWeb,
+		GRPCWeb:     reply,
+	})
+}
+
+func TestUniHandler_GRPCWeb_Unary(t *testing.T) {
+	uniHandler := newGRPCWebHandler(&config.GRPCWebConfig{Response: `{"message":"hello"}`})
+
+	w := callGRPCWeb(uniHandler, http.MethodPost, "application/grpc-web+json",
+		encodeGRPCWebFrame(0x00, `{"name":"world"}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/grpc-web+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, []grpcWebFrame{
+		{flag: 0x00, payload: `{"message":"hello"}`},
+		{flag: 0x80, payload: "grpc-status:0\r\n"},
+	}, decodeGRPCWebFrames(t, w.Body.Bytes()))
+}
+
+func TestUniHandler_GRPCWeb_ErrorStatus(t *testing.T) {
+	uniHandler := newGRPCWebHandler(&config.GRPCWebConfig{
+		Status:   5,
+		Message:  "greeting not found",
+		Trailers: map[string]string{"X-Request-Id": "r-1"},
+	})
+
+	w := callGRPCWeb(uniHandler, http.MethodPost, "application/grpc-web+json", encodeGRPCWebFrame(0x00, `{}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []grpcWebFrame{
+		{flag: 0x80, payload: "grpc-status:5\r\ngrpc-message:greeting%20not%20found\r\nx-request-id:r-1\r\n"},
+	}, decodeGRPCWebFrames(t, w.Body.Bytes()))
+}
+
+func TestUniHandler_GRPCWeb_Text(t *testing.T) {
+	uniHandler := newGRPCWebHandler(nil)
+	request := base64.StdEncoding.EncodeToString(encodeGRPCWebFrame(0x00, `{}`))
+
+	w := callGRPCWeb(uniHandler, http.MethodPost, "application/grpc-web-text", []byte(request))
+
+	assert.Equal(t, "application/grpc-web-text", w.Header().Get("Content-Type"))
+	body, err := base64.StdEncoding.DecodeString(w.Body.String())
+	require.NoError(t, err)
+	assert.Equal(t, []grpcWebFrame{
+		{flag: 0x00, payload: `{}`},
+		{flag: 0x80, payload: "grpc-status:0\r\n"},
+	}, decodeGRPCWebFrames(t, body))
+}
+
+func TestUniHandler_GRPCWeb_MalformedRequest(t *testing.T) {
+	uniHandler := newGRPCWebHandler(&config.GRPCWebConfig{Response: `{"message":"hello"}`})
+
+	w := callGRPCWeb(uniHandler, http.MethodPost, "application/grpc-web+json", []byte(`{"name":"world"}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	frames := decodeGRPCWebFrames(t, w.Body.Bytes())
+	require.Len(t, frames, 1)
+	assert.Contains(t, frames[0].payload, "grpc-status:13\r\n")
+}
+
+func TestUniHandler_GRPCWeb_RequiresPost(t *testing.T) {
+	uniHandler := newGRPCWebHandler(nil)
+
+	w := callGRPCWeb(uniHandler, http.MethodGet, "application/grpc-web+json", nil)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "POST", w.Header().Get("Allow"))
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// applyHeadHeaders sets the section's head_headers on successful HEAD responses, replacing headers
+// of the same name mirrored from GET, e.g. a Content-Length differing from the GET body
+func applyHeadHeaders(resp *http.Response, section *config.Section) *http.Response {
+	if resp == nil || len(section.HeadHeaders) == 0 ||
+		resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp
+	}
+	for name, value := range section.HeadHeaders {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
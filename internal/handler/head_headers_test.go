@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_HeadHeaders(t *testing.T) {
+	h := newSectionHandler("files", config.Section{
+		PathPattern: "/files/*",
+		BodyIDPaths: []string{"/id"},
+		HeadHeaders: map[string]string{"Content-Length": "1048576", "X-Checksum": "abc123"},
+	})
+	require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/files", `{"id":"1"}`).Code)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Head(srv.URL + "/files/1")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(1048576), resp.ContentLength)
+	assert.Equal(t, "abc123", resp.Header.Get("X-Checksum"))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "other headers still mirror GET")
+
+	resp, err = http.Get(srv.URL + "/files/1")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, resp.Header.Get("X-Checksum"), "GET is unaffected")
+
+	resp, err = http.Head(srv.URL + "/files/2")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("X-Checksum"), "failed HEAD requests get no override")
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+)
+
+// headerLineBreaks removes the characters that would end a header line and let a value inject
+// further headers or a body into the response
+var headerLineBreaks = strings.NewReplacer("\r", "", "\n", "")
+
+// UnsafeHeader returns the name of the first header whose name or values contain CR or LF
+func UnsafeHeader(header http.Header) (string, bool) {
+	for name, values := range header {
+		if strings.ContainsAny(name, "\r\n") {
+			return name, true
+		}
+		for _, value := range values {
+			if strings.ContainsAny(value, "\r\n") {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// SanitizeHeader strips CR and LF from the header names and values, so templated or injected values
+// cannot split the response
+func SanitizeHeader(header http.Header) {
+	for name, values := range header {
+		for i, value := range values {
+			values[i] = headerLineBreaks.Replace(value)
+		}
+		if clean := headerLineBreaks.Replace(name); clean != name {
+			delete(header, name)
+			header[clean] = values
+		}
+	}
+}
+
+// EnableHeaderRejection makes the handler answer 500 instead of stripping line breaks from unsafe
+// header values, so tests can assert that header injection attempts are caught
+func (h *UniHandler) EnableHeaderRejection() {
+	h.rejectUnsafeHeaders = true
+}
+
+// guardHeaders neutralizes CR and LF in the response headers, or replaces the response with an error
+// when unsafe headers are rejected
+func (h *UniHandler) guardHeaders(resp *http.Response) *http.Response {
+	if resp == nil {
+		return resp
+	}
+	name, unsafe := UnsafeHeader(resp.Header)
+	if !unsafe {
+		return resp
+	}
+	h.logger.Warn("response header contains a line break", "header", name)
+	if h.rejectUnsafeHeaders {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		return h.errorResponse(http.StatusInternalServerError, "unsafe response header value")
+	}
+	SanitizeHeader(resp.Header)
+	return resp
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+// injectedOrder smuggles a Set-Cookie header into the templated Location through a CRLF in the body
+const injectedOrder = `{"customerId":"c-7\r\nSet-Cookie: session=stolen","orderId":42}`
+
+func TestUniHandler_HeaderGuard_StripsLineBreaks(t *testing.T) {
+	uniHandler := newLocationTemplateHandler()
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", injectedOrder)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/orders/c-7Set-Cookie: session=stolen/42", w.Header().Get("Location"))
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+	_, unsafe := handler.UnsafeHeader(w.Header())
+	assert.False(t, unsafe)
+}
+
+func TestUniHandler_HeaderGuard_RejectMode(t *testing.T) {
+	uniHandler := newLocationTemplateHandler()
+	uniHandler.(*handler.UniHandler).EnableHeaderRejection()
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", injectedOrder)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+	assert.Equal(t, "unsafe response header value", w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodPost, "/orders", `{"customerId":"c-8","orderId":43}`)
+	assert.Equal(t, http.StatusCreated, w.Code, "safe headers pass in reject mode")
+}
+
+func TestSanitizeHeader(t *testing.T) {
+	header := http.Header{
+		"X-Safe":          {"plain"},
+		"X-Split":         {"a\r\nb", "c\nd"},
+		"X-Evil\r\nInner": {"v"},
+	}
+
+	handler.SanitizeHeader(header)
+
+	assert.Equal(t, http.Header{
+		"X-Safe":      {"plain"},
+		"X-Split":     {"ab", "cd"},
+		"X-EvilInner": {"v"},
+	}, header)
+}
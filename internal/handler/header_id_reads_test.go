@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWithResourceID sends a request addressing the resource through the X-Resource-ID header
+func serveWithResourceID(uniHandler http.Handler, method, path, id, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Resource-ID", id)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	return w
+}
+
+func newHeaderIDHandler(allowReads bool) *handler.UniHandler {
+	return newSectionHandler("tenants", config.Section{
+		PathPattern:           "/tenants/*",
+		HeaderIDNames:         []string{"X-Resource-ID"},
+		AllowHeaderIDForReads: allowReads,
+	})
+}
+
+func TestUniHandler_HeaderIDForReads(t *testing.T) {
+	uniHandler := newHeaderIDHandler(true)
+	w := serveWithResourceID(uniHandler, http.MethodPost, "/tenants", "123", `{"name":"acme"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveWithResourceID(uniHandler, http.MethodGet, "/tenants", "123", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"acme"}`, w.Body.String())
+
+	w = serveWithResourceID(uniHandler, http.MethodGet, "/tenants", "999", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveWithResourceID(uniHandler, http.MethodPut, "/tenants", "123", `{"name":"globex"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveRequest(uniHandler, http.MethodGet, "/tenants/123", "")
+	assert.JSONEq(t, `{"name":"globex"}`, w.Body.String())
+
+	w = serveWithResourceID(uniHandler, http.MethodDelete, "/tenants", "123", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveRequest(uniHandler, http.MethodGet, "/tenants/123", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUniHandler_HeaderIDForReads_PathIDWins(t *testing.T) {
+	uniHandler := newHeaderIDHandler(true)
+	for _, id := range []string{"1", "2"} {
+		w := serveWithResourceID(uniHandler, http.MethodPost, "/tenants", id, `{"id":"`+id+`"}`)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	w := serveWithResourceID(uniHandler, http.MethodGet, "/tenants/1", "2", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+}
+
+func TestUniHandler_HeaderIDForReads_Disabled(t *testing.T) {
+	uniHandler := newHeaderIDHandler(false)
+	w := serveWithResourceID(uniHandler, http.MethodPost, "/tenants", "123", `{"name":"acme"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveWithResourceID(uniHandler, http.MethodGet, "/tenants", "123", "")
+
+	// Without the flag the header is ignored and the collection is listed
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"name":"acme"}]`, w.Body.String())
+}
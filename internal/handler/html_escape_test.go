@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remarshalTransform decodes and re-encodes the body the way field transforms do
+func remarshalTransform(data model.UniData) (model.UniData, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data.Body, &doc); err != nil {
+		return data, err
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return data, err
+	}
+	data.Body = body
+	return data, nil
+}
+
+func newLinksHandler(disableHTMLEscape bool) http.Handler {
+	transforms := config.NewTransformationConfig()
+	transforms.AddResponseTransform(remarshalTransform)
+	return newSectionHandler("links", config.Section{
+		PathPattern:       "/links/*",
+		BodyIDPaths:       []string{"/id"},
+		Transformations:   transforms,
+		DisableHTMLEscape: disableHTMLEscape,
+	})
+}
+
+func TestUniHandler_DisableHTMLEscape_CollectionKeepsAmpersand(t *testing.T) {
+	uniHandler := newLinksHandler(true)
+
+	body := `{"id":"1","url":"https://example.com/search?a=1&b=<2>"}`
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/links", body).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/links", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"url":"https://example.com/search?a=1&b=<2>"`)
+	assert.NotContains(t, w.Body.String(), "\\u0026")
+	assert.JSONEq(t, `[`+body+`]`, w.Body.String())
+}
+
+func TestUniHandler_DisableHTMLEscape_DefaultEscapes(t *testing.T) {
+	uniHandler := newLinksHandler(false)
+
+	body := `{"id":"1","url":"https://example.com/search?a=1&b=2"}`
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/links", body).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/links", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "a=1\\u0026b=2")
+}
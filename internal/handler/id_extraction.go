@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/antchfx/jsonquery"
+	"github.com/antchfx/xmlquery"
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// extractIDs extracts IDs from the request using configured paths
+func (h *UniHandler) extractIDs(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+) ([]string, error) {
+	if h.isPathBasedMethod(req.Method) {
+		return h.extractPathBasedIDs(req, section, sectionName)
+	}
+	return h.extractBodyBasedIDs(ctx, req, section, sectionName)
+}
+
+// isPathBasedMethod checks if method uses path-based ID extraction
+func (*UniHandler) isPathBasedMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead ||
+		method == http.MethodPut || method == http.MethodDelete
+}
+
+// extractPathBasedIDs extracts IDs from path for GET/HEAD/PUT/DELETE methods, falling back to the
+// ID headers in sections that allow header IDs for reads
+func (h *UniHandler) extractPathBasedIDs(
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+) ([]string, error) {
+	if ids := h.extractPathIDs(req, section, sectionName); len(ids) > 0 {
+		return ids, nil
+	}
+	return headerReadIDs(req, section), nil
+}
+
+// extractPathIDs extracts the IDs carried by the request path
+func (h *UniHandler) extractPathIDs(req *http.Request, section *config.Section, sectionName string) []string {
+	if hasCompositeIDs(section) {
+		return extractCompositePathIDs(section, req.URL.Path)
+	}
+
+	pathSegments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	patternSegments := strings.Split(strings.Trim(section.PathPattern, "/"), "/")
+
+	if h.shouldExtractFromPath(pathSegments, patternSegments) {
+		lastSegment := pathSegments[len(pathSegments)-1]
+		if lastSegment != "" && lastSegment != sectionName {
+			return []string{lastSegment}
+		}
+	}
+	return nil
+}
+
+// headerReadIDs returns the IDs sent in the section's ID headers when it allows header IDs for
+// path-based methods
+func headerReadIDs(req *http.Request, section *config.Section) []string {
+	if !section.AllowHeaderIDForReads {
+		return nil
+	}
+	var ids []string
+	for _, headerName := range section.HeaderIDNames {
+		if headerID := req.Header.Get(headerName); headerName != "" && headerID != "" {
+			ids = append(ids, headerID)
+		}
+	}
+	return ids
+}
+
+// shouldExtractFromPath determines if ID should be extracted from path
+func (*UniHandler) shouldExtractFromPath(pathSegments, patternSegments []string) bool {
+	if len(patternSegments) == 0 || len(pathSegments) == 0 {
+		return false
+	}
+
+	lastPattern := patternSegments[len(patternSegments)-1]
+
+	// Handle recursive wildcard **
+	if lastPattern == "**" {
+		// For pattern /users/**, any path like /users/123 should extract 123
+		return len(pathSegments) > len(patternSegments)-1
+	}
+
+	// Handle single wildcard *
+	if lastPattern == "*" {
+		return len(pathSegments) == len(patternSegments)
+	}
+
+	// For exact patterns, only extract if path is longer
+	return len(pathSegments) > len(patternSegments)
+}
+
+// extractBodyBasedIDs extracts IDs from headers and body for POST requests
+func (h *UniHandler) extractBodyBasedIDs(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+) ([]string, error) {
+	var collectedIDs []string
+	seenIDs := make(map[string]bool)
+
+	addID := func(id string) {
+		if id != "" && id != sectionName && !seenIDs[id] {
+			collectedIDs = append(collectedIDs, id)
+			seenIDs[id] = true
+		}
+	}
+
+	return h.extractPostIDs(ctx, req, section, addID)
+}
+
+// extractPostIDs extracts IDs from headers and body for POST requests
+func (h *UniHandler) extractPostIDs(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	addID func(string),
+) ([]string, error) {
+	var collectedIDs []string
+
+	// Try header ID extraction
+	collectedIDs = h.tryExtractHeaderID(section, req, addID, collectedIDs)
+
+	// Try body ID extraction
+	bodyIDs, err := h.extractBodyIDs(ctx, req, section)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range bodyIDs {
+		addID(id)
+	}
+	collectedIDs = append(collectedIDs, bodyIDs...)
+
+	// Try path ID extraction as fallback
+	if len(collectedIDs) == 0 {
+		collectedIDs = h.tryExtractPathIDFallback(req, section, addID, collectedIDs)
+	}
+
+	return collectedIDs, nil
+}
+
+// tryExtractHeaderID attempts to extract ID from request headers
+func (*UniHandler) tryExtractHeaderID(
+	section *config.Section,
+	req *http.Request,
+	addID func(string),
+	collectedIDs []string,
+) []string {
+	for _, headerName := range section.HeaderIDNames {
+		if headerName != "" {
+			headerID := req.Header.Get(headerName)
+			if headerID != "" {
+				addID(headerID)
+				collectedIDs = append(collectedIDs, headerID)
+			}
+		}
+	}
+	return collectedIDs
+}
+
+// tryExtractPathIDFallback attempts to extract ID from path as fallback
+func (*UniHandler) tryExtractPathIDFallback(
+	req *http.Request,
+	section *config.Section,
+	addID func(string),
+	collectedIDs []string,
+) []string {
+	pathSegments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	patternSegments := strings.Split(strings.Trim(section.PathPattern, "/"), "/")
+
+	if len(pathSegments) > len(patternSegments) ||
+		(len(pathSegments) == len(patternSegments) && strings.HasSuffix(section.PathPattern, "*")) {
+		lastSegment := pathSegments[len(pathSegments)-1]
+		addID(lastSegment)
+		collectedIDs = append(collectedIDs, lastSegment)
+	}
+	return collectedIDs
+}
+
+// extractBodyIDs extracts IDs from request body
+func (h *UniHandler) extractBodyIDs(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+) ([]string, error) {
+	contentType := strings.ToLower(req.Header.Get("Content-Type"))
+
+	if !h.isSupportedContentType(contentType) {
+		return nil, nil
+	}
+
+	body, err := h.readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(contentType, multipartFormData) {
+		// The boundary is case-sensitive, so the original header value is used
+		return h.extractFormIDs(body, req.Header.Get(contentTypeHeader), section.BodyIDPaths)
+	}
+
+	return h.parseIDsFromBody(ctx, body, contentType, section)
+}
+
+// isSupportedContentType checks if content type supports ID extraction
+func (*UniHandler) isSupportedContentType(contentType string) bool {
+	return strings.Contains(contentType, "json") || strings.Contains(contentType, "xml") ||
+		strings.HasPrefix(contentType, multipartFormData)
+}
+
+// readAndRestoreRequestBody reads and restores request body
+func (*UniHandler) readAndRestoreRequestBody(req *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewBuffer(body))
+	return body, nil
+}
+
+// parseIDsFromBody parses IDs from body content based on content type
+func (h *UniHandler) parseIDsFromBody(
+	ctx context.Context,
+	body []byte,
+	contentType string,
+	section *config.Section,
+) ([]string, error) {
+	seenIDs := make(map[string]bool)
+	var extractedIDs []string
+	var err error
+
+	if strings.Contains(contentType, "json") {
+		extractedIDs, err = h.extractJSONIDs(body, section.BodyIDPaths, seenIDs)
+	} else {
+		extractedIDs, err = h.extractXMLIDs(body, section.BodyIDPaths, seenIDs)
+	}
+
+	if err != nil {
+		h.logger.WarnContext(ctx, "error extracting IDs from body", "error", err)
+		return nil, err
+	}
+
+	return extractedIDs, nil
+}
+
+// extractJSONIDs extracts IDs from JSON body
+func (h *UniHandler) extractJSONIDs(body []byte, idPaths []string, seenIDs map[string]bool) ([]string, error) {
+	doc, err := jsonquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON body: %w", err)
+	}
+
+	var ids []string
+	for _, path := range idPaths {
+		if strings.HasPrefix(path, formFieldPrefix) {
+			continue
+		}
+		pathIDs := h.extractJSONIDsFromPath(doc, path, seenIDs)
+		ids = append(ids, pathIDs...)
+	}
+	return ids, nil
+}
+
+// extractJSONIDsFromPath extracts IDs from a specific JSON path
+func (*UniHandler) extractJSONIDsFromPath(doc *jsonquery.Node, path string, seenIDs map[string]bool) []string {
+	nodes, err := jsonquery.QueryAll(doc, path)
+	if err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, node := range nodes {
+		if idStr := fmt.Sprintf("%v", node.Value()); idStr != "" && !seenIDs[idStr] {
+			ids = append(ids, idStr)
+			seenIDs[idStr] = true
+		}
+	}
+	return ids
+}
+
+// extractXMLIDs extracts IDs from XML body
+func (h *UniHandler) extractXMLIDs(body []byte, idPaths []string, seenIDs map[string]bool) ([]string, error) {
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XML body: %w", err)
+	}
+
+	var ids []string
+	for _, path := range idPaths {
+		if strings.HasPrefix(path, formFieldPrefix) {
+			continue
+		}
+		pathIDs := h.extractXMLIDsFromPath(doc, path, seenIDs)
+		ids = append(ids, pathIDs...)
+	}
+	return ids, nil
+}
+
+// extractXMLIDsFromPath extracts IDs from a specific XML path
+func (*UniHandler) extractXMLIDsFromPath(doc *xmlquery.Node, path string, seenIDs map[string]bool) []string {
+	nodes, err := xmlquery.QueryAll(doc, path)
+	if err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, node := range nodes {
+		if idStr := node.InnerText(); idStr != "" && !seenIDs[idStr] {
+			ids = append(ids, idStr)
+			seenIDs[idStr] = true
+		}
+	}
+	return ids
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// SetClock replaces the clock the handler reads the time of day from, e.g. to test latency schedules
+func (h *UniHandler) SetClock(now func() time.Time) {
+	h.clock = now
+}
+
+// delayBySchedule waits as long as the section's latency_schedule asks for at the current time of day,
+// giving up early when the client goes away
+func (h *UniHandler) delayBySchedule(req *http.Request) {
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || len(section.LatencySchedule) == 0 {
+		return
+	}
+	delay := section.LatencySchedule.DelayAt(h.clock())
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-req.Context().Done():
+	}
+}
@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_LatencySchedule(t *testing.T) {
+	h := newSectionHandler("users", config.Section{
+		PathPattern: "/users/*",
+		BodyIDPaths: []string{"/id"},
+		LatencySchedule: config.LatencySchedule{
+			"09:00-17:00": 150 * time.Millisecond,
+			"17:00-18:00": 20 * time.Millisecond,
+		},
+	})
+	now := time.Date(2024, time.March, 4, 7, 0, 0, 0, time.Local)
+	h.SetClock(func() time.Time { return now })
+
+	elapsed := func(method, path, body string) time.Duration {
+		start := time.Now()
+		w := serveRequest(h, method, path, body)
+		require.Less(t, w.Code, 300, w.Body.String())
+		return time.Since(start)
+	}
+
+	assert.Less(t, elapsed(http.MethodPost, "/users", `{"id":"1"}`), 100*time.Millisecond, "no delay off-schedule")
+
+	now = time.Date(2024, time.March, 4, 10, 30, 0, 0, time.Local)
+	assert.GreaterOrEqual(t, elapsed(http.MethodGet, "/users/1", ""), 150*time.Millisecond, "peak hours")
+
+	now = time.Date(2024, time.March, 4, 17, 15, 0, 0, time.Local)
+	evening := elapsed(http.MethodGet, "/users/1", "")
+	assert.GreaterOrEqual(t, evening, 20*time.Millisecond)
+	assert.Less(t, evening, 150*time.Millisecond, "the evening window is faster than peak hours")
+}
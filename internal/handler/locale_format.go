@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// defaultLocale is used when Accept-Language names no known locale
+const defaultLocale = "en"
+
+// localeConventions holds the number and date formatting rules of a locale
+type localeConventions struct {
+	thousandsSep string
+	decimalSep   string
+	dateLayout   string
+}
+
+// localeTable lists the supported locales keyed by lowercase language tag
+var localeTable = map[string]localeConventions{
+	"en":    {thousandsSep: ",", decimalSep: ".", dateLayout: "01/02/2006"},
+	"en-gb": {thousandsSep: ",", decimalSep: ".", dateLayout: "02/01/2006"},
+	"de":    {thousandsSep: ".", decimalSep: ",", dateLayout: "02.01.2006"},
+	"es":    {thousandsSep: ".", decimalSep: ",", dateLayout: "02/01/2006"},
+	"fr":    {thousandsSep: " ", decimalSep: ",", dateLayout: "02/01/2006"},
+	"it":    {thousandsSep: ".", decimalSep: ",", dateLayout: "02/01/2006"},
+	"nl":    {thousandsSep: ".", decimalSep: ",", dateLayout: "02-01-2006"},
+	"pl":    {thousandsSep: " ", decimalSep: ",", dateLayout: "02.01.2006"},
+	"ja":    {thousandsSep: ",", decimalSep: ".", dateLayout: "2006/01/02"},
+}
+
+// formatLocaleFields formats configured number and date fields according to the request's Accept-Language
+func (h *UniHandler) formatLocaleFields(
+	resp *http.Response,
+	req *http.Request,
+	section *config.Section,
+) *http.Response {
+	if len(section.LocaleFormatFields) == 0 {
+		return resp
+	}
+	conventions := requestLocale(req.Header.Get("Accept-Language"))
+	return h.rewriteResponseObjects(resp, func(obj map[string]any) {
+		for field, kind := range section.LocaleFormatFields {
+			value, ok := obj[field]
+			if !ok {
+				continue
+			}
+			if formatted, ok := conventions.format(kind, value); ok {
+				obj[field] = formatted
+			}
+		}
+	})
+}
+
+// requestLocale returns the conventions of the first known locale in an Accept-Language header
+func requestLocale(acceptLanguage string) localeConventions {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		if conventions, ok := localeTable[tag]; ok {
+			return conventions
+		}
+		if conventions, ok := localeTable[strings.SplitN(tag, "-", 2)[0]]; ok {
+			return conventions
+		}
+	}
+	return localeTable[defaultLocale]
+}
+
+// format renders a value of the given kind; it reports false when the value cannot be formatted
+func (lc localeConventions) format(kind string, value any) (string, bool) {
+	switch kind {
+	case config.LocaleFormatNumber:
+		return lc.formatNumber(value)
+	case config.LocaleFormatDate:
+		return lc.formatDate(value)
+	default:
+		return "", false
+	}
+}
+
+// formatNumber groups the integer digits and swaps the decimal separator, keeping the original precision
+func (lc localeConventions) formatNumber(value any) (string, bool) {
+	var digits string
+	switch typed := value.(type) {
+	case json.Number:
+		digits = typed.String()
+	case string:
+		digits = typed
+	default:
+		return "", false
+	}
+	f, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return "", false
+	}
+	if strings.ContainsAny(digits, "eE") {
+		digits = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(digits, ".")
+
+	var grouped strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteString(lc.thousandsSep)
+		}
+		grouped.WriteRune(digit)
+	}
+	if hasFrac {
+		grouped.WriteString(lc.decimalSep + fracPart)
+	}
+	return sign + grouped.String(), true
+}
+
+// formatDate renders an RFC 3339 timestamp or YYYY-MM-DD date with the locale date layout
+func (lc localeConventions) formatDate(value any) (string, bool) {
+	raw, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format(lc.dateLayout), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocaleHandler(t *testing.T) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("invoices", config.Section{
+		PathPattern: "/invoices/*",
+		BodyIDPaths: []string{"/id"},
+		LocaleFormatFields: map[string]string{
+			"amount": config.LocaleFormatNumber,
+			"issued": config.LocaleFormatDate,
+		},
+	})
+	created := serveRequest(uniHandler, http.MethodPost, "/invoices",
+		`{"id":"1","amount":1234567.89,"issued":"2024-03-15"}`)
+	require.Equal(t, http.StatusCreated, created.Code)
+	return uniHandler
+}
+
+func TestUniHandler_LocaleFormatFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{
+			name:           "english separators",
+			acceptLanguage: "en-US,en;q=0.9",
+			expected:       `{"id":"1","amount":"1,234,567.89","issued":"03/15/2024"}`,
+		},
+		{
+			name:           "german separators",
+			acceptLanguage: "de-DE,de;q=0.9",
+			expected:       `{"id":"1","amount":"1.234.567,89","issued":"15.03.2024"}`,
+		},
+		{
+			name:           "unknown locale falls back to english",
+			acceptLanguage: "xx",
+			expected:       `{"id":"1","amount":"1,234,567.89","issued":"03/15/2024"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newLocaleHandler(t)
+
+			w := getWithHeader(uniHandler, "/invoices/1", "Accept-Language", tt.acceptLanguage)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expected, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// renderLocation renders a section's location template against the JSON request body and the
+// request path's wildcard captures ({{.Path1}}, ...), which body fields of the same name override.
+// Numbers keep their literal form and fields missing from the body are reported as errors.
+func renderLocation(locationTemplate string, body []byte, captures []string) (string, error) {
+	tmpl, err := template.New("location").Option("missingkey=error").Parse(locationTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid location template: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return "", fmt.Errorf("location template requires a JSON object body: %w", err)
+	}
+	if fields == nil {
+		fields = make(map[string]any)
+	}
+	for name, capture := range config.PathCaptureFields(captures) {
+		if _, ok := fields[name]; !ok {
+			fields[name] = capture
+		}
+	}
+
+	var location strings.Builder
+	if err := tmpl.Execute(&location, fields); err != nil {
+		return "", fmt.Errorf("failed to render location: %w", err)
+	}
+	return location.String(), nil
+}
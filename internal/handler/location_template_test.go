@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLocationTemplateHandler() http.Handler {
+	return newSectionHandler("orders", config.Section{
+		PathPattern:      "/orders/**",
+		BodyIDPaths:      []string{"/orderId"},
+		LocationTemplate: "/orders/{{.customerId}}/{{.orderId}}",
+	})
+}
+
+func TestUniHandler_LocationTemplate(t *testing.T) {
+	uniHandler := newLocationTemplateHandler()
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"customerId":"c-7","orderId":42}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/orders/c-7/42", w.Header().Get("Location"))
+}
+
+func TestUniHandler_LocationTemplate_MissingField(t *testing.T) {
+	uniHandler := newLocationTemplateHandler()
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"orderId":"42"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "customerId")
+}
+
+func TestUniHandler_LocationTemplate_NestedField(t *testing.T) {
+	uniHandler := newSectionHandler("orders", config.Section{
+		PathPattern:      "/orders/**",
+		BodyIDPaths:      []string{"/id"},
+		LocationTemplate: "/customers/{{.customer.id}}/orders/{{.id}}",
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"o1","customer":{"id":"c1"}}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/customers/c1/orders/o1", w.Header().Get("Location"))
+}
+
+func TestUniHandler_LocationTemplate_PathCaptures(t *testing.T) {
+	uniHandler := newSectionHandler("orders", config.Section{
+		PathPattern:      "/users/*/orders/*",
+		BodyIDPaths:      []string{"/id"},
+		PathIDSegments:   []int{1, 3},
+		LocationTemplate: "/v2/users/{{.Path1}}/orders/{{.id}}",
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users/u1/orders", `{"id":"o1"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/v2/users/u1/orders/o1", w.Header().Get("Location"))
+}
+
+func TestUniHandler_SuppressLocation(t *testing.T) {
+	uniHandler := newSectionHandler("events", config.Section{
+		PathPattern:      "/events/*",
+		BodyIDPaths:      []string{"/id"},
+		ReturnBody:       true,
+		SuppressLocation: true,
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/events", `{"id":"e-1","type":"click"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	_, hasLocation := w.Header()["Location"]
+	assert.False(t, hasLocation, "Location must be omitted")
+
+	w = serveRequest(uniHandler, http.MethodGet, "/events/e-1", "")
+	assert.Equal(t, http.StatusOK, w.Code, "the resource stays retrievable by its ID")
+	assert.JSONEq(t, `{"id":"e-1","type":"click"}`, w.Body.String())
+}
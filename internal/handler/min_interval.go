@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// clientPacing remembers, per section and client address, when a request was last served
+type clientPacing struct {
+	mu         sync.Mutex
+	lastServed map[string]time.Time
+}
+
+// newClientPacing creates an empty clientPacing
+func newClientPacing() *clientPacing {
+	return &clientPacing{lastServed: make(map[string]time.Time)}
+}
+
+// checkMinInterval answers requests arriving sooner than the section's min_interval after the
+// client's previously served request with 425 Too Early and a Retry-After hint. Rejected requests
+// do not restart the interval.
+func (h *UniHandler) checkMinInterval(req *http.Request) *http.Response {
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil || section.MinInterval <= 0 {
+		return nil
+	}
+
+	key := sectionName + "|" + clientAddress(req)
+	now := time.Now()
+
+	h.pacing.mu.Lock()
+	defer h.pacing.mu.Unlock()
+
+	if last, ok := h.pacing.lastServed[key]; ok {
+		if wait := section.MinInterval - now.Sub(last); wait > 0 {
+			resp := h.errorResponse(http.StatusTooEarly,
+				fmt.Sprintf("too early: requests must be at least %s apart", section.MinInterval))
+			resp.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return resp
+		}
+	}
+	h.pacing.lastServed[key] = now
+	return nil
+}
+
+// clientAddress identifies the client by the host part of its remote address
+func clientAddress(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,49 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPacedHandler(interval time.Duration) http.Handler {
+	return newSectionHandler("paced", config.Section{
+		PathPattern: "/paced/*",
+		BodyIDPaths: []string{"/id"},
+		MinInterval: interval,
+	})
+}
+
+func TestUniHandler_MinInterval_RapidRequestIsTooEarly(t *testing.T) {
+	uniHandler := newPacedHandler(time.Minute)
+
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/paced", `{"id":"1"}`).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/paced/1", "")
+	assert.Equal(t, http.StatusTooEarly, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+}
+
+func TestUniHandler_MinInterval_SpacedRequestsSucceed(t *testing.T) {
+	uniHandler := newPacedHandler(50 * time.Millisecond)
+
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/paced", `{"id":"1"}`).Code)
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/paced/1", "").Code)
+}
+
+func TestUniHandler_MinInterval_PerClient(t *testing.T) {
+	uniHandler := newPacedHandler(time.Minute)
+
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/paced", `{"id":"1"}`).Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/paced/1", nil)
+	req.RemoteAddr = "198.51.100.7:4321"
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildMultipartBody creates a multipart form body with a text field and a file part
+func buildMultipartBody(t *testing.T, fields map[string]string, fileField, fileName string) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	if fileField != "" {
+		part, err := writer.CreateFormFile(fileField, fileName)
+		require.NoError(t, err)
+		_, err = part.Write([]byte("file content"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestUniHandler_MultipartFormFieldIDExtraction(t *testing.T) {
+	uniHandler := newSectionHandler("uploads", config.Section{
+		PathPattern: "/uploads/*",
+		BodyIDPaths: []string{"form:userId"},
+		ReturnBody:  true,
+	})
+	body, contentType := buildMultipartBody(t, map[string]string{"userId": "user-42"}, "avatar", "me.png")
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/uploads/user-42", w.Header().Get("Location"))
+
+	get := serveRequest(uniHandler, http.MethodGet, "/uploads/user-42", "")
+	assert.Equal(t, http.StatusOK, get.Code)
+	assert.Equal(t, contentType, get.Header().Get("Content-Type"))
+	assert.Contains(t, get.Body.String(), "file content")
+}
+
+func TestUniHandler_MultipartFileNameIDExtraction(t *testing.T) {
+	uniHandler := newSectionHandler("uploads", config.Section{
+		PathPattern: "/uploads/*",
+		BodyIDPaths: []string{"/id", "form:document"},
+	})
+	body, contentType := buildMultipartBody(t, nil, "document", "report.pdf")
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/uploads/report.pdf", w.Header().Get("Location"))
+}
+
+func TestUniHandler_MultipartMissingBoundary(t *testing.T) {
+	uniHandler := newSectionHandler("uploads", config.Section{
+		PathPattern: "/uploads/*",
+		BodyIDPaths: []string{"form:userId"},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", bytes.NewBufferString("userId=1"))
+	req.Header.Set("Content-Type", "multipart/form-data")
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
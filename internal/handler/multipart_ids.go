@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+const (
+	// formFieldPrefix marks body ID paths that reference multipart form field names (e.g. "form:userId")
+	formFieldPrefix = config.FormFieldPrefix
+	// multipartFormData is the media type of multipart form submissions
+	multipartFormData = "multipart/form-data"
+)
+
+// extractFormIDs extracts IDs from multipart form fields referenced as "form:<field>" in ID paths.
+// Text fields yield their value and file fields yield their file name.
+func (*UniHandler) extractFormIDs(body []byte, contentType string, idPaths []string) ([]string, error) {
+	fieldNames := make(map[string]bool)
+	for _, idPath := range idPaths {
+		if name, ok := strings.CutPrefix(idPath, formFieldPrefix); ok && name != "" {
+			fieldNames[name] = true
+		}
+	}
+	if len(fieldNames) == 0 {
+		return nil, nil
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["boundary"] == "" {
+		return nil, fmt.Errorf("failed to parse multipart body: missing boundary")
+	}
+
+	values, err := readFormFieldValues(multipart.NewReader(bytes.NewReader(body), params["boundary"]), fieldNames)
+	if err != nil {
+		return nil, err
+	}
+
+	seenIDs := make(map[string]bool)
+	var ids []string
+	for _, idPath := range idPaths {
+		name := strings.TrimPrefix(idPath, formFieldPrefix)
+		for _, value := range values[name] {
+			if value != "" && !seenIDs[value] {
+				ids = append(ids, value)
+				seenIDs[value] = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+// readFormFieldValues collects the values of the requested form fields from a multipart reader
+func readFormFieldValues(reader *multipart.Reader, fieldNames map[string]bool) (map[string][]string, error) {
+	values := make(map[string][]string)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse multipart body: %w", err)
+		}
+
+		name := part.FormName()
+		if !fieldNames[name] {
+			continue
+		}
+		if fileName := part.FileName(); fileName != "" {
+			values[name] = append(values[name], fileName)
+			continue
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart field %s: %w", name, err)
+		}
+		values[name] = append(values[name], strings.TrimSpace(string(content)))
+	}
+}
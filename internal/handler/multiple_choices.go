@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// variant describes a representation listed in a 300 Multiple Choices response
+type variant struct {
+	Href        string `json:"href"`
+	ContentType string `json:"contentType"`
+}
+
+// multipleChoicesBody is the JSON body of a 300 Multiple Choices response
+type multipleChoicesBody struct {
+	Variants []variant `json:"variants"`
+}
+
+// offerMultipleChoices replaces successful GET and HEAD responses of sections with
+// multiple_choices_on_no_match by 300 Multiple Choices listing the available representations when
+// the request's Accept header matches none of them
+func (h *UniHandler) offerMultipleChoices(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices ||
+		(req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return resp
+	}
+	accept := req.Header.Get("Accept")
+	contentType := resp.Header.Get(contentTypeHeader)
+	if accept == "" || contentType == "" {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || !section.MultipleChoicesOnNoMatch || acceptsMediaType(accept, contentType) {
+		return resp
+	}
+
+	body, err := json.Marshal(multipleChoicesBody{
+		Variants: []variant{{Href: req.URL.Path, ContentType: contentType}},
+	})
+	if err != nil {
+		return resp
+	}
+	if resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	choices := &http.Response{
+		StatusCode: http.StatusMultipleChoices,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+	choices.Header.Set(contentTypeHeader, applicationJSON)
+	return choices
+}
+
+// acceptsMediaType reports whether an Accept header admits the content type: a media range of the
+// same type and subtype, type/* or */* with a non-zero quality. Unparseable ranges are ignored.
+func acceptsMediaType(accept, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	for _, mediaRange := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+			continue
+		}
+		rangeMain, rangeSub, _ := strings.Cut(rangeType, "/")
+		if rangeType == "*/*" || rangeType == mediaType || (rangeSub == "*" && rangeMain == mainType) {
+			return true
+		}
+	}
+	return false
+}
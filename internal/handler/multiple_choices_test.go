@@ -0,0 +1,53 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getWithAccept(h http.Handler, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Accept", accept)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestUniHandler_MultipleChoicesOnNoMatch(t *testing.T) {
+	h := newSectionHandler("users", config.Section{
+		PathPattern:              "/users/*",
+		BodyIDPaths:              []string{"/id"},
+		MultipleChoicesOnNoMatch: true,
+	})
+	require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/users", `{"id":"1"}`).Code)
+
+	w := getWithAccept(h, "/users/1", "application/xml, text/csv;q=0.5")
+	require.Equal(t, http.StatusMultipleChoices, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"variants": [{"href": "/users/1", "contentType": "application/json"}]}`, w.Body.String())
+
+	for _, accept := range []string{"application/json", "application/*", "text/html, */*;q=0.1", ""} {
+		w = getWithAccept(h, "/users/1", accept)
+		assert.Equal(t, http.StatusOK, w.Code, "Accept: %q", accept)
+		assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+	}
+
+	w = getWithAccept(h, "/users/1", "application/json;q=0")
+	assert.Equal(t, http.StatusMultipleChoices, w.Code, "a zero quality excludes the type")
+
+	w = getWithAccept(h, "/users/2", "application/xml")
+	assert.Equal(t, http.StatusNotFound, w.Code, "errors are not negotiated")
+}
+
+func TestUniHandler_MultipleChoicesOnNoMatch_Disabled(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+	require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/users", `{"id":"1"}`).Code)
+
+	w := getWithAccept(h, "/users/1", "application/xml")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// jsonNumberChars are the bytes a JSON number literal consists of
+const jsonNumberChars = "+-.eE0123456789"
+
+// roundNumbers formats the fractional numbers of JSON response bodies to the section's numeric precision
+func (h *UniHandler) roundNumbers(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.Body == nil || !strings.Contains(strings.ToLower(resp.Header.Get(contentTypeHeader)), "json") {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.NumericPrecision <= 0 {
+		return resp
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		h.logger.Error("failed to read response body for numeric precision", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(roundJSONNumbers(body, section.NumericPrecision)))
+	resp.Header.Del("Content-Length")
+	return resp
+}
+
+// roundJSONNumbers rewrites the numbers with a fraction or exponent in a JSON document with exactly
+// precision decimal places, e.g. 3.14159 and 2.5 as 3.14 and 2.50 for precision 2. Integers, strings,
+// key order and whitespace are kept, and invalid JSON is returned unchanged.
+func roundJSONNumbers(body []byte, precision int) []byte {
+	if !json.Valid(body) {
+		return body
+	}
+	var out bytes.Buffer
+	out.Grow(len(body))
+	inString := false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' {
+				i++
+				out.WriteByte(body[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(body) && strings.IndexByte(jsonNumberChars, body[end]) >= 0 {
+				end++
+			}
+			out.Write(formatJSONNumber(body[i:end], precision))
+			i = end - 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
+
+// formatJSONNumber formats a JSON number literal with precision decimal places unless it is an integer
+func formatJSONNumber(literal []byte, precision int) []byte {
+	if !bytes.ContainsAny(literal, ".eE") {
+		return literal
+	}
+	value, err := strconv.ParseFloat(string(literal), 64)
+	if err != nil {
+		return literal
+	}
+	return strconv.AppendFloat(nil, value, 'f', precision, 64)
+}
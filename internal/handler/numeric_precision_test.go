@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_NumericPrecision(t *testing.T) {
+	h := newSectionHandler("prices", config.Section{
+		PathPattern:      "/prices/*",
+		BodyIDPaths:      []string{"/id"},
+		ReturnBody:       true,
+		NumericPrecision: 2,
+	})
+	created := serveRequest(h, http.MethodPost, "/prices",
+		`{"id":"1","amount":3.14159,"rate":2.5,"tiny":1e-7,"count":7,"label":"1.23456"}`)
+	require.Equal(t, http.StatusCreated, created.Code)
+	rounded := `{"id":"1","amount":3.14,"rate":2.50,"tiny":0.00,"count":7,"label":"1.23456"}`
+	assert.Equal(t, rounded, created.Body.String())
+
+	single := serveRequest(h, http.MethodGet, "/prices/1", "")
+	require.Equal(t, http.StatusOK, single.Code)
+	assert.Equal(t, rounded, single.Body.String(), "integers and strings are left as they are")
+
+	require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/prices", `{"id":"2","amount":-0.125}`).Code)
+	collection := serveRequest(h, http.MethodGet, "/prices", "")
+	require.Equal(t, http.StatusOK, collection.Code)
+	assert.Contains(t, collection.Body.String(), `"amount":3.14`)
+	assert.Contains(t, collection.Body.String(), `"amount":-0.12`)
+}
+
+func TestUniHandler_NumericPrecisionDisabled(t *testing.T) {
+	h := newSectionHandler("prices", config.Section{PathPattern: "/prices/*", BodyIDPaths: []string{"/id"}})
+	require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/prices", `{"id":"1","amount":3.14159}`).Code)
+
+	assert.JSONEq(t, `{"id":"1","amount":3.14159}`, serveRequest(h, http.MethodGet, "/prices/1", "").Body.String())
+}
@@ -0,0 +1,38 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniHandler_OPTIONS(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern: "/users/*",
+		BodyIDPaths: []string{"/id"},
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedAllow  string
+	}{
+		{name: "collection path", path: "/users", expectedStatus: http.StatusNoContent,
+			expectedAllow: "GET, HEAD, POST, PUT, DELETE, OPTIONS"},
+		{name: "resource path", path: "/users/1", expectedStatus: http.StatusNoContent,
+			expectedAllow: "GET, HEAD, POST, PUT, DELETE, OPTIONS"},
+		{name: "unknown path", path: "/orders/1", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRequest(uniHandler, http.MethodOptions, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
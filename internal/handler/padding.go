@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"bytes"
+	"strings"
+)
+
+// PadBody pads the body up to size bytes.
+// JSON bodies get whitespace before the closing bracket so they remain valid JSON.
+func PadBody(body []byte, contentType string, size int) []byte {
+	missing := size - len(body)
+	if missing <= 0 {
+		return body
+	}
+	padding := bytes.Repeat([]byte(" "), missing)
+
+	trimmed := bytes.TrimRight(body, " \t\r\n")
+	isJSON := strings.Contains(strings.ToLower(contentType), "json")
+	if isJSON && len(trimmed) > 0 && (trimmed[len(trimmed)-1] == '}' || trimmed[len(trimmed)-1] == ']') {
+		closing := len(trimmed) - 1
+		padded := make([]byte, 0, size)
+		padded = append(padded, body[:closing]...)
+		padded = append(padded, padding...)
+		return append(padded, body[closing:]...)
+	}
+	return append(body, padding...)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// getPartialCollection returns at most section.PartialCollectionSize resources, ordered by ID,
+// wrapped as {"items": [...], "hasMore": bool} where hasMore tells whether resources were left out
+func (h *UniHandler) getPartialCollection(
+	resources []model.UniData, section *config.Section, sectionName string,
+) *http.Response {
+	sort.Slice(resources, func(i, j int) bool {
+		return compareIDs(resourceID(resources[i]), resourceID(resources[j])) < 0
+	})
+	hasMore := len(resources) > section.PartialCollectionSize
+	page := resources[:min(section.PartialCollectionSize, len(resources))]
+
+	transformed, err := h.transformResourceCollection(page, section, sectionName)
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "response transformation failed")
+	}
+	resp := h.buildCollectionResponse(transformed, section)
+
+	items, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	wrapped := append([]byte(`{"items":`), items...)
+	wrapped = append(wrapped, `,"hasMore":`+strconv.FormatBool(hasMore)+`}`...)
+	resp.Body = io.NopCloser(bytes.NewReader(wrapped))
+	return resp
+}
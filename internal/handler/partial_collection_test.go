@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type partialCollection struct {
+	Items   []map[string]any `json:"items"`
+	HasMore bool             `json:"hasMore"`
+}
+
+func newPartialHandler(t *testing.T, size int, ids ...string) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("items", config.Section{
+		PathPattern:           "/items/*",
+		BodyIDPaths:           []string{"/id"},
+		PartialCollectionSize: size,
+	})
+	for _, id := range ids {
+		w := serveRequest(uniHandler, http.MethodPost, "/items", `{"id":"`+id+`"}`)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	return uniHandler
+}
+
+func getPartialCollection(t *testing.T, uniHandler http.Handler) partialCollection {
+	t.Helper()
+	w := serveRequest(uniHandler, http.MethodGet, "/items", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var result partialCollection
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return result
+}
+
+func TestUniHandler_PartialCollection_CapsItemsAndFlagsMore(t *testing.T) {
+	uniHandler := newPartialHandler(t, 2, "3", "1", "2")
+
+	result := getPartialCollection(t, uniHandler)
+
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "1", result.Items[0]["id"])
+	assert.Equal(t, "2", result.Items[1]["id"])
+	assert.True(t, result.HasMore)
+}
+
+func TestUniHandler_PartialCollection_NoMoreWhenAllFit(t *testing.T) {
+	uniHandler := newPartialHandler(t, 2, "1", "2")
+
+	result := getPartialCollection(t, uniHandler)
+
+	assert.Len(t, result.Items, 2)
+	assert.False(t, result.HasMore)
+}
@@ -0,0 +1,32 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_PathPatterns_ShareResourcesAcrossVersions(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:  "/v1/users/*",
+		PathPatterns: []string{"/v2/users/*"},
+		BodyIDPaths:  []string{"/id"},
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/v2/users", `{"id":"1","name":"alice"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/v2/users/1", w.Header().Get("Location"))
+
+	for _, path := range []string{"/v1/users/1", "/v2/users/1"} {
+		w = serveRequest(uniHandler, http.MethodGet, path, "")
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.JSONEq(t, `{"id":"1","name":"alice"}`, w.Body.String(), path)
+	}
+
+	w = serveRequest(uniHandler, http.MethodGet, "/v2/users", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1","name":"alice"}]`, w.Body.String())
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// pollIntervalHeader suggests to polling clients how many seconds to wait before requesting again
+const pollIntervalHeader = "X-Poll-Interval"
+
+// setPollInterval adds the section's poll interval, in whole seconds rounded up, to successful responses
+func setPollInterval(resp *http.Response, section *config.Section) {
+	if resp == nil || section.PollIntervalHeader <= 0 || resp.StatusCode >= http.StatusBadRequest {
+		return
+	}
+	resp.Header.Set(pollIntervalHeader, strconv.Itoa(int(math.Ceil(section.PollIntervalHeader.Seconds()))))
+}
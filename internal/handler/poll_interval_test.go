@@ -0,0 +1,49 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolledHandler(t *testing.T, interval time.Duration) http.Handler {
+	t.Helper()
+	uniHandler := newSectionHandler("jobs", config.Section{
+		PathPattern:        "/jobs/*",
+		BodyIDPaths:        []string{"/id"},
+		PollIntervalHeader: interval,
+	})
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/jobs", `{"id":"1"}`).Code)
+	return uniHandler
+}
+
+func TestUniHandler_PollInterval_SetOnReads(t *testing.T) {
+	uniHandler := newPolledHandler(t, 5*time.Second)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/jobs/1"},
+		{http.MethodHead, "/jobs/1"},
+		{http.MethodGet, "/jobs"},
+	} {
+		w := serveRequest(uniHandler, tc.method, tc.path, "")
+		require.Equal(t, http.StatusOK, w.Code, tc.method+" "+tc.path)
+		assert.Equal(t, "5", w.Header().Get("X-Poll-Interval"), tc.method+" "+tc.path)
+	}
+}
+
+func TestUniHandler_PollInterval_RoundsUpAndSkipsErrors(t *testing.T) {
+	uniHandler := newPolledHandler(t, 1500*time.Millisecond)
+
+	assert.Equal(t, "2", serveRequest(uniHandler, http.MethodGet, "/jobs/1", "").Header().Get("X-Poll-Interval"))
+	assert.Empty(t, serveRequest(uniHandler, http.MethodGet, "/jobs/missing", "").Header().Get("X-Poll-Interval"))
+}
+
+func TestUniHandler_PollInterval_NotSetByDefault(t *testing.T) {
+	uniHandler := newPolledHandler(t, 0)
+
+	assert.Empty(t, serveRequest(uniHandler, http.MethodGet, "/jobs/1", "").Header().Get("X-Poll-Interval"))
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// prettyJSONIndent is the indentation of pretty-printed JSON bodies
+const prettyJSONIndent = "  "
+
+// IndentJSON returns a JSON body indented for readability. Bodies of other content types and
+// invalid JSON are returned unchanged. Numbers, key order and string escapes are kept as they are.
+func IndentJSON(contentType string, body []byte) []byte {
+	if len(body) == 0 || !strings.Contains(strings.ToLower(contentType), "json") {
+		return body
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", prettyJSONIndent); err != nil {
+		return body
+	}
+	return indented.Bytes()
+}
+
+// EnablePrettyJSON makes the handler indent JSON response bodies
+func (h *UniHandler) EnablePrettyJSON() {
+	h.prettyJSON = true
+}
+
+// prettyPrint indents the JSON body of the response when pretty printing is enabled
+func (h *UniHandler) prettyPrint(resp *http.Response) *http.Response {
+	if !h.prettyJSON || resp == nil || resp.Body == nil {
+		return resp
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		h.logger.Error("failed to read response body for pretty printing", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(IndentJSON(resp.Header.Get(contentTypeHeader), body)))
+	resp.Header.Del("Content-Length")
+	return resp
+}
@@ -0,0 +1,37 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndentJSON(t *testing.T) {
+	assert.Equal(t, "{\n  \"id\": 1.50,\n  \"tags\": [\n    \"a\"\n  ]\n}",
+		string(handler.IndentJSON("application/json", []byte(`{"id":1.50,"tags":["a"]}`))))
+	assert.Equal(t, `<a><b/></a>`, string(handler.IndentJSON("application/xml", []byte(`<a><b/></a>`))))
+	assert.Equal(t, `{"id":`, string(handler.IndentJSON("application/json", []byte(`{"id":`))))
+}
+
+func TestUniHandler_PrettyJSON(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+	uniHandler.EnablePrettyJSON()
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`).Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+	assert.Equal(t, "{\n  \"id\": \"1\"\n}", w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodGet, "/users", "")
+	assert.Equal(t, "[\n  {\n    \"id\": \"1\"\n  }\n]", w.Body.String())
+}
+
+func TestUniHandler_PrettyJSON_DisabledByDefault(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`).Code)
+
+	assert.Equal(t, `{"id":"1"}`, serveRequest(uniHandler, http.MethodGet, "/users/1", "").Body.String())
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// processingTimeHeader reports how long the handler took to build the response, in milliseconds
+const processingTimeHeader = "X-Processing-Time-Ms"
+
+// reportProcessingTime sets the processing time header in report_processing_time sections,
+// measured since start and so including configured delays such as the latency schedule
+func (h *UniHandler) reportProcessingTime(req *http.Request, resp *http.Response, start time.Time) *http.Response {
+	if resp == nil {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || !section.ReportProcessingTime {
+		return resp
+	}
+	resp.Header.Set(processingTimeHeader, strconv.FormatInt(time.Since(start).Milliseconds(), 10))
+	return resp
+}
@@ -0,0 +1,45 @@
+package handler_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_ReportProcessingTime(t *testing.T) {
+	h := newSectionHandler("users", config.Section{
+		PathPattern:          "/users/*",
+		BodyIDPaths:          []string{"/id"},
+		ReportProcessingTime: true,
+		LatencySchedule:      config.LatencySchedule{"09:00-17:00": 120 * time.Millisecond},
+	})
+	now := time.Date(2024, time.March, 4, 7, 0, 0, 0, time.Local)
+	h.SetClock(func() time.Time { return now })
+
+	processingTime := func(method, path, body string) int {
+		w := serveRequest(h, method, path, body)
+		require.Less(t, w.Code, 300, w.Body.String())
+		ms, err := strconv.Atoi(w.Header().Get("X-Processing-Time-Ms"))
+		require.NoError(t, err)
+		return ms
+	}
+
+	assert.Less(t, processingTime(http.MethodPost, "/users", `{"id":"1"}`), 100)
+
+	now = time.Date(2024, time.March, 4, 10, 0, 0, 0, time.Local)
+	assert.GreaterOrEqual(t, processingTime(http.MethodGet, "/users/1", ""), 120, "the delay is included")
+}
+
+func TestUniHandler_ReportProcessingTimeDisabled(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+
+	w := serveRequest(h, http.MethodPost, "/users", `{"id":"1"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("X-Processing-Time-Ms"))
+}
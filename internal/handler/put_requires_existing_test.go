@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_PutRequiresExisting(t *testing.T) {
+	tests := []struct {
+		name            string
+		requireExisting bool
+		wantMissing     int
+	}{
+		{name: "upsert by default", requireExisting: false, wantMissing: http.StatusOK},
+		{name: "update only", requireExisting: true, wantMissing: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newSectionHandler("users", config.Section{
+				PathPattern:         "/users/*",
+				BodyIDPaths:         []string{"/id"},
+				ReturnBody:          true,
+				PutRequiresExisting: tt.requireExisting,
+			})
+			require.Equal(t, http.StatusCreated, serveRequest(h, http.MethodPost, "/users", `{"id":"1"}`).Code)
+
+			updated := serveRequest(h, http.MethodPut, "/users/1", `{"id":"1","name":"Ann"}`)
+			require.Equal(t, http.StatusOK, updated.Code, updated.Body.String())
+			assert.JSONEq(t, `{"id":"1","name":"Ann"}`, updated.Body.String())
+
+			assert.Equal(t, tt.wantMissing, serveRequest(h, http.MethodPut, "/users/2", `{"id":"2"}`).Code)
+			assert.Equal(t, tt.wantMissing, serveRequest(h, http.MethodGet, "/users/2", "").Code,
+				"the missing resource is only created by upsert")
+		})
+	}
+}
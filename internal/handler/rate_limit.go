@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// rateBuckets holds the token buckets of rate limited sections, keyed by section and caller
+type rateBuckets struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket holds the tokens left for a caller and when they were last counted
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newRateBuckets creates an empty rateBuckets
+func newRateBuckets() *rateBuckets {
+	return &rateBuckets{buckets: make(map[string]*tokenBucket)}
+}
+
+// checkRateLimit takes a token from the bucket of the request's section and caller, answering
+// 429 Too Many Requests with Retry-After and X-RateLimit-* headers when the bucket is empty.
+// Rejected requests take no token.
+func (h *UniHandler) checkRateLimit(req *http.Request) *http.Response {
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil || section.RateLimit == nil {
+		return nil
+	}
+	limit := section.RateLimit
+
+	h.rateLimits.mu.Lock()
+	defer h.rateLimits.mu.Unlock()
+
+	bucket := h.rateLimits.refill(rateLimitKey(req, sectionName, limit), limit, h.clock())
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return nil
+	}
+	resp := h.errorResponse(http.StatusTooManyRequests,
+		fmt.Sprintf("rate limit exceeded: %d requests per %s", limit.Requests, limit.Window))
+	setRateLimitHeaders(resp.Header, limit, bucket)
+	resp.Header.Set("Retry-After", strconv.Itoa(secondsUntil(1-bucket.tokens, limit)))
+	return resp
+}
+
+// addRateLimitHeaders reports the limit, the requests left and when the bucket is full again on
+// responses of rate limited sections
+func (h *UniHandler) addRateLimitHeaders(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.Header.Get("X-RateLimit-Limit") != "" {
+		return resp
+	}
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil || section.RateLimit == nil {
+		return resp
+	}
+
+	h.rateLimits.mu.Lock()
+	defer h.rateLimits.mu.Unlock()
+
+	bucket := h.rateLimits.refill(rateLimitKey(req, sectionName, section.RateLimit), section.RateLimit, h.clock())
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	setRateLimitHeaders(resp.Header, section.RateLimit, bucket)
+	return resp
+}
+
+// refill returns the bucket under key with the tokens earned since it was last counted added, up to
+// the limit. New buckets start full. Callers must hold the lock.
+func (rb *rateBuckets) refill(key string, limit *config.RateLimit, now time.Time) *tokenBucket {
+	bucket, ok := rb.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Requests), updated: now}
+		rb.buckets[key] = bucket
+		return bucket
+	}
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		earned := elapsed.Seconds() * float64(limit.Requests) / limit.Window.Seconds()
+		bucket.tokens = math.Min(float64(limit.Requests), bucket.tokens+earned)
+		bucket.updated = now
+	}
+	return bucket
+}
+
+// rateLimitKey identifies the bucket of the request: one per section, client address or header value
+func rateLimitKey(req *http.Request, sectionName string, limit *config.RateLimit) string {
+	switch limit.Per {
+	case config.RateLimitPerClient:
+		return sectionName + "|" + clientAddress(req)
+	case config.RateLimitPerHeader:
+		return sectionName + "|" + req.Header.Get(limit.HeaderName())
+	default:
+		return sectionName
+	}
+}
+
+// setRateLimitHeaders sets X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset, the
+// seconds until the bucket is full again
+func setRateLimitHeaders(header http.Header, limit *config.RateLimit, bucket *tokenBucket) {
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(int(bucket.tokens)))
+	header.Set("X-RateLimit-Reset", strconv.Itoa(secondsUntil(float64(limit.Requests)-bucket.tokens, limit)))
+}
+
+// secondsUntil returns the whole seconds, rounded up, the bucket takes to earn the given tokens
+func secondsUntil(tokens float64, limit *config.RateLimit) int {
+	if tokens <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens * limit.Window.Seconds() / float64(limit.Requests)))
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedHandler(limit *config.RateLimit, now *time.Time) *handler.UniHandler {
+	h := newSectionHandler("items", config.Section{
+		PathPattern: "/items/*",
+		BodyIDPaths: []string{"/id"},
+		RateLimit:   limit,
+	})
+	h.SetClock(func() time.Time { return *now })
+	return h
+}
+
+func TestUniHandler_RateLimit(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	h := newRateLimitedHandler(&config.RateLimit{Requests: 2, Window: time.Minute}, &now)
+
+	w := serveRequest(h, http.MethodPost, "/items", `{"id":"1"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, http.StatusOK, serveRequest(h, http.MethodGet, "/items/1", "").Code)
+
+	w = serveRequest(h, http.MethodGet, "/items/1", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("X-RateLimit-Reset"))
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, http.StatusOK, serveRequest(h, http.MethodGet, "/items/1", "").Code, "a token is earned back")
+	assert.Equal(t, http.StatusTooManyRequests, serveRequest(h, http.MethodGet, "/items/1", "").Code)
+}
+
+func TestUniHandler_RateLimit_PerHeader(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	h := newRateLimitedHandler(&config.RateLimit{
+		Requests: 1, Window: time.Minute, Per: config.RateLimitPerHeader,
+	}, &now)
+
+	get := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, get("alice"))
+	assert.Equal(t, http.StatusTooManyRequests, get("alice"))
+	assert.Equal(t, http.StatusNoContent, get("bob"), "every API key has its own limit")
+}
+
+func TestUniHandler_RateLimit_Concurrent(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	h := newRateLimitedHandler(&config.RateLimit{Requests: 10, Window: time.Hour}, &now)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	served := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if serveRequest(h, http.MethodOptions, "/items", "").Code == http.StatusNoContent {
+				mu.Lock()
+				served++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10, served)
+}
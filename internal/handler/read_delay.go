@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// readSource returns the section whose storage GET requests read: the command section named by
+// read_from for query sections, otherwise the matched section itself
+func (h *UniHandler) readSource(section *config.Section, sectionName string) (*config.Section, string) {
+	if section.ReadFrom == "" {
+		return section, sectionName
+	}
+	source, ok := h.uniCfg.Section(section.ReadFrom)
+	if !ok {
+		return section, sectionName
+	}
+	return &source, section.ReadFrom
+}
+
+// readVisible reports whether a resource is visible to a section with the given read delay.
+// Resources without a write time (e.g. seeded directly into storage) are always visible.
+func readVisible(resource model.UniData, delay time.Duration) bool {
+	return delay <= 0 || resource.WrittenAt.IsZero() || time.Since(resource.WrittenAt) >= delay
+}
+
+// filterReadVisible drops resources written less than delay ago
+func filterReadVisible(resources []model.UniData, delay time.Duration) []model.UniData {
+	if delay <= 0 {
+		return resources
+	}
+	visible := make([]model.UniData, 0, len(resources))
+	for _, resource := range resources {
+		if readVisible(resource, delay) {
+			visible = append(visible, resource)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReadDelay = 50 * time.Millisecond
+
+func newReadDelayHandler() *handler.UniHandler {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"orders": {
+				PathPattern: "/orders/*",
+				BodyIDPaths: []string{"/id"},
+			},
+			"order-views": {
+				PathPattern: "/order-views/*",
+				ReadFrom:    "orders",
+				ReadDelay:   testReadDelay,
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	return handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+}
+
+func TestUniHandler_ReadDelay_HidesFreshWrites(t *testing.T) {
+	uniHandler := newReadDelayHandler()
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1","total":10}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveRequest(uniHandler, http.MethodGet, "/order-views/1", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveRequest(uniHandler, http.MethodGet, "/order-views", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+
+	// The command section itself reads its own writes immediately
+	w = serveRequest(uniHandler, http.MethodGet, "/orders/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUniHandler_ReadDelay_VisibleAfterDelay(t *testing.T) {
+	uniHandler := newReadDelayHandler()
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1","total":10}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	time.Sleep(testReadDelay + 10*time.Millisecond)
+
+	w = serveRequest(uniHandler, http.MethodGet, "/order-views/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","total":10}`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodGet, "/order-views", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1","total":10}]`, w.Body.String())
+}
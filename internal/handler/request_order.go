@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// stepProgress tracks, per section, how many require_order steps have completed in sequence
+type stepProgress struct {
+	mu        sync.Mutex
+	completed map[string]int
+}
+
+// newStepProgress creates an empty stepProgress
+func newStepProgress() *stepProgress {
+	return &stepProgress{completed: make(map[string]int)}
+}
+
+// orderedStep identifies the require_order step a request performs
+type orderedStep struct {
+	sectionName string
+	index       int
+}
+
+// checkRequestOrder answers writes to a require_order step whose predecessor has not completed with
+// 409 Conflict. Otherwise it returns the step the request performs, if any, to be completed on success.
+func (h *UniHandler) checkRequestOrder(req *http.Request) (*orderedStep, *http.Response) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil, nil
+	}
+
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil || len(section.RequireOrder) == 0 {
+		return nil, nil
+	}
+	index := section.OrderStep(req.URL.Path)
+	if index < 0 {
+		return nil, nil
+	}
+
+	h.stepProgress.mu.Lock()
+	completed := h.stepProgress.completed[sectionName]
+	h.stepProgress.mu.Unlock()
+
+	if index > completed {
+		return nil, h.errorResponse(http.StatusConflict, fmt.Sprintf(
+			"out of order: %s requires %s to complete first", req.URL.Path, section.RequireOrder[completed]))
+	}
+	return &orderedStep{sectionName: sectionName, index: index}, nil
+}
+
+// completeStep advances the section's progress when the next expected step succeeded.
+// Repeating an already completed step is allowed and leaves the progress unchanged.
+func (h *UniHandler) completeStep(step *orderedStep, resp *http.Response) {
+	if step == nil || resp == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+
+	h.stepProgress.mu.Lock()
+	defer h.stepProgress.mu.Unlock()
+	if h.stepProgress.completed[step.sectionName] == step.index {
+		h.stepProgress.completed[step.sectionName] = step.index + 1
+	}
+}
@@ -0,0 +1,49 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOrderedHandler() http.Handler {
+	return newSectionHandler("saga", config.Section{
+		PathPattern:  "/saga/**",
+		BodyIDPaths:  []string{"/id"},
+		RequireOrder: []string{"/saga/reserve", "/saga/pay", "/saga/ship"},
+	})
+}
+
+func TestUniHandler_RequireOrder_OutOfOrder(t *testing.T) {
+	uniHandler := newOrderedHandler()
+
+	w := serveRequest(uniHandler, http.MethodPost, "/saga/pay", `{"id":"1"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "/saga/reserve")
+
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/saga/reserve", `{"id":"1"}`).Code)
+	assert.Equal(t, http.StatusConflict, serveRequest(uniHandler, http.MethodPost, "/saga/ship", `{"id":"1"}`).Code)
+}
+
+func TestUniHandler_RequireOrder_InOrder(t *testing.T) {
+	uniHandler := newOrderedHandler()
+
+	for i, step := range []string{"/saga/reserve", "/saga/pay", "/saga/ship"} {
+		body := fmt.Sprintf(`{"id":"%d"}`, i)
+		assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, step, body).Code, step)
+	}
+	// Completed steps may be repeated
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/saga/reserve", `{"id":"3"}`).Code)
+}
+
+func TestUniHandler_RequireOrder_FailedStepDoesNotAdvance(t *testing.T) {
+	uniHandler := newOrderedHandler()
+
+	assert.Equal(t, http.StatusBadRequest, serveRequest(uniHandler, http.MethodPost, "/saga/reserve", `{not json`).Code)
+	assert.Equal(t, http.StatusConflict, serveRequest(uniHandler, http.MethodPost, "/saga/pay", `{"id":"1"}`).Code)
+	// Reads are not steps
+	assert.NotEqual(t, http.StatusConflict, serveRequest(uniHandler, http.MethodGet, "/saga/pay/1", "").Code)
+}
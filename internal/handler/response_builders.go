@@ -2,6 +2,7 @@ package handler
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
@@ -28,8 +29,8 @@ func (h *UniHandler) buildPOSTResponse(
 		Header:     make(http.Header),
 	}
 	
-	// Set Location header
-	if responseData.Location != "" {
+	// Set Location header unless the section suppresses it; the resource stays stored under its IDs
+	if responseData.Location != "" && !section.SuppressLocation {
 		resp.Header.Set("Location", responseData.Location)
 	}
 	
@@ -73,13 +74,18 @@ func (*UniHandler) buildPUTResponse(data model.UniData, section *config.Section)
 // buildDELETEResponse builds response for DELETE operations based on configuration
 func (*UniHandler) buildDELETEResponse(section *config.Section) *http.Response {
 	resp := &http.Response{
-		StatusCode: http.StatusNoContent,
+		StatusCode: section.DeleteResponse.Status(),
 		Header:     make(http.Header),
 	}
+	if section.DeleteResponse != nil {
+		for name, value := range section.DeleteResponse.Headers {
+			resp.Header.Set(name, value)
+		}
+	}
 	
 	// For DELETE operations, only return body if explicitly configured
 	// Most DELETE operations should return empty body regardless of transformations
-	if section.ReturnBody {
+	if section.ReturnBody && resp.StatusCode != http.StatusNotModified {
 		// Since DELETE doesn't have resource data to return, we return empty JSON object
 		resp.Body = io.NopCloser(strings.NewReader("{}"))
 		resp.Header.Set("Content-Type", "application/json")
@@ -110,8 +116,11 @@ func (*UniHandler) buildSingleResourceResponse(data model.UniData) *http.Respons
 }
 
 // buildCollectionResponse builds response for collection of resources
-func (h *UniHandler) buildCollectionResponse(resources []model.UniData) *http.Response {
+func (h *UniHandler) buildCollectionResponse(resources []model.UniData, section *config.Section) *http.Response {
 	jsonItems := h.extractJSONItems(resources)
+	if section.DisableHTMLEscape {
+		jsonItems = h.unescapeHTMLItems(jsonItems)
+	}
 	responseBody := h.buildJSONArrayBody(jsonItems)
 	
 	return &http.Response{
@@ -132,6 +141,32 @@ func (*UniHandler) extractJSONItems(resources []model.UniData) [][]byte {
 	return jsonItems
 }
 
+// unescapeHTMLItems re-encodes JSON items with HTML escaping disabled, so \u003c, \u003e and \u0026
+// escapes left by earlier re-encoding become literal characters again. Invalid items are kept as is.
+func (h *UniHandler) unescapeHTMLItems(jsonItems [][]byte) [][]byte {
+	unescaped := make([][]byte, 0, len(jsonItems))
+	for _, item := range jsonItems {
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		decoder.UseNumber()
+		var doc any
+		if err := decoder.Decode(&doc); err != nil {
+			h.logger.Debug("collection item is not valid JSON, keeping it escaped", errorLogKey, err)
+			unescaped = append(unescaped, item)
+			continue
+		}
+
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(doc); err != nil {
+			unescaped = append(unescaped, item)
+			continue
+		}
+		unescaped = append(unescaped, bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
+	return unescaped
+}
+
 // buildJSONArrayBody creates JSON array from items
 func (*UniHandler) buildJSONArrayBody(jsonItems [][]byte) []byte {
 	if len(jsonItems) == 0 {
@@ -168,4 +203,108 @@ func (*UniHandler) errorResponse(statusCode int, message string) *http.Response
 		Header:     make(http.Header),
 		Body:       io.NopCloser(strings.NewReader(message)),
 	}
-}
\ No newline at end of file
+}
+
+// graphQLError represents a single entry of a GraphQL errors array
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// graphQLEnvelope represents a GraphQL-shaped response body
+type graphQLEnvelope struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []graphQLError             `json:"errors,omitempty"`
+}
+
+// wrapGraphQLResponse wraps the response body in a GraphQL envelope when the section requests it
+func (h *UniHandler) wrapGraphQLResponse(resp *http.Response, section *config.Section) *http.Response {
+	if resp == nil || section.GraphQLResponse == "" {
+		return resp
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			h.logger.Error("failed to read response body for GraphQL envelope", errorLogKey, err)
+			return h.errorResponse(http.StatusInternalServerError, "failed to build GraphQL response")
+		}
+	}
+
+	envelope := graphQLEnvelope{Data: map[string]json.RawMessage{}}
+	switch {
+	case resp.StatusCode >= http.StatusBadRequest:
+		envelope.Data[section.GraphQLResponse] = json.RawMessage("null")
+		envelope.Errors = []graphQLError{{Message: string(body)}}
+	case json.Valid(body):
+		envelope.Data[section.GraphQLResponse] = json.RawMessage(body)
+	default:
+		quoted, _ := json.Marshal(string(body))
+		envelope.Data[section.GraphQLResponse] = quoted
+	}
+
+	wrapped, err := json.Marshal(envelope)
+	if err != nil {
+		h.logger.Error("failed to encode GraphQL envelope", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to build GraphQL response")
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(wrapped))
+	resp.Header.Set(contentTypeHeader, applicationJSON)
+	resp.Header.Del("Content-Length")
+	return resp
+}
+
+// rewriteResponseObjects applies fn to the top-level JSON object of a successful JSON response,
+// or to each object of a JSON array response. Other responses are returned unchanged.
+func (h *UniHandler) rewriteResponseObjects(resp *http.Response, fn func(map[string]any)) *http.Response {
+	if resp == nil || resp.Body == nil || resp.StatusCode >= http.StatusBadRequest {
+		return resp
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get(contentTypeHeader)), "json") {
+		return resp
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		h.logger.Error("failed to read response body for rewriting", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+
+	rewritten, err := rewriteJSONObjects(body, fn)
+	if err != nil {
+		h.logger.Debug("response body is not valid JSON, skipping rewrite", errorLogKey, err)
+		rewritten = body
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(rewritten))
+	resp.Header.Del("Content-Length")
+	return resp
+}
+
+// rewriteJSONObjects applies fn to a JSON object body or to each object of a JSON array body
+func rewriteJSONObjects(body []byte, fn func(map[string]any)) ([]byte, error) {
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	switch typed := doc.(type) {
+	case map[string]any:
+		fn(typed)
+	case []any:
+		for _, item := range typed {
+			if obj, ok := item.(map[string]any); ok {
+				fn(obj)
+			}
+		}
+	default:
+		return body, nil
+	}
+	return json.Marshal(doc)
+}
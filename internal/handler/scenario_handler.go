@@ -14,6 +14,11 @@ import (
 const (
 	uuidLogKey = "uuid"
 	applicationJSON = "application/json"
+
+	// enableAction, disableAction and resetAction are the POST /_uni/scenarios/{uuid}/<action> sub-resources
+	enableAction  = "enable"
+	disableAction = "disable"
+	resetAction   = "reset"
 )
 
 // ScenarioHandler handles endpoints for managing scenarios
@@ -72,7 +77,18 @@ func (h *ScenarioHandler) handleGetRequest(w http.ResponseWriter, r *http.Reques
 func (h *ScenarioHandler) handlePostRequest(w http.ResponseWriter, r *http.Request, path string) {
 	if path == "" {
 		h.handleCreate(w, r)
-	} else {
+		return
+	}
+
+	uuid, action, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	switch action {
+	case enableAction:
+		h.handleSetEnabled(w, r, uuid, true)
+	case disableAction:
+		h.handleSetEnabled(w, r, uuid, false)
+	case resetAction:
+		h.handleReset(w, r, uuid)
+	default:
 		http.NotFound(w, r)
 	}
 }
@@ -163,6 +179,35 @@ func (h *ScenarioHandler) handleUpdate(w http.ResponseWriter, r *http.Request, u
 	h.writeScenarioResponse(w, scenario, http.StatusOK)
 }
 
+func (h *ScenarioHandler) handleSetEnabled(w http.ResponseWriter, r *http.Request, uuid string, enabled bool) {
+	scenario, err := h.service.SetScenarioEnabled(r.Context(), uuid, enabled)
+	if err != nil {
+		h.logger.Error("failed to switch scenario", errorLogKey, err, uuidLogKey, uuid, "enabled", enabled)
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Scenario not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	h.writeScenarioResponse(w, scenario, http.StatusOK)
+}
+
+func (h *ScenarioHandler) handleReset(w http.ResponseWriter, r *http.Request, uuid string) {
+	if err := h.service.ResetScenario(r.Context(), uuid); err != nil {
+		h.logger.Error("failed to reset scenario", errorLogKey, err, uuidLogKey, uuid)
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Scenario not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *ScenarioHandler) handleDelete(w http.ResponseWriter, r *http.Request, uuid string) {
 	// Delete the scenario
 	if err := h.service.DeleteScenario(r.Context(), uuid); err != nil {
@@ -378,3 +378,63 @@ func TestGetScenarioByPath(t *testing.T) {
 		})
 	}
 }
+
+func TestScenarioHandler_Create_InvalidPath(t *testing.T) {
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+
+	body := `{"requestPath":"GET api/test","statusCode":200,"contentType":"application/json"}`
+	req := httptest.NewRequest(http.MethodPost, "/_uni/scenarios", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	scenarioHandler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "api/test")
+}
+
+func TestScenarioHandler_EnableDisable(t *testing.T) {
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+	_, err := scenarioService.CreateScenario(context.Background(), model.Scenario{
+		UUID:        "toggle",
+		RequestPath: "GET /api/test",
+		StatusCode:  http.StatusServiceUnavailable,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantEnabled bool
+	}{
+		{name: "disable", path: "/_uni/scenarios/toggle/disable", wantStatus: http.StatusOK, wantEnabled: false},
+		{name: "enable", path: "/_uni/scenarios/toggle/enable", wantStatus: http.StatusOK, wantEnabled: true},
+		{name: "unknown scenario", path: "/_uni/scenarios/missing/enable", wantStatus: http.StatusNotFound},
+		{name: "unknown action", path: "/_uni/scenarios/toggle/pause", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			scenarioHandler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var scenario model.Scenario
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &scenario))
+			require.NotNil(t, scenario.Enabled)
+			assert.Equal(t, tt.wantEnabled, *scenario.Enabled)
+
+			_, found := scenarioService.GetScenarioForRequest(
+				context.Background(), "/api/test", httptest.NewRequest(http.MethodGet, "/api/test", nil))
+			assert.Equal(t, tt.wantEnabled, found)
+		})
+	}
+}
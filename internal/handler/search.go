@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"bytes"
+
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// searchQueryParam names the query parameter carrying the full-text search term
+const searchQueryParam = "q"
+
+// filterBySearchTerm returns the resources whose body contains term, ignoring case.
+// An empty term matches every resource.
+func filterBySearchTerm(resources []model.UniData, term string) []model.UniData {
+	if term == "" {
+		return resources
+	}
+
+	needle := bytes.ToLower([]byte(term))
+	matched := make([]model.UniData, 0, len(resources))
+	for _, resource := range resources {
+		if bytes.Contains(bytes.ToLower(resource.Body), needle) {
+			matched = append(matched, resource)
+		}
+	}
+	return matched
+}
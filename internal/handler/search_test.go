@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchHandler(t *testing.T, fullTextSearch bool) *handler.UniHandler {
+	t.Helper()
+	uniHandler := newSectionHandler("docs", config.Section{
+		PathPattern:    "/docs/*",
+		BodyIDPaths:    []string{"/id"},
+		FullTextSearch: fullTextSearch,
+	})
+	for _, body := range []string{
+		`{"id":"1","title":"Getting Started with Go"}`,
+		`{"id":"2","title":"Advanced GOLANG patterns"}`,
+		`{"id":"3","title":"Rust ownership"}`,
+	} {
+		w := serveRequest(uniHandler, http.MethodPost, "/docs", body)
+		require.Equal(t, http.StatusCreated, w.Code, body)
+	}
+	return uniHandler
+}
+
+func TestUniHandler_FullTextSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name: "case-insensitive match returns matching documents",
+			path: "/docs?q=go",
+			expected: `[{"id":"1","title":"Getting Started with Go"},` +
+				`{"id":"2","title":"Advanced GOLANG patterns"}]`,
+		},
+		{
+			name:     "non-matching documents are excluded",
+			path:     "/docs?q=rust",
+			expected: `[{"id":"3","title":"Rust ownership"}]`,
+		},
+		{
+			name:     "no match returns empty array",
+			path:     "/docs?q=python",
+			expected: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newSearchHandler(t, true)
+
+			w := serveRequest(uniHandler, http.MethodGet, tt.path, "")
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expected, w.Body.String())
+		})
+	}
+}
+
+func TestUniHandler_FullTextSearch_IgnoredWhenDisabled(t *testing.T) {
+	uniHandler := newSearchHandler(t, false)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/docs?q=rust", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var docs []map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &docs))
+	assert.Len(t, docs, 3)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// seedResult is the JSON body returned by the seed endpoint
+type seedResult struct {
+	Seeded int `json:"seeded"`
+}
+
+// seededResource is a seed item resolved to its section and storage data
+type seededResource struct {
+	section     *config.Section
+	sectionName string
+	data        model.UniData
+}
+
+// HandleSeed inserts the JSON array of model.SeedItem in the request body directly into storage,
+// bypassing ID extraction, request transformations and scenarios, and replaces resources already
+// stored under the same ID. Every item is checked before any is stored, so a rejected request seeds nothing.
+func (h *UniHandler) HandleSeed(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(strings.ToLower(r.Header.Get(contentTypeHeader)), applicationJSON) {
+		http.Error(w, "Unsupported Media Type: Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var items []model.SeedItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		h.logger.Error("failed to unmarshal seed items", errorLogKey, err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	resources := make([]seededResource, 0, len(items))
+	for i, item := range items {
+		resource, err := h.resolveSeedItem(item)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("item %d: %v", i+1, err), http.StatusBadRequest)
+			return
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, resource := range resources {
+		id := resource.data.IDs[0]
+		err := h.service.UpdateResource(r.Context(), resource.sectionName, resource.section.StrictPath, id, resource.data)
+		if err != nil {
+			h.logger.Error("failed to seed resource", errorLogKey, err, "path", resource.data.Location)
+			http.Error(w, "failed to seed resource "+resource.data.Location, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(seedResult{Seeded: len(resources)}); err != nil {
+		h.logger.Error("failed to write seed response", errorLogKey, err)
+	}
+}
+
+// resolveSeedItem finds the section of the seed item's path and builds the data stored for it,
+// as if the item had been POSTed to its collection with the path's last segment as ID
+func (h *UniHandler) resolveSeedItem(item model.SeedItem) (seededResource, error) {
+	path := strings.TrimSuffix(item.Path, config.PathSeparator)
+	separator := strings.LastIndex(path, config.PathSeparator)
+	if separator < 1 || !strings.HasPrefix(path, config.PathSeparator) {
+		return seededResource{}, fmt.Errorf("path %q must be a resource path like /users/123", item.Path)
+	}
+	collection, id := path[:separator], path[separator+1:]
+	section, sectionName, err := h.findSection(path)
+	if err != nil {
+		return seededResource{}, err
+	}
+	if len(item.Body) == 0 {
+		return seededResource{}, errors.New("body is required")
+	}
+
+	contentType := item.ContentType
+	if contentType == "" {
+		contentType = applicationJSON
+	}
+	body := []byte(item.Body)
+	var text string
+	if !strings.Contains(strings.ToLower(contentType), "json") && json.Unmarshal(item.Body, &text) == nil {
+		body = []byte(text)
+	}
+
+	data := model.UniData{
+		Path:        collection,
+		IDs:         []string{id},
+		Location:    path,
+		ContentType: contentType,
+		Body:        body,
+		WrittenAt:   time.Now(),
+	}
+	if section.TTL > 0 {
+		data.ExpiresAt = data.WrittenAt.Add(section.TTL)
+	}
+	return seededResource{section: section, sectionName: sectionName, data: data}, nil
+}
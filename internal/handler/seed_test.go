@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_HandleSeed(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/uuid"}})
+	seed := http.HandlerFunc(h.HandleSeed)
+
+	w := serveRequest(seed, http.MethodPost, "/_uni/seed", `[
+		{"path": "/users/1", "body": {"name": "Ann"}},
+		{"path": "/users/2", "body": {"name": "Bob"}},
+		{"path": "/users/3/", "contentType": "text/plain", "body": "Cid"}
+	]`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"seeded": 3}`, w.Body.String())
+
+	w = serveRequest(h, http.MethodGet, "/users/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name": "Ann"}`, w.Body.String(), "seeded resources skip ID extraction from the body")
+
+	w = serveRequest(h, http.MethodGet, "/users/3", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Cid", w.Body.String())
+
+	w = serveRequest(h, http.MethodGet, "/users", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Bob"`, "seeded resources are listed in their collection")
+
+	w = serveRequest(seed, http.MethodPost, "/_uni/seed", `[{"path": "/users/1", "body": {"name": "Ann B."}}]`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = serveRequest(h, http.MethodGet, "/users/1", "")
+	assert.JSONEq(t, `{"name": "Ann B."}`, w.Body.String(), "seeding replaces stored resources")
+}
+
+func TestUniHandler_HandleSeed_Rejected(t *testing.T) {
+	h := newSectionHandler("users", config.Section{PathPattern: "/users/*"})
+	seed := http.HandlerFunc(h.HandleSeed)
+
+	tests := []struct {
+		name    string
+		body    string
+		problem string
+	}{
+		{name: "invalid JSON", body: `{"path": "/users/1"}`, problem: "Invalid JSON"},
+		{
+			name:    "no section",
+			body:    `[{"path": "/users/9", "body": {}}, {"path": "/orders/1", "body": {}}]`,
+			problem: "item 2",
+		},
+		{name: "collection path", body: `[{"path": "/users", "body": {}}]`, problem: "resource path"},
+		{name: "missing body", body: `[{"path": "/users/9"}]`, problem: "body is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRequest(seed, http.MethodPost, "/_uni/seed", tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.problem)
+		})
+	}
+
+	w := serveRequest(h, http.MethodGet, "/users/9", "")
+	assert.Equal(t, http.StatusNotFound, w.Code, "rejected requests seed nothing")
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// idSequences hands out incrementing integer IDs, one independent sequence per section
+type idSequences struct {
+	mu       sync.Mutex
+	counters map[string]*atomic.Int64
+}
+
+// newIDSequences creates an empty idSequences
+func newIDSequences() *idSequences {
+	return &idSequences{counters: make(map[string]*atomic.Int64)}
+}
+
+// next returns the next ID of the section's sequence, starting at "1"
+func (s *idSequences) next(sectionName string) string {
+	s.mu.Lock()
+	counter, ok := s.counters[sectionName]
+	if !ok {
+		counter = new(atomic.Int64)
+		s.counters[sectionName] = counter
+	}
+	s.mu.Unlock()
+
+	return strconv.FormatInt(counter.Add(1), 10)
+}
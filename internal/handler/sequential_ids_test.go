@@ -0,0 +1,43 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_SequentialIDs(t *testing.T) {
+	uniHandler := newSectionHandler("orders", config.Section{
+		PathPattern:   "/orders/*",
+		BodyIDPaths:   []string{"/id"},
+		SequentialIDs: true,
+	})
+
+	for _, want := range []string{"/orders/1", "/orders/2", "/orders/3"} {
+		w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"item":"book"}`)
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, want, w.Header().Get("Location"))
+	}
+
+	w := serveRequest(uniHandler, http.MethodGet, "/orders/2", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUniHandler_SequentialIDs_BodyIDTakesPrecedence(t *testing.T) {
+	uniHandler := newSectionHandler("orders", config.Section{
+		PathPattern:   "/orders/*",
+		BodyIDPaths:   []string{"/id"},
+		SequentialIDs: true,
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"abc"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/orders/abc", w.Header().Get("Location"))
+
+	w = serveRequest(uniHandler, http.MethodPost, "/orders", `{"item":"book"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/orders/1", w.Header().Get("Location"))
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// signResponse adds the HMAC-SHA256 signature of the response body to sections with
+// sign_responses configured. The body is buffered so the signature covers exactly what is served.
+func (h *UniHandler) signResponse(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil {
+		return resp
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.SignResponses == nil {
+		return resp
+	}
+
+	body, err := bufferResponseBody(resp)
+	if err != nil {
+		h.logger.Error("failed to read response body for signing", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "response signing failed")
+	}
+
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set(section.SignResponses.HeaderName(), computeSignature(section.SignResponses.Secret, body))
+	return resp
+}
+
+// computeSignature returns the hex-encoded HMAC-SHA256 of body keyed with secret
+func computeSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectedSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestUniHandler_SignResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		sign       *config.SignConfig
+		wantHeader string
+	}{
+		{name: "default header", sign: &config.SignConfig{Secret: "s3cret"}, wantHeader: "X-Signature"},
+		{
+			name:       "custom header",
+			sign:       &config.SignConfig{Secret: "s3cret", Header: "X-Hub-Signature"},
+			wantHeader: "X-Hub-Signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newSectionHandler("users", config.Section{
+				PathPattern:   "/users/*",
+				BodyIDPaths:   []string{"/id"},
+				ReturnBody:    true,
+				SignResponses: tt.sign,
+			})
+
+			w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Alice"}`)
+			require.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, expectedSignature("s3cret", w.Body.Bytes()), w.Header().Get(tt.wantHeader))
+
+			w = serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+			require.Equal(t, http.StatusOK, w.Code)
+			require.NotEmpty(t, w.Body.Bytes())
+			assert.Equal(t, expectedSignature("s3cret", w.Body.Bytes()), w.Header().Get(tt.wantHeader))
+		})
+	}
+}
+
+func TestUniHandler_SignResponses_Disabled(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{PathPattern: "/users/*", BodyIDPaths: []string{"/id"}})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("X-Signature"))
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// softDeleteField marks a JSON resource as soft-deleted when set to true
+const softDeleteField = "deleted"
+
+// trySoftDelete marks the resource as deleted instead of removing it in soft_delete sections.
+// It returns nil when the section deletes resources for good or the resource is not a JSON object,
+// which cannot carry the flag and is removed instead.
+func (h *UniHandler) trySoftDelete(
+	ctx context.Context, id string, section *config.Section, sectionName string,
+) *http.Response {
+	if !section.SoftDelete {
+		return nil
+	}
+	resource, err := h.service.GetResource(ctx, sectionName, section.StrictPath, id)
+	if err != nil {
+		return h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+	if isTombstone(resource) {
+		return h.errorResponse(http.StatusGone, "resource deleted")
+	}
+	tombstone, ok := markDeleted(resource)
+	if !ok {
+		return nil
+	}
+	if err := h.service.UpdateResource(ctx, sectionName, section.StrictPath, id, tombstone); err != nil {
+		h.logger.Error("failed to soft-delete resource", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to delete resource")
+	}
+	return h.buildDELETEResponse(section)
+}
+
+// markDeleted returns the resource with the soft delete flag set in its JSON object body
+func markDeleted(resource model.UniData) (model.UniData, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(resource.Body, &fields); err != nil || fields == nil {
+		return resource, false
+	}
+	fields[softDeleteField] = json.RawMessage("true")
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return resource, false
+	}
+	resource.Body = body
+	return resource, true
+}
+
+// isTombstone reports whether the resource's JSON body carries the soft delete flag
+func isTombstone(resource model.UniData) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(resource.Body, &fields); err != nil {
+		return false
+	}
+	var deleted bool
+	return json.Unmarshal(fields[softDeleteField], &deleted) == nil && deleted
+}
+
+// filterTombstones drops soft-deleted resources from a collection
+func filterTombstones(resources []model.UniData) []model.UniData {
+	live := make([]model.UniData, 0, len(resources))
+	for _, resource := range resources {
+		if !isTombstone(resource) {
+			live = append(live, resource)
+		}
+	}
+	return live
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSoftDeleteHandler(softDelete bool) http.Handler {
+	return newSectionHandler("accounts", config.Section{
+		PathPattern: "/accounts/*",
+		BodyIDPaths: []string{"/id"},
+		SoftDelete:  softDelete,
+	})
+}
+
+func TestUniHandler_SoftDelete_TombstoneExcludedFromCollection(t *testing.T) {
+	uniHandler := newSoftDeleteHandler(true)
+	for _, body := range []string{`{"id":"1","name":"Alice"}`, `{"id":"2","name":"Bob"}`} {
+		require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/accounts", body).Code)
+	}
+
+	require.Equal(t, http.StatusNoContent, serveRequest(uniHandler, http.MethodDelete, "/accounts/1", "").Code)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/accounts", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"2","name":"Bob"}]`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodGet, "/accounts/1", "")
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.JSONEq(t, `{"id":"1","name":"Alice","deleted":true}`, w.Body.String())
+
+	assert.Equal(t, http.StatusGone, serveRequest(uniHandler, http.MethodDelete, "/accounts/1", "").Code)
+}
+
+func TestUniHandler_SoftDelete_PutRestores(t *testing.T) {
+	uniHandler := newSoftDeleteHandler(true)
+	require.Equal(t, http.StatusCreated,
+		serveRequest(uniHandler, http.MethodPost, "/accounts", `{"id":"1","name":"Alice"}`).Code)
+	require.Equal(t, http.StatusNoContent, serveRequest(uniHandler, http.MethodDelete, "/accounts/1", "").Code)
+
+	require.Equal(t, http.StatusOK,
+		serveRequest(uniHandler, http.MethodPut, "/accounts/1", `{"id":"1","name":"Alice"}`).Code)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/accounts/1", "").Code)
+}
+
+func TestUniHandler_SoftDelete_DisabledRemoves(t *testing.T) {
+	uniHandler := newSoftDeleteHandler(false)
+	require.Equal(t, http.StatusCreated,
+		serveRequest(uniHandler, http.MethodPost, "/accounts", `{"id":"1","name":"Alice"}`).Code)
+
+	require.Equal(t, http.StatusNoContent, serveRequest(uniHandler, http.MethodDelete, "/accounts/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/accounts/1", "").Code)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+const (
+	// stateStatusField holds the resource's current state in state machine sections
+	stateStatusField = "status"
+	// nextActionsField lists the transitions allowed from the resource's current state
+	nextActionsField = "_next"
+)
+
+// addNextActions sets the _next field of a JSON object resource to the actions the section's
+// state_transitions allow from its current status; statuses without transitions get an empty list
+func addNextActions(data model.UniData, section *config.Section) model.UniData {
+	if len(section.StateTransitions) == 0 {
+		return data
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data.Body, &fields); err != nil || fields == nil {
+		return data
+	}
+	var status string
+	if err := json.Unmarshal(fields[stateStatusField], &status); err != nil {
+		return data
+	}
+
+	actions := section.StateTransitions[status]
+	if actions == nil {
+		actions = []string{}
+	}
+	next, err := json.Marshal(actions)
+	if err != nil {
+		return data
+	}
+	fields[nextActionsField] = next
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+	data.Body = body
+	return data
+}
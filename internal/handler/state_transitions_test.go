@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStateMachineHandler() http.Handler {
+	return newSectionHandler("orders", config.Section{
+		PathPattern: "/orders/*",
+		BodyIDPaths: []string{"/id"},
+		StateTransitions: map[string][]string{
+			"pending":  {"approve", "reject"},
+			"approved": {"ship"},
+		},
+	})
+}
+
+func TestUniHandler_StateTransitions(t *testing.T) {
+	uniHandler := newStateMachineHandler()
+	w := serveRequest(uniHandler, http.MethodPost, "/orders", `{"id":"1","status":"pending"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveRequest(uniHandler, http.MethodGet, "/orders/1", "")
+	assert.JSONEq(t, `{"id":"1","status":"pending","_next":["approve","reject"]}`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodPut, "/orders/1", `{"id":"1","status":"approved"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serveRequest(uniHandler, http.MethodGet, "/orders/1", "")
+	assert.JSONEq(t, `{"id":"1","status":"approved","_next":["ship"]}`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodPut, "/orders/1", `{"id":"1","status":"shipped"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serveRequest(uniHandler, http.MethodGet, "/orders/1", "")
+	assert.JSONEq(t, `{"id":"1","status":"shipped","_next":[]}`, w.Body.String(), "final states allow no actions")
+}
+
+func TestUniHandler_StateTransitions_Collection(t *testing.T) {
+	uniHandler := newStateMachineHandler()
+	for _, body := range []string{`{"id":"1","status":"pending"}`, `{"id":"2","note":"no status"}`} {
+		w := serveRequest(uniHandler, http.MethodPost, "/orders", body)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	w := serveRequest(uniHandler, http.MethodGet, "/orders", "")
+
+	assert.JSONEq(t,
+		`[{"id":"1","status":"pending","_next":["approve","reject"]},{"id":"2","note":"no status"}]`,
+		w.Body.String())
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// defaultStaticContentType is served for files whose extension has no registered media type
+const defaultStaticContentType = "application/octet-stream"
+
+// tryServeStatic serves requests for sections with a static_dir from files on disk.
+// It returns nil when the request does not target a static section.
+func (h *UniHandler) tryServeStatic(req *http.Request) *http.Response {
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil || section.StaticDir == "" {
+		return nil
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp := h.errorResponse(http.StatusMethodNotAllowed, "method not allowed")
+		resp.Header.Set("Allow", "GET, HEAD")
+		return resp
+	}
+
+	filePath, err := h.uniCfg.StaticFilePath(section, req.URL.Path)
+	if err != nil {
+		h.logger.Warn("rejected static file path", pathLogKey, req.URL.Path, errorLogKey, err)
+		return h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			h.logger.Warn("failed to read static file", pathLogKey, filePath, errorLogKey, err)
+		}
+		return h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(filePath))
+	if contentType == "" {
+		contentType = defaultStaticContentType
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{contentTypeHeader: []string{contentType}},
+		Body:       io.NopCloser(bytes.NewReader(content)),
+	}
+	if req.Method == http.MethodHead {
+		return h.suppressResponseBody(resp)
+	}
+	return resp
+}
@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStaticHandler(t *testing.T) *handler.UniHandler {
+	t.Helper()
+	root := t.TempDir()
+	staticDir := filepath.Join(root, "fixtures")
+	require.NoError(t, os.MkdirAll(filepath.Join(staticDir, "assets", "css"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "assets", "logo.png"), []byte("PNGDATA"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "assets", "css", "site.css"), []byte("body{}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0600))
+
+	return newSectionHandler("assets", config.Section{
+		PathPattern: "/assets/**",
+		StaticDir:   staticDir,
+	})
+}
+
+func TestUniHandler_StaticDir(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "serves file with type from extension", method: http.MethodGet, path: "/assets/logo.png",
+			wantStatus: http.StatusOK, wantContentType: "image/png", wantBody: "PNGDATA",
+		},
+		{
+			name: "serves nested file", method: http.MethodGet, path: "/assets/css/site.css",
+			wantStatus: http.StatusOK, wantContentType: "text/css; charset=utf-8", wantBody: "body{}",
+		},
+		{
+			name: "HEAD omits body", method: http.MethodHead, path: "/assets/logo.png",
+			wantStatus: http.StatusOK, wantContentType: "image/png",
+		},
+		{name: "missing file", method: http.MethodGet, path: "/assets/missing.png", wantStatus: http.StatusNotFound},
+		{name: "directory", method: http.MethodGet, path: "/assets/css", wantStatus: http.StatusNotFound},
+		{
+			name: "path traversal rejected", method: http.MethodGet, path: "/assets/../../secret.txt",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "writes not allowed", method: http.MethodPost, path: "/assets/logo.png",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uniHandler := newStaticHandler(t)
+
+			w := serveRequest(uniHandler, tt.method, tt.path, "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+)
+
+// storageFullResponse answers 507 Insufficient Storage when a write failed because the resource
+// limit is reached and new resources are rejected, or returns nil for other errors
+func (h *UniHandler) storageFullResponse(err error) *http.Response {
+	var full *unimockerrors.StorageFullError
+	if !errors.As(err, &full) {
+		return nil
+	}
+	return h.errorResponse(http.StatusInsufficientStorage, full.Error())
+}
@@ -0,0 +1,35 @@
+package handler_test
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniHandler_StorageFull(t *testing.T) {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"items": {PathPattern: "/items/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+	store := storage.NewUniStorage()
+	store.LimitResources(1, true)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	uniHandler := handler.NewUniHandler(
+		service.NewUniService(store, cfg), service.NewScenarioService(storage.NewScenarioStorage()), logger, cfg,
+	)
+
+	assert.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/items", `{"id":"1"}`).Code)
+	assert.Equal(t, http.StatusInsufficientStorage,
+		serveRequest(uniHandler, http.MethodPost, "/items", `{"id":"2"}`).Code)
+	assert.Equal(t, http.StatusInsufficientStorage,
+		serveRequest(uniHandler, http.MethodPut, "/items/3", `{"id":"3"}`).Code, "upserts create resources too")
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodPut, "/items/1", `{"id":"1"}`).Code)
+}
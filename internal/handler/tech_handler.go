@@ -7,21 +7,36 @@ import (
 	"strings"
 
 	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
 )
 
-// TechHandler handles technical endpoints like health checks and metrics
+// TechHandler handles technical endpoints like health and readiness checks, metrics, storage statistics,
+// configuration export and reconfiguration
 type TechHandler struct {
-	prefix  string
-	service *service.TechService
-	logger  *slog.Logger
+	prefix          string
+	service         *service.TechService
+	uniService      *service.UniService
+	scenarioService *service.ScenarioService
+	logger          *slog.Logger
+	uniCfg          *config.UniConfig
 }
 
 // NewTechHandler creates a new instance of TechHandler
-func NewTechHandler(techSvc *service.TechService, logger *slog.Logger) *TechHandler {
+func NewTechHandler(
+	techSvc *service.TechService,
+	uniSvc *service.UniService,
+	scenarioSvc *service.ScenarioService,
+	logger *slog.Logger,
+	cfg *config.UniConfig,
+) *TechHandler {
 	return &TechHandler{
-		prefix:  "/_uni/",
-		service: techSvc,
-		logger:  logger,
+		prefix:          "/_uni/",
+		service:         techSvc,
+		uniService:      uniSvc,
+		scenarioService: scenarioSvc,
+		logger:          logger,
+		uniCfg:          cfg,
 	}
 }
 
@@ -33,6 +48,13 @@ func (h *TechHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		"path", r.URL.Path)
 
 
+	// Reconfiguration is the only technical endpoint accepting POST
+	path := strings.TrimPrefix(r.URL.Path, h.prefix)
+	if path == "config" {
+		h.handleConfigApply(w, r)
+		return
+	}
+
 	// Only allow GET method for technical endpoints
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -40,12 +62,17 @@ func (h *TechHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Handle based on path
-	path := strings.TrimPrefix(r.URL.Path, h.prefix)
 	switch path {
 	case "health":
 		h.handleHealthCheck(w, r)
+	case "ready":
+		h.writeJSONResponse(w, map[string]any{"status": "ready"})
 	case "metrics":
 		h.handleMetrics(w, r)
+	case "stats":
+		h.handleStats(w, r)
+	case "config/export":
+		h.handleConfigExport(w, r)
 	default:
 		http.NotFound(w, r)
 	}
@@ -69,6 +96,54 @@ func (h *TechHandler) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, response)
 }
 
+// handleStats returns the number of stored resources, in total and per section, the number of
+// scenarios and the approximate memory the resources take
+func (h *TechHandler) handleStats(w http.ResponseWriter, r *http.Request) {
+	var resources service.ResourceStats
+	if h.uniService != nil {
+		resources = h.uniService.Stats(r.Context())
+	}
+	resourcesBySection := resources.ResourcesBySection
+	if resourcesBySection == nil {
+		resourcesBySection = map[string]int{}
+	}
+	scenarioCount := 0
+	if h.scenarioService != nil {
+		scenarioCount = h.scenarioService.CountScenarios(r.Context())
+	}
+
+	h.writeJSONResponse(w, map[string]any{
+		"resource_count":       resources.Resources,
+		"resources_by_section": resourcesBySection,
+		"scenario_count":       scenarioCount,
+		"memory_bytes":         resources.Bytes,
+	})
+}
+
+// handleConfigExport returns the current sections and scenarios as YAML loadable by config.LoadFromYAML
+func (h *TechHandler) handleConfigExport(w http.ResponseWriter, r *http.Request) {
+	cfg := h.uniCfg
+	if cfg == nil {
+		cfg = config.NewUniConfig()
+	}
+	var scenarios []model.Scenario
+	if h.scenarioService != nil {
+		scenarios = h.scenarioService.ListScenarios(r.Context())
+	}
+
+	data, err := cfg.ExportYAML(scenarios)
+	if err != nil {
+		h.logger.Error("failed to export configuration", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	if _, err := w.Write(data); err != nil {
+		h.logger.Error("failed to write response", "error", err)
+	}
+}
+
 // writeJSONResponse writes a JSON response
 func (h *TechHandler) writeJSONResponse(w http.ResponseWriter, data any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// throttleTicksPerSec is how many chunks are written per second of throttled output
+const throttleTicksPerSec = 10
+
+// WriteThrottled writes body to w at approximately bytesPerSec, flushing after each chunk.
+// A non-positive rate writes the body at once. It stops early when ctx is cancelled.
+func WriteThrottled(ctx context.Context, w io.Writer, body []byte, bytesPerSec int) error {
+	if bytesPerSec <= 0 {
+		_, err := w.Write(body)
+		return err
+	}
+
+	chunkSize := max(bytesPerSec/throttleTicksPerSec, 1)
+	flusher, _ := w.(http.Flusher)
+	for len(body) > 0 {
+		n := min(chunkSize, len(body))
+		if _, err := w.Write(body[:n]); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		body = body[n:]
+		if len(body) == 0 {
+			break
+		}
+
+		timer := time.NewTimer(time.Duration(n) * time.Second / time.Duration(bytesPerSec))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// WriteAtBandwidth writes body to w as if transferred over a link of bytesPerSec, so the whole
+// body takes len(body)/bytesPerSec. Each chunk is released once the link would have carried it;
+// waiting for absolute deadlines keeps the total delay precise regardless of chunking.
+// A non-positive bandwidth writes the body at once. It stops early when ctx is cancelled.
+func WriteAtBandwidth(ctx context.Context, w io.Writer, body []byte, bytesPerSec int) error {
+	if bytesPerSec <= 0 {
+		_, err := w.Write(body)
+		return err
+	}
+
+	chunkSize := max(bytesPerSec/throttleTicksPerSec, 1)
+	flusher, _ := w.(http.Flusher)
+	start := time.Now()
+	for sent := 0; sent < len(body); {
+		n := min(chunkSize, len(body)-sent)
+		arrival := start.Add(time.Duration(sent+n) * time.Second / time.Duration(bytesPerSec))
+
+		timer := time.NewTimer(time.Until(arrival))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if _, err := w.Write(body[sent : sent+n]); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		sent += n
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteThrottled_Unthrottled(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := handler.WriteThrottled(context.Background(), &buf, []byte("hello"), 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", buf.String())
+}
+
+func TestWriteThrottled_ApproximatesRate(t *testing.T) {
+	var buf bytes.Buffer
+	body := []byte(strings.Repeat("a", 300))
+
+	start := time.Now()
+	err := handler.WriteThrottled(context.Background(), &buf, body, 1000)
+
+	require.NoError(t, err)
+	assert.Equal(t, body, buf.Bytes())
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestWriteThrottled_StopsOnCancel(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := handler.WriteThrottled(ctx, &buf, []byte(strings.Repeat("a", 1000)), 100)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, buf.Len(), 1000)
+}
+
+func TestUniHandler_SectionThrottle(t *testing.T) {
+	uniHandler := newSectionHandler("files", config.Section{
+		PathPattern:         "/files/*",
+		BodyIDPaths:         []string{"/id"},
+		ThrottleBytesPerSec: 500,
+	})
+	payload := `{"id":"1","data":"` + strings.Repeat("z", 150) + `"}`
+	created := serveRequest(uniHandler, http.MethodPost, "/files", payload)
+	require.Equal(t, http.StatusCreated, created.Code)
+
+	start := time.Now()
+	w := serveRequest(uniHandler, http.MethodGet, "/files/1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, payload, w.Body.String())
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestWriteAtBandwidth_PreciseTotalDelay(t *testing.T) {
+	var buf bytes.Buffer
+	body := []byte(strings.Repeat("a", 250))
+
+	start := time.Now()
+	err := handler.WriteAtBandwidth(context.Background(), &buf, body, 1000)
+
+	require.NoError(t, err)
+	assert.Equal(t, body, buf.Bytes())
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 250*time.Millisecond)
+	assert.Less(t, elapsed, 400*time.Millisecond)
+}
+
+func TestUniHandler_SimulateBandwidth_ProportionalToBodySize(t *testing.T) {
+	uniHandler := newSectionHandler("files", config.Section{
+		PathPattern:       "/files/*",
+		BodyIDPaths:       []string{"/id"},
+		SimulateBandwidth: 2000,
+	})
+	small := `{"id":"small","data":"` + strings.Repeat("s", 60) + `"}`
+	large := `{"id":"large","data":"` + strings.Repeat("l", 760) + `"}`
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/files", small).Code)
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/files", large).Code)
+
+	timeGET := func(path string) time.Duration {
+		start := time.Now()
+		w := serveRequest(uniHandler, http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		return time.Since(start)
+	}
+	smallElapsed := timeGET("/files/small")
+	largeElapsed := timeGET("/files/large")
+
+	// 84 bytes take 42ms and 784 bytes take 392ms at 2000 bytes/sec
+	assert.GreaterOrEqual(t, smallElapsed, 42*time.Millisecond)
+	assert.GreaterOrEqual(t, largeElapsed, 392*time.Millisecond)
+	assert.Greater(t, largeElapsed, 5*smallElapsed)
+}
@@ -0,0 +1,12 @@
+package handler
+
+import "strings"
+
+// keepTrailingSlash ends the location of a resource created at a path ending in a slash with one
+// too when trailing slashes are preserved, so the location matches the section the path did
+func (h *UniHandler) keepTrailingSlash(reqPath, location string) string {
+	if !h.uniCfg.PreservesTrailingSlash() || !strings.HasSuffix(reqPath, "/") || strings.HasSuffix(location, "/") {
+		return location
+	}
+	return location + "/"
+}
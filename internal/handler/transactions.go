@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+const (
+	// transactionIDHeader carries the transaction ID of two-phase operations
+	transactionIDHeader = "X-Transaction-ID"
+
+	txPrepareSuffix  = "/tx/prepare"
+	txCommitSuffix   = "/tx/commit"
+	txRollbackSuffix = "/tx/rollback"
+)
+
+// transactionResult is the JSON body returned by transaction endpoints
+type transactionResult struct {
+	TransactionID string `json:"transaction_id"`
+	Committed     int    `json:"committed,omitempty"`
+}
+
+// tryHandleTransaction handles POST requests to the transaction endpoints of sections with transactions enabled.
+// It returns nil when the request is not a transaction request.
+func (h *UniHandler) tryHandleTransaction(ctx context.Context, req *http.Request) *http.Response {
+	for _, suffix := range []string{txPrepareSuffix, txCommitSuffix, txRollbackSuffix} {
+		if !strings.HasSuffix(req.URL.Path, suffix) {
+			continue
+		}
+		basePath := strings.TrimSuffix(req.URL.Path, suffix)
+		section, sectionName, err := h.findSection(basePath)
+		if err != nil || !section.Transactions {
+			return nil
+		}
+		req.URL.Path = basePath
+
+		switch suffix {
+		case txPrepareSuffix:
+			return h.handleTxPrepare(ctx, req, section, sectionName)
+		case txCommitSuffix:
+			return h.handleTxCommit(ctx, req)
+		default:
+			return h.handleTxRollback(ctx, req)
+		}
+	}
+	return nil
+}
+
+// handleTxPrepare stages the request resource under the request's transaction ID (generated if missing)
+func (h *UniHandler) handleTxPrepare(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+) *http.Response {
+	txID := req.Header.Get(transactionIDHeader)
+	if txID == "" {
+		txID = h.service.NewID()
+	}
+
+	ids, mockData, errResp := h.preparePostData(ctx, req, section, sectionName)
+	if errResp != nil {
+		return errResp
+	}
+	transformedData, err := h.applyRequestTransformations(mockData, section, sectionName)
+	if err != nil {
+		h.logger.Error("request transformation failed for transaction prepare", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "request transformation failed")
+	}
+
+	err = h.service.PrepareResource(ctx, txID, sectionName, section.StrictPath, ids, transformedData)
+	if err != nil {
+		h.logger.Error("failed to prepare resource", errorLogKey, err)
+		return h.errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	h.logger.Debug("staged resource in transaction", "transaction_id", txID, "section", sectionName)
+	resp := h.transactionResponse(http.StatusAccepted, transactionResult{TransactionID: txID})
+	resp.Header.Set("Location", transformedData.Location)
+	return resp
+}
+
+// handleTxCommit makes all resources staged under the transaction visible
+func (h *UniHandler) handleTxCommit(ctx context.Context, req *http.Request) *http.Response {
+	txID := req.Header.Get(transactionIDHeader)
+	if txID == "" {
+		return h.errorResponse(http.StatusBadRequest, "missing "+transactionIDHeader+" header")
+	}
+
+	committed, err := h.service.CommitTransaction(ctx, txID)
+	if err != nil {
+		h.logger.Warn("failed to commit transaction", "transaction_id", txID, errorLogKey, err)
+		return h.transactionErrorResponse(err)
+	}
+	return h.transactionResponse(http.StatusOK, transactionResult{TransactionID: txID, Committed: len(committed)})
+}
+
+// handleTxRollback discards all resources staged under the transaction
+func (h *UniHandler) handleTxRollback(ctx context.Context, req *http.Request) *http.Response {
+	txID := req.Header.Get(transactionIDHeader)
+	if txID == "" {
+		return h.errorResponse(http.StatusBadRequest, "missing "+transactionIDHeader+" header")
+	}
+
+	if err := h.service.RollbackTransaction(ctx, txID); err != nil {
+		h.logger.Warn("failed to roll back transaction", "transaction_id", txID, errorLogKey, err)
+		return h.transactionErrorResponse(err)
+	}
+	return &http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{transactionIDHeader: []string{txID}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+}
+
+// transactionErrorResponse maps transaction service errors to HTTP responses
+func (h *UniHandler) transactionErrorResponse(err error) *http.Response {
+	var notFound *unimockerrors.NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return h.errorResponse(http.StatusNotFound, "transaction not found")
+	case strings.Contains(err.Error(), "already exists"):
+		return h.errorResponse(http.StatusConflict, "resource already exists")
+	default:
+		return h.errorResponse(http.StatusInternalServerError, "failed to commit transaction")
+	}
+}
+
+// transactionResponse builds a JSON response for transaction endpoints
+func (h *UniHandler) transactionResponse(status int, result transactionResult) *http.Response {
+	body, err := json.Marshal(result)
+	if err != nil {
+		h.logger.Error("failed to encode transaction response", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to encode response")
+	}
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+	resp.Header.Set(contentTypeHeader, applicationJSON)
+	resp.Header.Set(transactionIDHeader, result.TransactionID)
+	return resp
+}
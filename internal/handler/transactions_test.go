@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransactionalHandler() http.Handler {
+	return newSectionHandler("orders", config.Section{
+		PathPattern:  "/orders/*",
+		BodyIDPaths:  []string{"/id"},
+		Transactions: true,
+	})
+}
+
+func postTransaction(uniHandler http.Handler, path, txID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if txID != "" {
+		req.Header.Set("X-Transaction-ID", txID)
+	}
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	return w
+}
+
+func TestUniHandler_Transaction_PrepareThenCommit(t *testing.T) {
+	uniHandler := newTransactionalHandler()
+
+	prepared := postTransaction(uniHandler, "/orders/tx/prepare", "", `{"id":"1","total":10}`)
+	require.Equal(t, http.StatusAccepted, prepared.Code)
+	txID := prepared.Header().Get("X-Transaction-ID")
+	require.NotEmpty(t, txID)
+	assert.JSONEq(t, `{"transaction_id":"`+txID+`"}`, prepared.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/orders/1", "").Code)
+
+	committed := postTransaction(uniHandler, "/orders/tx/commit", txID, "")
+	require.Equal(t, http.StatusOK, committed.Code)
+	assert.JSONEq(t, `{"transaction_id":"`+txID+`","committed":1}`, committed.Body.String())
+
+	w := serveRequest(uniHandler, http.MethodGet, "/orders/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","total":10}`, w.Body.String())
+}
+
+func TestUniHandler_Transaction_Rollback(t *testing.T) {
+	uniHandler := newTransactionalHandler()
+
+	prepared := postTransaction(uniHandler, "/orders/tx/prepare", "tx-1", `{"id":"2"}`)
+	require.Equal(t, http.StatusAccepted, prepared.Code)
+	assert.Equal(t, "tx-1", prepared.Header().Get("X-Transaction-ID"))
+
+	rolledBack := postTransaction(uniHandler, "/orders/tx/rollback", "tx-1", "")
+	assert.Equal(t, http.StatusNoContent, rolledBack.Code)
+
+	assert.Equal(t, http.StatusNotFound, postTransaction(uniHandler, "/orders/tx/commit", "tx-1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/orders/2", "").Code)
+}
+
+func TestUniHandler_Transaction_CommitRequiresTransactionID(t *testing.T) {
+	uniHandler := newTransactionalHandler()
+
+	w := postTransaction(uniHandler, "/orders/tx/commit", "", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
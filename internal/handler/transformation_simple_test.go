@@ -165,4 +165,37 @@ func TestTransformationSimple_ErrorHandling(t *testing.T) {
 	require.NoError(t, err) // Handler returns response, not error
 	defer resp.Body.Close()
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode) // Now returns 500 for all transformation errors
-}
\ No newline at end of file
+}
+
+func TestTransformationSimple_StoreJSONServeXML(t *testing.T) {
+	transformConfig := config.NewTransformationConfig()
+	transformConfig.AddResponseTransform(config.JSONToXML)
+	uniHandler := createHandlerWithTransforms(transformConfig)
+
+	w := serveRequest(uniHandler, http.MethodPost, "/users", `{"id":"1","name":"Ann"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveRequest(uniHandler, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<root><id>1</id><name>Ann</name></root>`, w.Body.String())
+}
+
+func TestTransformationSimple_StoreXMLAsJSON(t *testing.T) {
+	transformConfig := config.NewTransformationConfig()
+	transformConfig.AddRequestTransform(config.RequestTransformFunc(config.XMLToJSON))
+	uniHandler := createHandlerWithTransforms(transformConfig)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`<user><id>1</id><name>Ann</name></user>`))
+	req.Header.Set("Content-Type", "application/xml")
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveRequest(uniHandler, http.MethodGet, w.Header().Get("Location"), "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"user":{"id":"1","name":"Ann"}}`, w.Body.String())
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// writeTruncated declares the full body length in Content-Length but writes only the first half of
+// the body. The server closes connections whose response falls short of its Content-Length, so
+// clients see the transfer end prematurely, as when a backend dies mid-response.
+func (h *UniHandler) writeTruncated(w http.ResponseWriter, statusCode int, body []byte) {
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(body[:len(body)/2]); err != nil {
+		h.logger.Error("failed to write truncated response body", "error", err)
+	}
+}
@@ -0,0 +1,36 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_TruncateBeforeContentLength(t *testing.T) {
+	uniHandler := newSectionHandler("users", config.Section{
+		PathPattern:                 "/users/*",
+		BodyIDPaths:                 []string{"/id"},
+		TruncateBeforeContentLength: true,
+	})
+	body := `{"id":"1","name":"cut short"}`
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/users", body).Code)
+
+	server := httptest.NewServer(uniHandler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/users/1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(len(body)), resp.ContentLength)
+	received, err := io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "the client must detect the incomplete read")
+	assert.Less(t, len(received), len(body))
+	assert.Equal(t, body[:len(received)], string(received))
+}
@@ -0,0 +1,47 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniHandler_TTL(t *testing.T) {
+	uniHandler := newSectionHandler("sessions", config.Section{
+		PathPattern: "/sessions/*",
+		BodyIDPaths: []string{"/id"},
+		TTL:         50 * time.Millisecond,
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/sessions", `{"id":"s1"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/sessions/s1", "").Code)
+
+	time.Sleep(80 * time.Millisecond)
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/sessions/s1", "").Code)
+	assert.NotContains(t, serveRequest(uniHandler, http.MethodGet, "/sessions", "").Body.String(), `"s1"`)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodDelete, "/sessions/s1", "").Code)
+
+	// PUT upserts, so writing an expired resource starts a fresh lifetime
+	w = serveRequest(uniHandler, http.MethodPut, "/sessions/s1", `{"id":"s1"}`)
+	require.Less(t, w.Code, http.StatusMultipleChoices)
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/sessions/s1", "").Code)
+}
+
+func TestUniHandler_NoTTLNeverExpires(t *testing.T) {
+	uniHandler := newSectionHandler("sessions", config.Section{
+		PathPattern: "/sessions/*",
+		BodyIDPaths: []string{"/id"},
+	})
+
+	w := serveRequest(uniHandler, http.MethodPost, "/sessions", `{"id":"s1"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/sessions/s1", "").Code)
+}
@@ -9,13 +9,11 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
-	"github.com/antchfx/jsonquery"
-	"github.com/antchfx/xmlquery"
 	"github.com/bmcszk/unimock/internal/service"
 	"github.com/bmcszk/unimock/pkg/config"
 	"github.com/bmcszk/unimock/pkg/model"
-	"github.com/google/uuid"
 )
 
 const (
@@ -23,6 +21,9 @@ const (
 	errorLogKey       = "error"
 	pathLogKey        = "path"
 	contentTypeHeader = "Content-Type"
+
+	// AllowedMethods lists the methods every section supports, as reported in the Allow header
+	AllowedMethods = "GET, HEAD, POST, PUT, DELETE, OPTIONS"
 )
 
 // UniHandler provides clear, step-by-step HTTP method handlers
@@ -31,6 +32,17 @@ type UniHandler struct {
 	scenarioService *service.ScenarioService
 	logger          *slog.Logger
 	uniCfg          *config.UniConfig
+	coalescer       *requestCoalescer
+	sequences       *idSequences
+	stepProgress    *stepProgress
+	pacing          *clientPacing
+	variations      *variationSources
+	rateLimits      *rateBuckets
+	clock           func() time.Time
+	prettyJSON      bool
+
+	rejectUnsafeHeaders bool
+	sniffContentType    bool
 }
 
 // NewUniHandler creates a new handler
@@ -45,6 +57,13 @@ func NewUniHandler(
 		scenarioService: scenarioService,
 		logger:          logger,
 		uniCfg:          cfg,
+		coalescer:       newRequestCoalescer(),
+		sequences:       newIDSequences(),
+		stepProgress:    newStepProgress(),
+		pacing:          newClientPacing(),
+		variations:      newVariationSources(),
+		rateLimits:      newRateBuckets(),
+		clock:           time.Now,
 	}
 }
 
@@ -52,12 +71,19 @@ func NewUniHandler(
 func (h *UniHandler) HandlePOST(ctx context.Context, req *http.Request) (*http.Response, error) {
 	h.logger.Debug("starting POST request processing", "path", req.URL.Path)
 
+	if resp := h.tryHandleTransaction(ctx, req); resp != nil {
+		return resp, nil
+	}
+
 	// Step 1: Find matching configuration section
 	section, sectionName, err := h.findSection(req.URL.Path)
 	if err != nil {
 		h.logger.Warn("no matching section for POST", "path", req.URL.Path, "error", err)
 		return h.errorResponse(http.StatusNotFound, err.Error()), nil
 	}
+	if resp := h.tryHandleArrayPOST(ctx, req, section, sectionName); resp != nil {
+		return resp, nil
+	}
 
 	// Step 2: Prepare POST data with ID extraction
 	_, mockData, errResp := h.preparePostData(ctx, req, section, sectionName)
@@ -91,19 +117,43 @@ func (h *UniHandler) preparePostData(
 		return nil, model.UniData{}, h.errorResponse(http.StatusBadRequest, "failed to extract IDs")
 	}
 
-	// Generate UUID if no IDs found
+	// Generate an ID if none found: the section's next sequence number or a UUID
+	if len(ids) == 0 && section.SequentialIDs {
+		generatedID := h.sequences.next(sectionName)
+		ids = []string{generatedID}
+		h.logger.Debug("generated sequential ID for POST", "id", generatedID)
+	}
 	if len(ids) == 0 {
-		generatedID := uuid.New().String()
+		generatedID := h.service.NewID()
 		ids = []string{generatedID}
 		h.logger.Debug("generated UUID for POST", "uuid", generatedID)
 	}
+	if hasCompositeIDs(section) {
+		ids = compositeBodyIDs(section, req.URL.Path, ids)
+		if len(ids) == 0 {
+			return nil, model.UniData{}, h.errorResponse(http.StatusBadRequest, "failed to build composite ID")
+		}
+	}
 
 	// Build UniData from request
-	mockData, err := h.buildUniDataFromRequest(req, ids)
+	mockData, err := h.buildUniDataFromRequest(req, ids, section)
 	if err != nil {
 		h.logger.Error("failed to build UniData for POST", "error", err)
 		return nil, model.UniData{}, h.errorResponse(http.StatusBadRequest, "failed to process request data")
 	}
+	if hasCompositeIDs(section) {
+		mockData.Location = mockData.Path + "/" + leafID(ids[0])
+	}
+	mockData.Location = h.keepTrailingSlash(req.URL.Path, mockData.Location)
+	if section.LocationTemplate != "" {
+		captures, _ := config.MatchCaptures(section.PathPattern, req.URL.Path, section.CaseSensitive)
+		location, err := renderLocation(section.LocationTemplate, mockData.Body, captures)
+		if err != nil {
+			h.logger.Warn("failed to render location for POST", "path", req.URL.Path, "error", err)
+			return nil, model.UniData{}, h.errorResponse(http.StatusBadRequest, err.Error())
+		}
+		mockData.Location = location
+	}
 
 	return ids, mockData, nil
 }
@@ -130,6 +180,9 @@ func (h *UniHandler) processPostRequest(
 		if strings.Contains(err.Error(), "already exists") {
 			return model.UniData{}, h.errorResponse(http.StatusConflict, "resource already exists")
 		}
+		if resp := h.storageFullResponse(err); resp != nil {
+			return model.UniData{}, resp
+		}
 		return model.UniData{}, h.errorResponse(http.StatusInternalServerError, "failed to create resource")
 	}
 
@@ -138,6 +191,11 @@ func (h *UniHandler) processPostRequest(
 
 // HandleGET processes GET requests step by step
 func (h *UniHandler) HandleGET(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if section, _, err := h.findSection(req.URL.Path); err == nil && section.CoalesceRequests {
+		return h.coalescer.do(coalesceKey(req), func() (*http.Response, error) {
+			return h.handleGetRequest(ctx, req)
+		})
+	}
 	return h.handleGetRequest(ctx, req)
 }
 
@@ -157,14 +215,30 @@ func (h *UniHandler) handleGetRequest(ctx context.Context, req *http.Request) (*
 		return h.errorResponse(http.StatusNotFound, err.Error()), nil
 	}
 
-	// Step 2: Try to get individual resource first
+	// Step 2: Serve the previous versions of a versioned resource
+	if historyResp := h.tryGetHistory(ctx, req, section, sectionName); historyResp != nil {
+		return historyResp, nil
+	}
+
+	// Step 3: Try to get individual resource first
 	individualResp := h.tryGetIndividualResource(ctx, req, section, sectionName)
 	if individualResp != nil {
-		return individualResp, nil
+		individualResp = h.embedSubResources(ctx, individualResp, req, section)
+		return h.shapeGetResponse(individualResp, req, section), nil
 	}
 
-	// Step 3: Get collection of resources
-	return h.getResourceCollection(ctx, req, section, sectionName), nil
+	// Step 4: Get collection of resources
+	return h.shapeGetResponse(h.getResourceCollection(ctx, req, section, sectionName), req, section), nil
+}
+
+// shapeGetResponse applies section-level response shaping (field scopes, locale formatting,
+// echoed query parameters, GraphQL envelope, poll interval hint) to GET responses
+func (h *UniHandler) shapeGetResponse(resp *http.Response, req *http.Request, section *config.Section) *http.Response {
+	setPollInterval(resp, section)
+	resp = h.projectFieldScopes(resp, req, section)
+	resp = h.formatLocaleFields(resp, req, section)
+	resp = h.echoQueryParams(resp, req, section)
+	return h.wrapGraphQLResponse(resp, section)
 }
 
 // handleHEADRequest processes HEAD requests without body
@@ -178,15 +252,21 @@ func (h *UniHandler) handleHEADRequest(ctx context.Context, req *http.Request) (
 		return h.errorResponse(http.StatusNotFound, err.Error()), nil
 	}
 
-	// Step 2: Try to get individual resource first
+	// Step 2: Answer for the previous versions of a versioned resource
+	if historyResp := h.tryGetHistory(ctx, req, section, sectionName); historyResp != nil {
+		return applyHeadHeaders(h.suppressResponseBody(historyResp), section), nil
+	}
+
+	// Step 3: Try to get individual resource first
 	individualResp := h.tryGetIndividualResource(ctx, req, section, sectionName)
 	if individualResp != nil {
-		return h.suppressResponseBody(individualResp), nil
+		resp := h.suppressResponseBody(h.shapeGetResponse(individualResp, req, section))
+		return applyHeadHeaders(resp, section), nil
 	}
 
-	// Step 3: Get collection of resources
-	resp := h.getResourceCollection(ctx, req, section, sectionName)
-	return h.suppressResponseBody(resp), nil
+	// Step 4: Get collection of resources
+	resp := h.shapeGetResponse(h.getResourceCollection(ctx, req, section, sectionName), req, section)
+	return applyHeadHeaders(h.suppressResponseBody(resp), section), nil
 }
 
 // tryGetIndividualResource attempts to get an individual resource
@@ -197,17 +277,31 @@ func (h *UniHandler) tryGetIndividualResource(
 	sectionName string,
 ) *http.Response {
 	lastSegment := h.extractLastPathSegment(req.URL.Path)
+	if hasCompositeIDs(section) {
+		ids := extractCompositePathIDs(section, req.URL.Path)
+		if len(ids) == 0 {
+			return nil
+		}
+		lastSegment = ids[0]
+	}
+	if pathIDs := h.extractPathIDs(req, section, sectionName); len(pathIDs) == 0 {
+		// A collection path addresses the resource named by the ID header, if the section allows it
+		if headerIDs := headerReadIDs(req, section); len(headerIDs) > 0 {
+			lastSegment = headerIDs[0]
+		}
+	}
 	if lastSegment == "" || lastSegment == sectionName {
 		return nil
 	}
 
-	resource, err := h.service.GetResource(ctx, sectionName, section.StrictPath, lastSegment)
-	if err != nil {
+	sourceSection, sourceName := h.readSource(section, sectionName)
+	resource, err := h.service.GetResource(ctx, sourceName, sourceSection.StrictPath, lastSegment)
+	if err != nil || !readVisible(resource, section.ReadDelay) {
 		return h.errorResponse(http.StatusNotFound, "resource not found")
 	}
 
-	// Apply strict path validation if enabled
-	if section.StrictPath {
+	// Apply strict path validation if enabled; resources read from a command section live under its path
+	if section.StrictPath && section.ReadFrom == "" {
 		if err := h.validateStrictPathAccess(req.URL.Path, resource.Path, section.PathPattern); err != nil {
 			h.logger.Debug("strict path validation failed for GET",
 				"requestPath", req.URL.Path, "resourcePath", resource.Path, "error", err)
@@ -215,7 +309,26 @@ func (h *UniHandler) tryGetIndividualResource(
 		}
 	}
 
-	return h.buildTransformedResponse(resource, section, sectionName)
+	resource, versionResp := h.selectVersion(ctx, req, sourceSection, sourceName, lastSegment, resource)
+	if versionResp != nil {
+		return versionResp
+	}
+
+	resp := h.buildTransformedResponse(resource, section, sectionName)
+	if sourceSection.SoftDelete && isTombstone(resource) && resp.StatusCode < http.StatusBadRequest {
+		// Soft-deleted resources stay readable as tombstones but are reported as gone
+		resp.StatusCode = http.StatusGone
+	}
+	if section.ContentDisposition != "" && resp.StatusCode < http.StatusBadRequest {
+		captures, _ := config.MatchCaptures(section.PathPattern, req.URL.Path, section.CaseSensitive)
+		disposition, err := renderContentDisposition(section.ContentDisposition, lastSegment, captures)
+		if err != nil {
+			h.logger.Error("failed to render content disposition", errorLogKey, err)
+			return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+		}
+		resp.Header.Set("Content-Disposition", disposition)
+	}
+	return resp
 }
 
 // getResourceCollection gets a collection of resources
@@ -228,18 +341,43 @@ func (h *UniHandler) getResourceCollection(
 	// For collection requests, use the base path from the pattern
 	// e.g., for pattern "/users/*" and request "/users/nonexistent", look for resources at "/users"
 	basePath := h.getCollectionBasePath(section.PathPattern, req.URL.Path)
+	if hasCompositeIDs(section) {
+		// Composite sections list only the resources under the requested parent path,
+		// e.g. /tenants/t1/users lists only tenant t1's users
+		basePath = req.URL.Path
+	}
+	if section.ReadFrom != "" {
+		sourceSection, _ := h.readSource(section, sectionName)
+		basePath = h.getCollectionBasePath(sourceSection.PathPattern, sourceSection.PathPattern)
+	}
 
 	resources, err := h.service.GetResourcesByPath(ctx, basePath)
 	if err != nil || len(resources) == 0 {
 		return h.errorResponse(http.StatusNotFound, "resource not found")
 	}
+	resources = filterReadVisible(resources, section.ReadDelay)
+	if sourceSection, _ := h.readSource(section, sectionName); sourceSection.SoftDelete {
+		resources = filterTombstones(resources)
+	}
+	if section.FullTextSearch {
+		resources = filterBySearchTerm(resources, req.URL.Query().Get(searchQueryParam))
+	}
+	if groupBy := req.URL.Query().Get(groupByQueryParam); section.AllowGroupBy && groupBy != "" {
+		return h.getGroupedCollection(resources, groupBy, section, sectionName)
+	}
+	if section.CursorPagination {
+		return h.getCursorPage(req, resources, section, sectionName)
+	}
+	if section.PartialCollectionSize > 0 {
+		return h.getPartialCollection(resources, section, sectionName)
+	}
 
 	transformedResources, err := h.transformResourceCollection(resources, section, sectionName)
 	if err != nil {
 		return h.errorResponse(http.StatusInternalServerError, "response transformation failed")
 	}
 
-	return h.buildCollectionResponse(transformedResources)
+	return h.buildCollectionResponse(transformedResources, section)
 }
 
 // getCollectionBasePath determines the base path for collection queries
@@ -316,7 +454,7 @@ func (h *UniHandler) processPUTRequest(
 	}
 
 	// Build and transform data
-	mockData, err := h.buildUniDataFromRequest(req, ids)
+	mockData, err := h.buildUniDataFromRequest(req, ids, section)
 	if err != nil {
 		h.logger.Error("failed to build UniData for PUT", "error", err)
 		return h.errorResponse(http.StatusBadRequest, "failed to process request data"), nil
@@ -336,6 +474,19 @@ func (h *UniHandler) processPUTRequest(
 		}
 	}
 
+	// Refuse to create missing resources where PUT only updates
+	if section.PutRequiresExisting {
+		if err := h.validateResourceExists(ctx, sectionName, section.StrictPath, ids[0], "PUT"); err != nil {
+			return h.errorResponse(http.StatusNotFound, "resource not found"), nil
+		}
+	}
+
+	if createOnly(req) {
+		return h.executeResourceCreate(ctx, ids[0], transformedData, section, sectionName)
+	}
+	if version, ok := ifMatchVersion(req); ok {
+		return h.executeVersionedUpdate(ctx, ids[0], version, transformedData, section, sectionName)
+	}
 	return h.executeResourceUpdate(ctx, ids[0], transformedData, section, sectionName)
 }
 
@@ -359,6 +510,9 @@ func (h *UniHandler) executeResourceUpdate(
 	err := h.service.UpdateResource(ctx, sectionName, section.StrictPath, id, data)
 	if err != nil {
 		h.logger.Error("failed to update resource", "error", err)
+		if resp := h.storageFullResponse(err); resp != nil {
+			return resp, nil
+		}
 		return h.errorResponse(http.StatusInternalServerError, "failed to update resource"), nil
 	}
 
@@ -411,6 +565,10 @@ func (h *UniHandler) processDELETERequest(
 func (h *UniHandler) executeResourceDeletion(
 	ctx context.Context, id string, section *config.Section, sectionName string,
 ) (*http.Response, error) {
+	if resp := h.trySoftDelete(ctx, id, section, sectionName); resp != nil {
+		return resp, nil
+	}
+
 	err := h.service.DeleteResource(ctx, sectionName, section.StrictPath, id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -443,8 +601,10 @@ func (h *UniHandler) findSection(reqPath string) (*config.Section, string, error
 	return section, sectionName, nil
 }
 
-// buildUniDataFromRequest creates UniData from HTTP request
-func (*UniHandler) buildUniDataFromRequest(req *http.Request, ids []string) (model.UniData, error) {
+// buildUniDataFromRequest creates UniData from HTTP request, expiring after the section's TTL if set
+func (*UniHandler) buildUniDataFromRequest(
+	req *http.Request, ids []string, section *config.Section,
+) (model.UniData, error) {
 	// Read request body
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
@@ -460,6 +620,10 @@ func (*UniHandler) buildUniDataFromRequest(req *http.Request, ids []string) (mod
 		IDs:         ids,
 		ContentType: req.Header.Get("Content-Type"),
 		Body:        body,
+		WrittenAt:   time.Now(),
+	}
+	if section.TTL > 0 {
+		mockData.ExpiresAt = mockData.WrittenAt.Add(section.TTL)
 	}
 
 	// Set location for the resource
@@ -495,12 +659,13 @@ func (h *UniHandler) applyRequestTransformations(
 	return currentData, nil
 }
 
-// applyResponseTransformations applies response transformations if configured
+// applyResponseTransformations adds the next state actions and applies response transformations if configured
 func (h *UniHandler) applyResponseTransformations(
 	data model.UniData,
 	section *config.Section,
 	sectionName string,
 ) (model.UniData, error) {
+	data = addNextActions(data, section)
 	if section.Transformations == nil || !section.Transformations.HasResponseTransforms() {
 		return data, nil
 	}
@@ -520,287 +685,114 @@ func (h *UniHandler) applyResponseTransformations(
 	return currentData, nil
 }
 
-// extractIDs extracts IDs from the request using configured paths
-func (h *UniHandler) extractIDs(
-	ctx context.Context,
-	req *http.Request,
-	section *config.Section,
-	sectionName string,
-) ([]string, error) {
-	if h.isPathBasedMethod(req.Method) {
-		return h.extractPathBasedIDs(req, section, sectionName)
-	}
-	return h.extractBodyBasedIDs(ctx, req, section, sectionName)
-}
-
-// isPathBasedMethod checks if method uses path-based ID extraction
-func (*UniHandler) isPathBasedMethod(method string) bool {
-	return method == http.MethodGet || method == http.MethodHead ||
-		method == http.MethodPut || method == http.MethodDelete
-}
-
-// extractPathBasedIDs extracts IDs from path for GET/HEAD/PUT/DELETE methods
-func (h *UniHandler) extractPathBasedIDs(
-	req *http.Request,
-	section *config.Section,
-	sectionName string,
-) ([]string, error) {
-	pathSegments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
-	patternSegments := strings.Split(strings.Trim(section.PathPattern, "/"), "/")
-
-	if h.shouldExtractFromPath(pathSegments, patternSegments) {
-		lastSegment := pathSegments[len(pathSegments)-1]
-		if lastSegment != "" && lastSegment != sectionName {
-			return []string{lastSegment}, nil
-		}
-	}
-	return nil, nil
-}
-
-// shouldExtractFromPath determines if ID should be extracted from path
-func (*UniHandler) shouldExtractFromPath(pathSegments, patternSegments []string) bool {
-	if len(patternSegments) == 0 || len(pathSegments) == 0 {
-		return false
-	}
-
-	lastPattern := patternSegments[len(patternSegments)-1]
-
-	// Handle recursive wildcard **
-	if lastPattern == "**" {
-		// For pattern /users/**, any path like /users/123 should extract 123
-		return len(pathSegments) > len(patternSegments)-1
-	}
-
-	// Handle single wildcard *
-	if lastPattern == "*" {
-		return len(pathSegments) == len(patternSegments)
-	}
-
-	// For exact patterns, only extract if path is longer
-	return len(pathSegments) > len(patternSegments)
-}
-
-// extractBodyBasedIDs extracts IDs from headers and body for POST requests
-func (h *UniHandler) extractBodyBasedIDs(
-	ctx context.Context,
-	req *http.Request,
-	section *config.Section,
-	sectionName string,
-) ([]string, error) {
-	var collectedIDs []string
-	seenIDs := make(map[string]bool)
-
-	addID := func(id string) {
-		if id != "" && id != sectionName && !seenIDs[id] {
-			collectedIDs = append(collectedIDs, id)
-			seenIDs[id] = true
-		}
-	}
-
-	return h.extractPostIDs(ctx, req, section, addID)
-}
-
-// extractPostIDs extracts IDs from headers and body for POST requests
-func (h *UniHandler) extractPostIDs(
-	ctx context.Context,
-	req *http.Request,
-	section *config.Section,
-	addID func(string),
-) ([]string, error) {
-	var collectedIDs []string
-
-	// Try header ID extraction
-	collectedIDs = h.tryExtractHeaderID(section, req, addID, collectedIDs)
-
-	// Try body ID extraction
-	bodyIDs, err := h.extractBodyIDs(ctx, req, section)
-	if err != nil {
-		return nil, err
-	}
-	for _, id := range bodyIDs {
-		addID(id)
-	}
-	collectedIDs = append(collectedIDs, bodyIDs...)
-
-	// Try path ID extraction as fallback
-	if len(collectedIDs) == 0 {
-		collectedIDs = h.tryExtractPathIDFallback(req, section, addID, collectedIDs)
-	}
-
-	return collectedIDs, nil
-}
-
-// tryExtractHeaderID attempts to extract ID from request headers
-func (*UniHandler) tryExtractHeaderID(
-	section *config.Section,
-	req *http.Request,
-	addID func(string),
-	collectedIDs []string,
-) []string {
-	for _, headerName := range section.HeaderIDNames {
-		if headerName != "" {
-			headerID := req.Header.Get(headerName)
-			if headerID != "" {
-				addID(headerID)
-				collectedIDs = append(collectedIDs, headerID)
-			}
-		}
+// HandleOPTIONS responds with the methods supported by the matched section
+func (h *UniHandler) HandleOPTIONS(_ context.Context, req *http.Request) (*http.Response, error) {
+	if _, _, err := h.findSection(req.URL.Path); err != nil {
+		h.logger.Warn("no matching section for OPTIONS", "path", req.URL.Path, "error", err)
+		return h.errorResponse(http.StatusNotFound, err.Error()), nil
 	}
-	return collectedIDs
-}
-
-// tryExtractPathIDFallback attempts to extract ID from path as fallback
-func (*UniHandler) tryExtractPathIDFallback(
-	req *http.Request,
-	section *config.Section,
-	addID func(string),
-	collectedIDs []string,
-) []string {
-	pathSegments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
-	patternSegments := strings.Split(strings.Trim(section.PathPattern, "/"), "/")
 
-	if len(pathSegments) > len(patternSegments) ||
-		(len(pathSegments) == len(patternSegments) && strings.HasSuffix(section.PathPattern, "*")) {
-		lastSegment := pathSegments[len(pathSegments)-1]
-		addID(lastSegment)
-		collectedIDs = append(collectedIDs, lastSegment)
+	resp := &http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	return collectedIDs
+	resp.Header.Set("Allow", AllowedMethods)
+	return resp, nil
 }
 
-// extractBodyIDs extracts IDs from request body
-func (h *UniHandler) extractBodyIDs(
-	ctx context.Context,
-	req *http.Request,
-	section *config.Section,
-) ([]string, error) {
-	contentType := strings.ToLower(req.Header.Get("Content-Type"))
-
-	if !h.isSupportedContentType(contentType) {
-		return nil, nil
+// HandleRequest processes the HTTP request and returns appropriate response
+func (h *UniHandler) HandleRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if !h.uniCfg.PreservesTrailingSlash() {
+		req.URL.Path = strings.TrimSuffix(req.URL.Path, "/")
 	}
+	h.sniffRequestContentType(req)
+	h.delayBySchedule(req)
 
-	body, err := h.readAndRestoreRequestBody(req)
+	resp, err := h.routeRequest(ctx, req)
 	if err != nil {
-		return nil, err
-	}
-
-	if len(body) == 0 {
-		return nil, nil
+		return resp, err
 	}
 
-	return h.parseIDsFromBody(ctx, body, contentType, section)
-}
-
-// isSupportedContentType checks if content type supports ID extraction
-func (*UniHandler) isSupportedContentType(contentType string) bool {
-	return strings.Contains(contentType, "json") || strings.Contains(contentType, "xml")
+	resp = h.addRateLimitHeaders(req, resp)
+	resp = h.applyErrorTemplate(req, resp)
+	resp = h.contentTypeFromExtension(req, resp)
+	resp = h.offerMultipleChoices(req, resp)
+	resp = h.roundNumbers(req, resp)
+	resp = h.prettyPrint(resp)
+	resp = h.applyVariations(req, resp)
+	resp = h.addDigest(req, resp)
+	resp = h.closeConnection(req, resp)
+	resp = h.reportProcessingTime(req, resp, start)
+	return h.guardHeaders(h.signResponse(req, resp)), nil
 }
 
-// readAndRestoreRequestBody reads and restores request body
-func (*UniHandler) readAndRestoreRequestBody(req *http.Request) ([]byte, error) {
-	body, err := io.ReadAll(req.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read request body: %w", err)
+// routeRequest runs the canonical redirect, auth, pacing, rate limit and dependency checks, static file
+// and gRPC-Web serving, request order and conditional request checks, then the method handler for the request
+func (h *UniHandler) routeRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	// Redirect non-canonical spellings of the path (case, duplicate slashes) where configured
+	if resp := h.tryCanonicalRedirect(req); resp != nil {
+		return resp, nil
 	}
-	req.Body = io.NopCloser(bytes.NewBuffer(body))
-	return body, nil
-}
-
-// parseIDsFromBody parses IDs from body content based on content type
-func (h *UniHandler) parseIDsFromBody(
-	ctx context.Context,
-	body []byte,
-	contentType string,
-	section *config.Section,
-) ([]string, error) {
-	seenIDs := make(map[string]bool)
-	var extractedIDs []string
-	var err error
 
-	if strings.Contains(contentType, "json") {
-		extractedIDs, err = h.extractJSONIDs(body, section.BodyIDPaths, seenIDs)
-	} else {
-		extractedIDs, err = h.extractXMLIDs(body, section.BodyIDPaths, seenIDs)
+	// Reject requests lacking the credentials the matched section requires
+	if resp := h.checkAuth(req); resp != nil {
+		return resp, nil
 	}
 
-	if err != nil {
-		h.logger.WarnContext(ctx, "error extracting IDs from body", "error", err)
-		return nil, err
+	// Turn away clients requesting sooner than the section's minimum interval allows
+	if resp := h.checkMinInterval(req); resp != nil {
+		return resp, nil
 	}
 
-	return extractedIDs, nil
-}
-
-// extractJSONIDs extracts IDs from JSON body
-func (h *UniHandler) extractJSONIDs(body []byte, idPaths []string, seenIDs map[string]bool) ([]string, error) {
-	doc, err := jsonquery.Parse(bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse JSON body: %w", err)
+	// Turn away requests beyond the section's rate limit
+	if resp := h.checkRateLimit(req); resp != nil {
+		return resp, nil
 	}
 
-	var ids []string
-	for _, path := range idPaths {
-		pathIDs := h.extractJSONIDsFromPath(doc, path, seenIDs)
-		ids = append(ids, pathIDs...)
+	// Stay unavailable until the resource the section depends on exists
+	if resp := h.checkDependency(ctx, req); resp != nil {
+		return resp, nil
 	}
-	return ids, nil
-}
 
-// extractJSONIDsFromPath extracts IDs from a specific JSON path
-func (*UniHandler) extractJSONIDsFromPath(doc *jsonquery.Node, path string, seenIDs map[string]bool) []string {
-	nodes, err := jsonquery.QueryAll(doc, path)
-	if err != nil {
-		return nil
+	// Serve sections backed by a directory of static files
+	if resp := h.tryServeStatic(req); resp != nil {
+		return resp, nil
 	}
 
-	var ids []string
-	for _, node := range nodes {
-		if idStr := fmt.Sprintf("%v", node.Value()); idStr != "" && !seenIDs[idStr] {
-			ids = append(ids, idStr)
-			seenIDs[idStr] = true
-		}
+	// Answer unary calls to gRPC-Web sections with their configured message and trailers
+	if resp := h.tryServeGRPCWeb(req); resp != nil {
+		return resp, nil
 	}
-	return ids
-}
 
-// extractXMLIDs extracts IDs from XML body
-func (h *UniHandler) extractXMLIDs(body []byte, idPaths []string, seenIDs map[string]bool) ([]string, error) {
-	doc, err := xmlquery.Parse(bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse XML body: %w", err)
+	// Reject workflow steps requested before their predecessors completed
+	step, resp := h.checkRequestOrder(req)
+	if resp != nil {
+		return resp, nil
 	}
 
-	var ids []string
-	for _, path := range idPaths {
-		pathIDs := h.extractXMLIDsFromPath(doc, path, seenIDs)
-		ids = append(ids, pathIDs...)
+	// Keep the history of versioned resources read-only
+	if resp := h.rejectHistoryWrite(req); resp != nil {
+		return resp, nil
 	}
-	return ids, nil
-}
 
-// extractXMLIDsFromPath extracts IDs from a specific XML path
-func (*UniHandler) extractXMLIDsFromPath(doc *xmlquery.Node, path string, seenIDs map[string]bool) []string {
-	nodes, err := xmlquery.QueryAll(doc, path)
-	if err != nil {
-		return nil
+	// Answer conditional requests from the addressed resource's current ETag
+	etag, version := h.currentState(ctx, req)
+	if resp := h.checkPreconditions(req, etag); resp != nil {
+		return resp, nil
 	}
 
-	var ids []string
-	for _, node := range nodes {
-		if idStr := node.InnerText(); idStr != "" && !seenIDs[idStr] {
-			ids = append(ids, idStr)
-			seenIDs[idStr] = true
-		}
+	resp, err := h.dispatchMethod(ctx, req)
+	if err == nil {
+		h.setResourceState(ctx, req, resp, etag, version)
+		h.completeStep(step, resp)
 	}
-	return ids
+	return resp, err
 }
 
-// HandleRequest processes the HTTP request and returns appropriate response
-func (h *UniHandler) HandleRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
-	req.URL.Path = strings.TrimSuffix(req.URL.Path, "/")
-
-	// Process the request using the appropriate handler
+// dispatchMethod processes the request using the handler for its method
+func (h *UniHandler) dispatchMethod(ctx context.Context, req *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var err error
 
@@ -815,13 +807,12 @@ func (h *UniHandler) HandleRequest(ctx context.Context, req *http.Request) (*htt
 		resp, err = h.HandlePUT(ctx, req)
 	case http.MethodDelete:
 		resp, err = h.HandleDELETE(ctx, req)
+	case http.MethodOptions:
+		resp, err = h.HandleOPTIONS(ctx, req)
 	default:
-		resp = &http.Response{
-			StatusCode: http.StatusMethodNotAllowed,
-			Header:     make(http.Header),
-			Body:       io.NopCloser(strings.NewReader("method not allowed")),
-		}
-		err = nil
+		// Built by errorResponse so the section's error template formats it like any other error
+		resp = h.errorResponse(http.StatusMethodNotAllowed, "method not allowed")
+		resp.Header.Set("Allow", AllowedMethods)
 	}
 
 	return resp, err
@@ -843,8 +834,36 @@ func (h *UniHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}()
 	}
 
+	delivery := h.deliveryFor(r.URL.Path)
 	h.copyHeaders(w, resp)
-	h.writeResponse(w, resp)
+	if len(delivery.chunkBoundaries) > 0 {
+		// A fixed length would stop the server from using chunked transfer encoding
+		w.Header().Del("Content-Length")
+	}
+	h.writeResponse(r.Context(), w, resp, delivery)
+}
+
+// bodyDelivery controls how a response body is written to the client
+type bodyDelivery struct {
+	bytesPerSec     int
+	bandwidth       int
+	chunkBoundaries []int
+	truncate        bool
+}
+
+// deliveryFor returns the configured response throttle, simulated bandwidth, chunk boundaries and
+// truncation for the request path
+func (h *UniHandler) deliveryFor(reqPath string) bodyDelivery {
+	section, _, err := h.findSection(reqPath)
+	if err != nil {
+		return bodyDelivery{}
+	}
+	return bodyDelivery{
+		bytesPerSec:     section.ThrottleBytesPerSec,
+		bandwidth:       section.SimulateBandwidth,
+		chunkBoundaries: section.ChunkBoundaries,
+		truncate:        section.TruncateBeforeContentLength,
+	}
 }
 
 // copyHeaders copies response headers to the writer
@@ -855,31 +874,54 @@ func (*UniHandler) copyHeaders(w http.ResponseWriter, resp *http.Response) {
 }
 
 // writeResponse writes the response body and status code
-func (h *UniHandler) writeResponse(w http.ResponseWriter, resp *http.Response) {
+func (h *UniHandler) writeResponse(
+	ctx context.Context,
+	w http.ResponseWriter,
+	resp *http.Response,
+	delivery bodyDelivery,
+) {
 	if resp.Body == nil {
 		w.WriteHeader(resp.StatusCode)
 		return
 	}
-	h.writeResponseBody(w, resp)
+	h.writeResponseBody(ctx, w, resp, delivery)
 }
 
 // writeResponseBody handles writing response with body
-func (h *UniHandler) writeResponseBody(w http.ResponseWriter, resp *http.Response) {
+func (h *UniHandler) writeResponseBody(
+	ctx context.Context,
+	w http.ResponseWriter,
+	resp *http.Response,
+	delivery bodyDelivery,
+) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		h.logger.Error("failed to read response body", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	if delivery.truncate && len(body) > 0 {
+		h.writeTruncated(w, resp.StatusCode, body)
+		return
+	}
 	w.WriteHeader(resp.StatusCode)
 	if len(body) > 0 {
-		h.writeBodyContent(w, body)
+		h.writeBodyContent(ctx, w, body, delivery)
 	}
 }
 
-// writeBodyContent writes the actual body content
-func (h *UniHandler) writeBodyContent(w http.ResponseWriter, body []byte) {
-	_, err := w.Write(body)
+// writeBodyContent writes the actual body content, split at the configured chunk boundaries,
+// paced by the simulated bandwidth or throttled when bytesPerSec is positive
+func (h *UniHandler) writeBodyContent(ctx context.Context, w http.ResponseWriter, body []byte, delivery bodyDelivery) {
+	var err error
+	switch {
+	case len(delivery.chunkBoundaries) > 0:
+		err = WriteChunked(w, body, delivery.chunkBoundaries)
+	case delivery.bandwidth > 0:
+		err = WriteAtBandwidth(ctx, w, body, delivery.bandwidth)
+	default:
+		err = WriteThrottled(ctx, w, body, delivery.bytesPerSec)
+	}
 	if err != nil {
 		h.logger.Error("failed to write response body", "error", err)
 	}
@@ -702,3 +702,25 @@ func verifyBodyConsistency(t *testing.T, getW, headW *httptest.ResponseRecorder)
 		t.Errorf("HEAD request should have empty body, got: %s", string(headBody))
 	}
 }
+
+// newSectionHandler creates a handler serving a single configured section
+func newSectionHandler(sectionName string, section config.Section) *handler.UniHandler {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{sectionName: section},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	return handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+}
+
+// serveRequest sends a request with an optional JSON body to the handler and records the response
+func serveRequest(uniHandler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	uniHandler.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// variationSources holds one random source per section with variations, seeded from the section's
+// seed so that a fixed seed reproduces the same sequence of variations
+type variationSources struct {
+	mu      sync.Mutex
+	sources map[string]*rand.Rand
+}
+
+// newVariationSources creates an empty variationSources
+func newVariationSources() *variationSources {
+	return &variationSources{sources: make(map[string]*rand.Rand)}
+}
+
+// draw runs fn with the section's random source while holding the lock, as rand.Rand is not safe
+// for concurrent use
+func (s *variationSources) draw(sectionName string, variations *config.Variations, fn func(*rand.Rand)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	source, ok := s.sources[sectionName]
+	if !ok {
+		seed := variations.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		source = rand.New(rand.NewSource(seed))
+		s.sources[sectionName] = source
+	}
+	fn(source)
+}
+
+// applyVariations varies the status code and body size of successful responses in sections with
+// variations (see config.Variations)
+func (h *UniHandler) applyVariations(req *http.Request, resp *http.Response) *http.Response {
+	if resp == nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp
+	}
+	section, sectionName, err := h.findSection(req.URL.Path)
+	if err != nil || section.Variations == nil {
+		return resp
+	}
+	variations := section.Variations
+
+	statusCode, bodySize := resp.StatusCode, 0
+	h.variations.draw(sectionName, variations, func(source *rand.Rand) {
+		if source.Float64() >= variations.Chance() {
+			return
+		}
+		if code, ok := drawStatusCode(source, variations.StatusCodes); ok {
+			statusCode = code
+		}
+		if size := variations.BodySize; size != nil {
+			bodySize = size.Min + source.Intn(size.Max-size.Min+1)
+		}
+	})
+	resp.StatusCode = statusCode
+	if bodySize == 0 || resp.Body == nil || statusCode == http.StatusNoContent {
+		return resp
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		h.logger.Error("failed to read response body for variations", errorLogKey, err)
+		return h.errorResponse(http.StatusInternalServerError, "failed to build response")
+	}
+	if len(body) > 0 {
+		body = PadBody(body, resp.Header.Get(contentTypeHeader), bodySize)
+		resp.Header.Del("Content-Length")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp
+}
+
+// drawStatusCode picks a status code with probability proportional to its weight
+func drawStatusCode(source *rand.Rand, statuses []config.WeightedStatus) (int, bool) {
+	total := 0
+	for _, status := range statuses {
+		total += statusWeight(status)
+	}
+	if total == 0 {
+		return 0, false
+	}
+	pick := source.Intn(total)
+	for _, status := range statuses {
+		if pick < statusWeight(status) {
+			return status.Code, true
+		}
+		pick -= statusWeight(status)
+	}
+	return 0, false
+}
+
+// statusWeight returns the weight of a status code, 1 when unset
+func statusWeight(status config.WeightedStatus) int {
+	if status.Weight == 0 {
+		return 1
+	}
+	return status.Weight
+}
@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVariationsHandler(t *testing.T, variations *config.Variations) *handler.UniHandler {
+	t.Helper()
+	uniHandler := newSectionHandler("items", config.Section{
+		PathPattern: "/items/*",
+		BodyIDPaths: []string{"/id"},
+		ReturnBody:  true,
+		Variations:  variations,
+	})
+	w := serveRequest(uniHandler, http.MethodPut, "/items/1", `{"id":"1","name":"widget"}`)
+	require.Less(t, w.Code, 300, "the write may be varied as well")
+	return uniHandler
+}
+
+func TestUniHandler_Variations_StatusCodes(t *testing.T) {
+	variations := &config.Variations{
+		StatusCodes: []config.WeightedStatus{{Code: 200, Weight: 3}, {Code: 202, Weight: 1}},
+		Seed:        42,
+	}
+	sequence := func() []int {
+		uniHandler := newVariationsHandler(t, variations)
+		codes := make([]int, 0, 100)
+		for i := 0; i < 100; i++ {
+			codes = append(codes, serveRequest(uniHandler, http.MethodGet, "/items/1", "").Code)
+		}
+		return codes
+	}
+
+	codes := sequence()
+	counts := map[int]int{}
+	for _, code := range codes {
+		counts[code]++
+	}
+	assert.Len(t, counts, 2, "only the configured status codes are returned")
+	assert.Greater(t, counts[200], counts[202], "status codes are drawn by weight")
+	assert.Equal(t, codes, sequence(), "a fixed seed reproduces the same variations")
+}
+
+func TestUniHandler_Variations_BodySize(t *testing.T) {
+	uniHandler := newVariationsHandler(t, &config.Variations{BodySize: &config.SizeRange{Min: 500, Max: 600}})
+
+	for i := 0; i < 20; i++ {
+		w := serveRequest(uniHandler, http.MethodGet, "/items/1", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, w.Body.Len(), 500)
+		assert.LessOrEqual(t, w.Body.Len(), 600)
+		var item map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &item), "padded JSON stays valid")
+		assert.Equal(t, "widget", item["name"])
+	}
+}
+
+func TestUniHandler_Variations_OnlySuccessfulResponses(t *testing.T) {
+	never := 0.0
+	uniHandler := newVariationsHandler(t, &config.Variations{
+		Probability: &never,
+		StatusCodes: []config.WeightedStatus{{Code: 202}},
+	})
+	assert.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodGet, "/items/1", "").Code,
+		"responses are not varied with probability 0")
+
+	uniHandler = newVariationsHandler(t, &config.Variations{StatusCodes: []config.WeightedStatus{{Code: 202}}})
+	assert.Equal(t, http.StatusAccepted, serveRequest(uniHandler, http.MethodGet, "/items/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/items/2", "").Code,
+		"error responses are not varied")
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// versionQueryParam selects a single version of a resource in a versioned section
+const versionQueryParam = "version"
+
+// rejectHistoryWrite answers anything but GET and HEAD on a versioned resource's history path
+// with 405 Method Not Allowed, as the history is read-only
+func (h *UniHandler) rejectHistoryWrite(req *http.Request) *http.Response {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return nil
+	}
+	section, _, err := h.findSection(req.URL.Path)
+	if err != nil {
+		return nil
+	}
+	if _, ok := section.HistoryResourcePath(req.URL.Path); !ok {
+		return nil
+	}
+	resp := h.errorResponse(http.StatusMethodNotAllowed, "resource history is read-only")
+	resp.Header.Set("Allow", "GET, HEAD")
+	return resp
+}
+
+// tryGetHistory returns the previous versions of the resource whose history path is requested,
+// oldest first, or nil if the request does not address a history path
+func (h *UniHandler) tryGetHistory(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+) *http.Response {
+	resourcePath, ok := section.HistoryResourcePath(req.URL.Path)
+	if !ok {
+		return nil
+	}
+	id := h.extractLastPathSegment(resourcePath)
+	sourceSection, sourceName := h.readSource(section, sectionName)
+	if _, err := h.service.GetResource(ctx, sourceName, sourceSection.StrictPath, id); err != nil {
+		return h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+	versions, err := h.service.GetResourceHistory(ctx, sourceName, id)
+	if err != nil {
+		return h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+	return h.buildCollectionResponse(versions, section)
+}
+
+// selectVersion picks the version requested by the version query parameter: 1 is the original
+// resource and the number following the last previous version is the current one. Without the
+// parameter, or outside versioned sections, the current resource is returned unchanged.
+func (h *UniHandler) selectVersion(
+	ctx context.Context,
+	req *http.Request,
+	section *config.Section,
+	sectionName string,
+	id string,
+	current model.UniData,
+) (model.UniData, *http.Response) {
+	raw := req.URL.Query().Get(versionQueryParam)
+	if !section.Versioned || raw == "" {
+		return current, nil
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		return model.UniData{}, h.errorResponse(http.StatusBadRequest, "version must be a positive integer")
+	}
+
+	versions, err := h.service.GetResourceHistory(ctx, sectionName, id)
+	if err != nil {
+		return model.UniData{}, h.errorResponse(http.StatusNotFound, "resource not found")
+	}
+	switch {
+	case version <= len(versions):
+		return versions[version-1], nil
+	case version == len(versions)+1:
+		return current, nil
+	default:
+		return model.UniData{}, h.errorResponse(http.StatusNotFound, "version not found")
+	}
+}
@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedHandler(versioned bool) http.Handler {
+	return newSectionHandler("docs", config.Section{
+		PathPattern: "/docs/*",
+		BodyIDPaths: []string{"/id"},
+		Versioned:   versioned,
+	})
+}
+
+func createDocVersions(t *testing.T, uniHandler http.Handler) {
+	t.Helper()
+	require.Equal(t, http.StatusCreated, serveRequest(uniHandler, http.MethodPost, "/docs", `{"id":"1","v":1}`).Code)
+	require.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodPut, "/docs/1", `{"id":"1","v":2}`).Code)
+	require.Equal(t, http.StatusOK, serveRequest(uniHandler, http.MethodPut, "/docs/1", `{"id":"1","v":3}`).Code)
+}
+
+func TestUniHandler_Versioned_History(t *testing.T) {
+	uniHandler := newVersionedHandler(true)
+	createDocVersions(t, uniHandler)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/docs/1/history", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"1","v":1},{"id":"1","v":2}]`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodGet, "/docs/1", "")
+	assert.JSONEq(t, `{"id":"1","v":3}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/docs/2/history", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed,
+		serveRequest(uniHandler, http.MethodPut, "/docs/1/history", `{"id":"1"}`).Code)
+}
+
+func TestUniHandler_Versioned_VersionQuery(t *testing.T) {
+	uniHandler := newVersionedHandler(true)
+	createDocVersions(t, uniHandler)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/docs/1?version=1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","v":1}`, w.Body.String())
+
+	w = serveRequest(uniHandler, http.MethodGet, "/docs/1?version=3", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","v":3}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/docs/1?version=4", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serveRequest(uniHandler, http.MethodGet, "/docs/1?version=x", "").Code)
+}
+
+func TestUniHandler_Versioned_DisabledOverwrites(t *testing.T) {
+	uniHandler := newVersionedHandler(false)
+	createDocVersions(t, uniHandler)
+
+	w := serveRequest(uniHandler, http.MethodGet, "/docs/1?version=1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"1","v":3}`, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, serveRequest(uniHandler, http.MethodGet, "/docs/1/history", "").Code)
+}
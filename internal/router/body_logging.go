@@ -0,0 +1,96 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// unparsableBodyPlaceholder replaces JSON bodies that cannot be parsed for redaction,
+// so fields that should be masked never reach the log
+const unparsableBodyPlaceholder = "[unparsable JSON body omitted]"
+
+// bodyLogger renders request and response bodies for the debug log
+type bodyLogger struct {
+	redactions []config.ResponseTransformFunc
+	maxBytes   int
+}
+
+// EnableBodyLogging makes the logging middleware record request and response bodies.
+// Fields of JSON bodies selected by redactPaths are masked and bodies longer than
+// maxBytes are truncated; maxBytes <= 0 uses config.DefaultLogBodyMaxBytes.
+func (r *Router) EnableBodyLogging(redactPaths []string, maxBytes int) error {
+	redactions := make([]config.ResponseTransformFunc, 0, len(redactPaths))
+	for _, path := range redactPaths {
+		redact, err := config.NewResponseFieldTransform(config.FieldTransform{
+			Path:   path,
+			Action: config.TransformActionRedact,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid log redact path %q: %w", path, err)
+		}
+		redactions = append(redactions, redact)
+	}
+	if maxBytes <= 0 {
+		maxBytes = config.DefaultLogBodyMaxBytes
+	}
+	r.bodyLogger = &bodyLogger{redactions: redactions, maxBytes: maxBytes}
+	return nil
+}
+
+// render returns the loggable form of a body: redacted first, then truncated
+func (b *bodyLogger) render(contentType string, body []byte) string {
+	data := model.UniData{ContentType: contentType, Body: body}
+	for _, redact := range b.redactions {
+		redacted, err := redact(data)
+		if err != nil {
+			return unparsableBodyPlaceholder
+		}
+		data = redacted
+	}
+	if len(data.Body) > b.maxBytes {
+		return fmt.Sprintf("%s...[truncated %d bytes]", data.Body[:b.maxBytes], len(data.Body)-b.maxBytes)
+	}
+	return string(data.Body)
+}
+
+// readRequestBody reads the request body and restores it for the next handler
+func readRequestBody(req *http.Request) []byte {
+	if req.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		body = nil
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
+
+// bodyCaptureWriter wraps http.ResponseWriter to keep a copy of the response
+type bodyCaptureWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (bw *bodyCaptureWriter) WriteHeader(code int) {
+	bw.statusCode = code
+	bw.ResponseWriter.WriteHeader(code)
+}
+
+func (bw *bodyCaptureWriter) Write(p []byte) (int, error) {
+	bw.body.Write(p)
+	return bw.ResponseWriter.Write(p)
+}
+
+// Flush keeps chunked and throttled delivery working through the wrapper
+func (bw *bodyCaptureWriter) Flush() {
+	if flusher, ok := bw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
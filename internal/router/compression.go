@@ -0,0 +1,56 @@
+package router
+
+import (
+	"compress/gzip"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// plainTextContentType is what clients would sniff from the untyped bodies of error responses
+const plainTextContentType = "text/plain; charset=utf-8"
+
+// compressibleContentTypes are the response types compressed when gzip is enabled
+var compressibleContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/problem+json",
+	"application/xml",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+// EnableGzip compresses responses of textual content types for clients sending
+// Accept-Encoding: gzip. Error responses are compressed too, including the plain messages built
+// without a Content-Type, simulating a backend whose proxy compresses everything it sends.
+func (r *Router) EnableGzip() {
+	compress := middleware.Compress(gzip.DefaultCompression, compressibleContentTypes...)
+	r.handler = compress(typeErrorResponses(r.router))
+}
+
+// typeErrorResponses labels error responses without a Content-Type as plain text before the
+// compression middleware decides whether to compress them
+func typeErrorResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(&errorTypingWriter{ResponseWriter: w}, req)
+	})
+}
+
+// errorTypingWriter sets the plain text Content-Type on untyped error responses
+type errorTypingWriter struct {
+	http.ResponseWriter
+}
+
+func (ew *errorTypingWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest && ew.Header().Get("Content-Type") == "" {
+		ew.Header().Set("Content-Type", plainTextContentType)
+	}
+	ew.ResponseWriter.WriteHeader(code)
+}
+
+// Flush keeps chunked and throttled delivery working through the wrapper
+func (ew *errorTypingWriter) Flush() {
+	if flusher, ok := ew.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
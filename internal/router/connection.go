@@ -0,0 +1,37 @@
+package router
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// connRequestsKey keys the per-connection request counter in request contexts
+type connRequestsKey struct{}
+
+// CountConnectionRequests is an http.Server ConnContext hook giving each connection a request
+// counter, which the router needs to close connections after EnableMaxRequestsPerConnection's limit
+func CountConnectionRequests(ctx context.Context, _ net.Conn) context.Context {
+	return context.WithValue(ctx, connRequestsKey{}, &atomic.Int64{})
+}
+
+// EnableMaxRequestsPerConnection answers the n-th request on a connection with Connection: close,
+// so the server closes it and clients have to open a new one. The server must count requests with
+// CountConnectionRequests.
+func (r *Router) EnableMaxRequestsPerConnection(n int) {
+	r.maxRequestsPerConn = int64(n)
+}
+
+// connectionLimitMiddleware closes connections that reached the maximum number of requests
+func (r *Router) connectionLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.maxRequestsPerConn > 0 {
+			counter, ok := req.Context().Value(connRequestsKey{}).(*atomic.Int64)
+			if ok && counter.Add(1) >= r.maxRequestsPerConn {
+				w.Header().Set("Connection", "close")
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// drainingRetryAfter is the Retry-After, in seconds, sent to requests turned away while draining:
+// about the time a rolling restart takes to bring up the replacement
+const drainingRetryAfter = 1
+
+// SetDrainPeriod sets how long Drain keeps turning requests away before it returns
+func (r *Router) SetDrainPeriod(period time.Duration) {
+	r.drainPeriod = period
+}
+
+// Drain flips the router into the draining state of a shutting down server: every request but the
+// liveness probe, scenarios and forced failures included, is answered with 503 Service Unavailable
+// and Retry-After while the server still accepts connections. It returns once the drain period has
+// elapsed or ctx is done, after which the caller shuts the http.Server down (see pkg.Shutdown).
+func (r *Router) Drain(ctx context.Context) {
+	r.draining.Store(true)
+	timer := time.NewTimer(r.drainPeriod)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// drainingMiddleware rejects requests arriving after Drain, closing their connections
+func (r *Router) drainingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.draining.Load() || req.URL.Path == livenessPath {
+			next.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(drainingRetryAfter))
+		w.Header().Set("Connection", "close")
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+}
@@ -1,12 +1,17 @@
 package router
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/bmcszk/unimock/internal/handler"
 	"github.com/bmcszk/unimock/internal/service"
 	"github.com/bmcszk/unimock/pkg/config"
 	"github.com/bmcszk/unimock/pkg/model"
@@ -14,24 +19,41 @@ import (
 
 const (
 	pathLogKey = "path"
+
+	// adminKeyHeader carries the admin API key required by /_uni endpoints when one is configured
+	adminKeyHeader = "X-Unimock-Key"
 )
 
 // Router wraps a Chi router with scenario handling capabilities
 type Router struct {
 	router          chi.Router
+	handler         http.Handler // router, wrapped in response compression when enabled
 	uniHandler      http.Handler
 	techHandler     http.Handler
 	scenarioHandler http.Handler
+	failureHandler  http.Handler
 	scenarioService *service.ScenarioService
+	failureService  *service.FailureService
 	techService     *service.TechService
 	logger          *slog.Logger
 	uniConfig      *config.UniConfig
+	adminAPIKey     string
+	bodyLogger      *bodyLogger // nil unless body logging is enabled
+	prettyJSON      bool
+
+	rejectUnsafeHeaders   bool
+	maxRequestsPerConn    int64 // 0 keeps connections open for any number of requests
+	redirectTrailingSlash bool
+	readyAt               time.Time // requests other than the liveness probe get 503 until then
+	draining              atomic.Bool   // set by Drain: requests other than the liveness probe get 503
+	drainPeriod           time.Duration // how long Drain waits before the server shuts down
 }
 
 // NewRouter creates a new Router instance with Chi
 func NewRouter(
-	uniHandler, techHandler, scenarioHandler http.Handler, 
+	uniHandler, techHandler, scenarioHandler, failureHandler http.Handler,
 	scenarioService *service.ScenarioService, 
+	failureService *service.FailureService,
 	techService *service.TechService,
 	logger *slog.Logger, 
 	uniConfig *config.UniConfig,
@@ -40,13 +62,16 @@ func NewRouter(
 		uniHandler:      uniHandler,
 		techHandler:     techHandler,
 		scenarioHandler: scenarioHandler,
+		failureHandler:  failureHandler,
 		scenarioService: scenarioService,
+		failureService:  failureService,
 		techService:     techService,
 		logger:          logger,
 		uniConfig:      uniConfig,
 	}
 	
 	r.setupRoutes()
+	r.handler = r.router
 	return r
 }
 
@@ -60,13 +85,28 @@ func (r *Router) setupRoutes() {
 	r.router.Use(r.loggingMiddleware)
 	r.router.Use(r.metricsMiddleware)
 	r.router.Use(middleware.Recoverer)
+	r.router.Use(r.startupDelayMiddleware)
+	r.router.Use(r.drainingMiddleware)
+	r.router.Use(r.connectionLimitMiddleware)
+	r.router.Use(r.trailingSlashMiddleware)
 	
-	// Add scenario handling middleware (runs before route matching)
+	// Add forced failure and scenario handling middleware (runs before route matching);
+	// failures take precedence over scenarios
+	r.router.Use(r.failureMiddleware)
 	r.router.Use(r.scenarioMiddleware)
 	
 	// Technical endpoints (/_uni/*)
-	r.router.Mount("/_uni/scenarios", r.scenarioHandler)
-	r.router.Mount("/_uni", r.techHandler)
+	r.router.Group(func(admin chi.Router) {
+		admin.Use(r.adminKeyMiddleware)
+		admin.Mount("/_uni/scenarios", r.scenarioHandler)
+		admin.Mount("/_uni/failures", r.failureHandler)
+		if seeder, ok := r.uniHandler.(interface {
+			HandleSeed(http.ResponseWriter, *http.Request)
+		}); ok {
+			admin.Post("/_uni/seed", seeder.HandleSeed)
+		}
+		admin.Mount("/_uni", r.techHandler)
+	})
 	
 	// Catch-all route for uni handler (must be last)
 	r.router.HandleFunc("/*", r.uniHandlerFunc)
@@ -74,7 +114,7 @@ func (r *Router) setupRoutes() {
 
 // ServeHTTP implements the http.Handler interface
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
 
 // loggingMiddleware adds request logging
@@ -84,6 +124,42 @@ func (r *Router) loggingMiddleware(next http.Handler) http.Handler {
 			"method", req.Method,
 			"path", req.URL.Path,
 			"remote_addr", req.RemoteAddr)
+		if r.bodyLogger == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		r.logger.Debug("request body",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"body", r.bodyLogger.render(req.Header.Get("Content-Type"), readRequestBody(req)))
+
+		bw := &bodyCaptureWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(bw, req)
+
+		r.logger.Debug("response body",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"status", bw.statusCode,
+			"body", r.bodyLogger.render(bw.Header().Get("Content-Type"), bw.body.Bytes()))
+	})
+}
+
+// RequireAdminKey makes /_uni endpoints require key in the X-Unimock-Key header; an empty key
+// leaves them open
+func (r *Router) RequireAdminKey(key string) {
+	r.adminAPIKey = key
+}
+
+// adminKeyMiddleware rejects management requests without the configured admin API key
+func (r *Router) adminKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.adminAPIKey != "" &&
+			subtle.ConstantTimeCompare([]byte(req.Header.Get(adminKeyHeader)), []byte(r.adminAPIKey)) != 1 {
+			r.logger.Warn("rejected management request without valid admin key", pathLogKey, req.URL.Path)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
 		next.ServeHTTP(w, req)
 	})
 }
@@ -121,8 +197,23 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// normalizePath normalizes the request path
-func (*Router) normalizePath(path string) string {
+// Flush keeps chunked and throttled delivery working through the wrapper
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// normalizePath normalizes the request path, trimming the trailing slash unless it is preserved
+func (r *Router) normalizePath(path string) string {
+	if r.uniConfig.PreservesTrailingSlash() {
+		return path
+	}
 	requestPath := strings.TrimSuffix(path, "/")
 	if requestPath == "" {
 		requestPath = "/"
@@ -130,6 +221,34 @@ func (*Router) normalizePath(path string) string {
 	return requestPath
 }
 
+// failureMiddleware answers requests matching a registered failure with its error response
+func (r *Router) failureMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestPath := r.normalizePath(req.URL.Path)
+		if strings.HasPrefix(requestPath, "/_uni/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		failure, found := r.failureService.GetFailureForRequest(req.Method, requestPath)
+		if !found {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		r.logger.Info("forcing failure",
+			"method", req.Method,
+			pathLogKey, requestPath,
+			"id", failure.ID,
+			"status", failure.StatusCode)
+		message := failure.Message
+		if message == "" {
+			message = http.StatusText(failure.StatusCode)
+		}
+		http.Error(w, message, failure.StatusCode)
+	})
+}
+
 // scenarioMiddleware checks for scenario matches before route handling
 func (r *Router) scenarioMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -141,14 +260,21 @@ func (r *Router) scenarioMiddleware(next http.Handler) http.Handler {
 			return
 		}
 		
-		scenario, found := r.scenarioService.GetScenarioByPath(req.Context(), requestPath, req.Method)
+		scenario, found := r.scenarioService.GetScenarioForRequest(req.Context(), requestPath, req)
 		if found {
 			r.logger.Info("found matching scenario",
 				"method", req.Method,
 				pathLogKey, requestPath,
 				"uuid", scenario.UUID)
 			
-			r.writeScenarioResponse(w, req, scenario)
+			hit := r.scenarioService.RecordHit(scenario.UUID)
+			if scenario.ExpireAfterHits <= 0 || hit <= int64(scenario.ExpireAfterHits) {
+				r.writeScenarioResponse(w, req, scenario.ResponseForHit(hit))
+				return
+			}
+			// A concurrent request took the last hit the scenario allowed
+		}
+		if req.Method == http.MethodOptions && r.writeScenarioOptions(w, req, requestPath) {
 			return
 		}
 		
@@ -156,29 +282,87 @@ func (r *Router) scenarioMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// EnablePrettyJSON indents the JSON bodies of scenario and mock responses (see handler.IndentJSON)
+func (r *Router) EnablePrettyJSON() {
+	r.prettyJSON = true
+	if uniHandler, ok := r.uniHandler.(interface{ EnablePrettyJSON() }); ok {
+		uniHandler.EnablePrettyJSON()
+	}
+}
+
+// EnableContentTypeSniffing makes mock requests sent without a Content-Type header be treated as
+// JSON or XML when their body looks like it (see handler.UniHandler.EnableContentTypeSniffing)
+func (r *Router) EnableContentTypeSniffing() {
+	if uniHandler, ok := r.uniHandler.(interface{ EnableContentTypeSniffing() }); ok {
+		uniHandler.EnableContentTypeSniffing()
+	}
+}
+
+// EnableHeaderRejection answers 500 instead of stripping line breaks from unsafe scenario and mock
+// response headers (see handler.UnsafeHeader)
+func (r *Router) EnableHeaderRejection() {
+	r.rejectUnsafeHeaders = true
+	if uniHandler, ok := r.uniHandler.(interface{ EnableHeaderRejection() }); ok {
+		uniHandler.EnableHeaderRejection()
+	}
+}
+
 // writeScenarioResponse writes the scenario response
 func (r *Router) writeScenarioResponse(w http.ResponseWriter, req *http.Request, scenario model.Scenario) {
-	w.Header().Set("Content-Type", scenario.ContentType)
+	header := make(http.Header)
+	header.Set("Content-Type", scenario.ContentType)
 	if scenario.Location != "" {
-		w.Header().Set("Location", scenario.Location)
+		header.Set("Location", scenario.Location)
 	}
-	
-	if scenario.Headers != nil {
-		for k, v := range scenario.Headers {
-			w.Header().Set(k, v)
+	for k, v := range scenario.Headers {
+		header.Set(k, v)
+	}
+	if scenario.CloseConnection {
+		header.Set("Connection", "close")
+	}
+	if name, unsafe := handler.UnsafeHeader(header); unsafe {
+		r.logger.Warn("scenario header contains a line break", "uuid", scenario.UUID, "header", name)
+		if r.rejectUnsafeHeaders {
+			http.Error(w, "unsafe response header value", http.StatusInternalServerError)
+			return
 		}
+		handler.SanitizeHeader(header)
 	}
-	
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+
 	w.WriteHeader(scenario.StatusCode)
 	
 	// For HEAD requests, don't write response body
 	if req.Method != http.MethodHead {
-		if _, err := w.Write([]byte(scenario.Data)); err != nil {
+		if scenario.Data == "" && len(scenario.ResponseSchema) > 0 {
+			example, _ := config.SchemaExample(scenario.ResponseSchema)
+			scenario.Data = string(example)
+		}
+		scenario.Data = renderScenarioCaptures(scenario, r.normalizePath(req.URL.Path), config.RequestLine(req))
+		if r.prettyJSON {
+			scenario.Data = string(handler.IndentJSON(scenario.ContentType, []byte(scenario.Data)))
+		}
+		body := r.buildScenarioBody(scenario)
+		if err := handler.WriteThrottled(req.Context(), w, body, scenario.ThrottleBytesPerSec); err != nil {
 			r.logger.Error("failed to write scenario response in router", "error", err)
 		}
 	}
 }
 
+// buildScenarioBody returns the scenario body with random data or padding applied
+func (r *Router) buildScenarioBody(scenario model.Scenario) []byte {
+	if scenario.RandomBytes > 0 {
+		body := make([]byte, scenario.RandomBytes)
+		if _, err := rand.Read(body); err != nil {
+			r.logger.Error("failed to generate random scenario body", "error", err)
+		}
+		return body
+	}
+	return handler.PadBody([]byte(scenario.Data), scenario.ContentType, scenario.PadToBytes)
+}
+
 // uniHandlerFunc wraps the uni handler with path validation
 func (r *Router) uniHandlerFunc(w http.ResponseWriter, req *http.Request) {
 	requestPath := r.normalizePath(req.URL.Path)
@@ -0,0 +1,159 @@
+package router_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/router"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestRouterWithAdminKey(adminAPIKey string) *router.Router {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	techService := service.NewTechService(time.Now())
+
+	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+	techHandler := handler.NewTechHandler(techService, uniService, scenarioService, logger, cfg)
+	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+	failureService := service.NewFailureService()
+	failureHandler := handler.NewFailureHandler(failureService, logger)
+
+	appRouter := router.NewRouter(
+		uniHandler, techHandler, scenarioHandler, failureHandler,
+		scenarioService, failureService, techService, logger, cfg,
+	)
+	appRouter.RequireAdminKey(adminAPIKey)
+	return appRouter
+}
+
+func TestRouter_AdminAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminKey   string
+		path       string
+		headerKey  string
+		wantStatus int
+	}{
+		{name: "no key configured", path: "/_uni/scenarios", wantStatus: http.StatusOK},
+		{name: "missing key", adminKey: "k1", path: "/_uni/scenarios", wantStatus: http.StatusUnauthorized},
+		{name: "wrong key", adminKey: "k1", path: "/_uni/health", headerKey: "nope", wantStatus: http.StatusUnauthorized},
+		{name: "valid key scenarios", adminKey: "k1", path: "/_uni/scenarios", headerKey: "k1", wantStatus: http.StatusOK},
+		{name: "valid key health", adminKey: "k1", path: "/_uni/health", headerKey: "k1", wantStatus: http.StatusOK},
+		{name: "mock endpoints stay open", adminKey: "k1", path: "/users/1", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appRouter := setupTestRouterWithAdminKey(tt.adminKey)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.headerKey != "" {
+				req.Header.Set("X-Unimock-Key", tt.headerKey)
+			}
+			w := httptest.NewRecorder()
+
+			appRouter.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRouter_SeedEndpoint(t *testing.T) {
+	appRouter := setupTestRouterWithAdminKey("k1")
+	seed := func(key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/_uni/seed",
+			strings.NewReader(`[{"path": "/users/7", "body": {"name": "Eve"}}]`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Unimock-Key", key)
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, seed("nope"))
+	assert.Equal(t, http.StatusCreated, seed("k1"))
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name": "Eve"}`, w.Body.String())
+}
+
+func TestRouter_StatsEndpoint(t *testing.T) {
+	appRouter := setupTestRouterWithAdminKey("")
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, req)
+		return w
+	}
+	stats := func() map[string]any {
+		w := serve(http.MethodGet, "/_uni/stats", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		return got
+	}
+
+	empty := stats()
+	assert.EqualValues(t, 0, empty["resource_count"])
+	assert.Empty(t, empty["resources_by_section"])
+	assert.EqualValues(t, 0, empty["scenario_count"])
+	assert.EqualValues(t, 0, empty["memory_bytes"])
+
+	require.Equal(t, http.StatusCreated, serve(http.MethodPost, "/users", `{"id": "1", "name": "Ann"}`).Code)
+	require.Equal(t, http.StatusCreated, serve(http.MethodPost, "/users", `{"id": "2", "name": "Bob"}`).Code)
+	require.Equal(t, http.StatusCreated,
+		serve(http.MethodPost, "/_uni/scenarios", `{"requestPath": "GET /health", "statusCode": 200}`).Code)
+
+	filled := stats()
+	assert.EqualValues(t, 2, filled["resource_count"])
+	assert.Equal(t, map[string]any{"users": float64(2)}, filled["resources_by_section"])
+	assert.EqualValues(t, 1, filled["scenario_count"])
+	assert.Greater(t, filled["memory_bytes"], float64(0))
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/users/1", "").Code)
+	assert.EqualValues(t, 1, stats()["resource_count"])
+}
+
+func TestRouter_StartupDelay(t *testing.T) {
+	appRouter := setupTestRouterWithAdminKey("")
+	appRouter.EnableStartupDelay(300 * time.Millisecond)
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/_uni/health").Code, "liveness succeeds during the warm-up")
+	for _, path := range []string{"/_uni/ready", "/users", "/_uni/metrics"} {
+		w := serve(path)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, path)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"), path)
+	}
+
+	require.Eventually(t, func() bool {
+		return serve("/_uni/ready").Code == http.StatusOK
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.Equal(t, http.StatusNotFound, serve("/users/1").Code, "mock requests are handled after the delay")
+}
@@ -0,0 +1,104 @@
+package router_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/handler"
+	"github.com/bmcszk/unimock/internal/router"
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestRouterWithLogOutput(logOutput io.Writer) *router.Router {
+	logger := slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+
+	uniService := service.NewUniService(storage.NewUniStorage(), cfg)
+	scenarioService := service.NewScenarioService(storage.NewScenarioStorage())
+	techService := service.NewTechService(time.Now())
+
+	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, cfg)
+	techHandler := handler.NewTechHandler(techService, uniService, scenarioService, logger, cfg)
+	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+	failureService := service.NewFailureService()
+	failureHandler := handler.NewFailureHandler(failureService, logger)
+
+	return router.NewRouter(
+		uniHandler, techHandler, scenarioHandler, failureHandler,
+		scenarioService, failureService, techService, logger, cfg,
+	)
+}
+
+func serveJSON(appRouter *router.Router, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	appRouter.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRouter_BodyLoggingRedactsFields(t *testing.T) {
+	var logs bytes.Buffer
+	appRouter := setupTestRouterWithLogOutput(&logs)
+	require.NoError(t, appRouter.EnableBodyLogging([]string{"$.password", "/card/number"}, 0))
+
+	body := `{"id":"1","name":"alice","password":"hunter2","card":{"number":"4111"}}`
+	rec := serveJSON(appRouter, http.MethodPost, "/users", body)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = serveJSON(appRouter, http.MethodGet, "/users/1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "hunter2", "redaction must only affect the log")
+
+	output := logs.String()
+	assert.Contains(t, output, "msg=\"request body\"")
+	assert.Contains(t, output, "msg=\"response body\"")
+	assert.Contains(t, output, "alice")
+	assert.Contains(t, output, config.RedactedValue)
+	assert.NotContains(t, output, "hunter2")
+	assert.NotContains(t, output, "4111")
+}
+
+func TestRouter_BodyLoggingTruncatesLargeBodies(t *testing.T) {
+	var logs bytes.Buffer
+	appRouter := setupTestRouterWithLogOutput(&logs)
+	require.NoError(t, appRouter.EnableBodyLogging(nil, 16))
+
+	body := `{"id":"1","name":"` + strings.Repeat("x", 100) + `"}`
+	rec := serveJSON(appRouter, http.MethodPost, "/users", body)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	output := logs.String()
+	assert.Contains(t, output, "...[truncated 104 bytes]")
+	assert.NotContains(t, output, strings.Repeat("x", 17))
+}
+
+func TestRouter_BodyLoggingDisabledByDefault(t *testing.T) {
+	var logs bytes.Buffer
+	appRouter := setupTestRouterWithLogOutput(&logs)
+
+	rec := serveJSON(appRouter, http.MethodPost, "/users", `{"id":"1","password":"hunter2"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	assert.NotContains(t, logs.String(), "request body")
+	assert.NotContains(t, logs.String(), "hunter2")
+}
+
+func TestRouter_EnableBodyLoggingRejectsInvalidPath(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+	assert.Error(t, appRouter.EnableBodyLogging([]string{"$"}, 0))
+}
@@ -0,0 +1,65 @@
+package router_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveGzip(t *testing.T, handler http.Handler, method, path, body string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		return rec, rec.Body.String()
+	}
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return rec, string(decompressed)
+}
+
+func TestRouter_GzipCompressesErrorResponses(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+	appRouter.EnableGzip()
+
+	rec, body := serveGzip(t, appRouter, http.MethodGet, "/users/missing", "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "resource not found", body)
+}
+
+func TestRouter_GzipCompressesSuccessResponses(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+	appRouter.EnableGzip()
+	serveJSON(appRouter, http.MethodPost, "/users", `{"id":"1","name":"alice"}`)
+
+	rec, body := serveGzip(t, appRouter, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.JSONEq(t, `{"id":"1","name":"alice"}`, body)
+}
+
+func TestRouter_GzipDisabledByDefault(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+
+	rec, body := serveGzip(t, appRouter, http.MethodGet, "/users/missing", "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "resource not found", body)
+}
@@ -0,0 +1,60 @@
+package router_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_FailureOverridesResourcesAndScenarios(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+	require.Equal(t, http.StatusCreated, serveJSON(appRouter, http.MethodPost, "/users", `{"id":"1"}`).Code)
+	scenario := `{"requestPath":"GET /users/1","statusCode":200,"contentType":"application/json","data":"{}"}`
+	require.Equal(t, http.StatusCreated, serveJSON(appRouter, http.MethodPost, "/_uni/scenarios", scenario).Code)
+
+	rec := serveJSON(appRouter, http.MethodPost, "/_uni/failures",
+		`{"method":"GET","path":"/users/*","statusCode":503,"message":"backend down"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var failure model.Failure
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &failure))
+	require.NotEmpty(t, failure.ID)
+	assert.Equal(t, "/_uni/failures/"+failure.ID, rec.Header().Get("Location"))
+
+	rec = serveJSON(appRouter, http.MethodGet, "/users/1", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "backend down")
+	assert.Equal(t, http.StatusNoContent, serveJSON(appRouter, http.MethodDelete, "/users/1", "").Code,
+		"other methods are unaffected")
+
+	require.Equal(t, http.StatusNoContent, serveJSON(appRouter, http.MethodDelete, "/_uni/failures/"+failure.ID, "").Code)
+	assert.Equal(t, http.StatusOK, serveJSON(appRouter, http.MethodGet, "/users/1", "").Code)
+}
+
+func TestRouter_FailureListAndValidation(t *testing.T) {
+	appRouter := setupTestRouterWithLogOutput(io.Discard)
+
+	rec := serveJSON(appRouter, http.MethodPost, "/_uni/failures", `{"method":"GET","path":"/users/1","statusCode":200}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "only error status codes are accepted")
+
+	failure := `{"id":"f1","method":"post","path":"/users","statusCode":500}`
+	require.Equal(t, http.StatusCreated, serveJSON(appRouter, http.MethodPost, "/_uni/failures", failure).Code)
+	assert.Equal(t, http.StatusConflict, serveJSON(appRouter, http.MethodPost, "/_uni/failures", failure).Code)
+
+	rec = serveJSON(appRouter, http.MethodGet, "/_uni/failures", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var failures []model.Failure
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &failures))
+	require.Len(t, failures, 1)
+	assert.Equal(t, http.MethodPost, failures[0].Method)
+
+	rec = serveJSON(appRouter, http.MethodPost, "/users", `{"id":"1"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), http.StatusText(http.StatusInternalServerError))
+
+	assert.Equal(t, http.StatusNotFound, serveJSON(appRouter, http.MethodDelete, "/_uni/failures/unknown", "").Code)
+}
@@ -2,6 +2,7 @@ package router_test
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"net/http/httptest"
@@ -123,11 +124,404 @@ func setupTestRouterWithReturnBodyFalse(t *testing.T) (*router.Router, *service.
 	techService := service.NewTechService(time.Now())
 
 	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, cfg)
-	techHandler := handler.NewTechHandler(techService, logger)
+	techHandler := handler.NewTechHandler(techService, uniService, scenarioService, logger, cfg)
 	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+	failureService := service.NewFailureService()
+	failureHandler := handler.NewFailureHandler(failureService, logger)
 
 	return router.NewRouter(
-		uniHandler, techHandler, scenarioHandler, 
-		scenarioService, techService, logger, cfg,
+		uniHandler, techHandler, scenarioHandler, failureHandler,
+		scenarioService, failureService, techService, logger, cfg,
 	), scenarioService
-}
\ No newline at end of file
+}
+
+func TestRouter_ScenarioPadToBytes(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	for _, scenario := range []model.Scenario{
+		{
+			UUID:        "padded-json",
+			RequestPath: "GET /api/padded-json",
+			StatusCode:  200,
+			ContentType: "application/json",
+			Data:        `{"id": "1"}`,
+			PadToBytes:  1024,
+		},
+		{
+			UUID:        "padded-text",
+			RequestPath: "GET /api/padded-text",
+			StatusCode:  200,
+			ContentType: "text/plain",
+			Data:        "hello",
+			PadToBytes:  64,
+		},
+	} {
+		_, err := scenarioService.CreateScenario(context.TODO(), scenario)
+		require.NoError(t, err)
+	}
+
+	jsonResp := httptest.NewRecorder()
+	appRouter.ServeHTTP(jsonResp, httptest.NewRequest("GET", "/api/padded-json", nil))
+	textResp := httptest.NewRecorder()
+	appRouter.ServeHTTP(textResp, httptest.NewRequest("GET", "/api/padded-text", nil))
+
+	assert.Equal(t, 1024, jsonResp.Body.Len())
+	assert.JSONEq(t, `{"id": "1"}`, jsonResp.Body.String())
+	assert.Equal(t, 64, textResp.Body.Len())
+	assert.True(t, strings.HasPrefix(textResp.Body.String(), "hello"))
+}
+
+func TestRouter_ScenarioRandomBytes(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "random-body",
+		RequestPath: "GET /api/random",
+		StatusCode:  200,
+		ContentType: "application/octet-stream",
+		RandomBytes: 4096,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/random", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, 4096, w.Body.Len())
+}
+
+func TestRouter_ScenarioThrottleBytesPerSec(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:                "throttled-body",
+		RequestPath:         "GET /api/slow",
+		StatusCode:          200,
+		ContentType:         "text/plain",
+		Data:                strings.Repeat("x", 300),
+		ThrottleBytesPerSec: 1000,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	start := time.Now()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/slow", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, 300, w.Body.Len())
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestRouter_ScenarioThresholdResponses(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "quota",
+		RequestPath: "GET /api/quota",
+		StatusCode:  200,
+		ContentType: "application/json",
+		Data:        `{"ok":true}`,
+		ThresholdResponses: []model.ThresholdResponse{
+			{AfterHits: 4, StatusCode: 503, Data: "down"},
+			{AfterHits: 2, StatusCode: 429, Data: `{"error":"quota exceeded"}`, Headers: map[string]string{"Retry-After": "60"}},
+		},
+	})
+	require.NoError(t, err)
+
+	statusFor := func() int {
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/quota", nil))
+		return w.Code
+	}
+
+	var statuses []int
+	for i := 0; i < 6; i++ {
+		statuses = append(statuses, statusFor())
+	}
+	assert.Equal(t, []int{200, 200, 429, 429, 503, 503}, statuses)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("POST", "/_uni/scenarios/quota/reset", nil))
+	require.Equal(t, 204, w.Code)
+
+	assert.Equal(t, 200, statusFor(), "reset restores the initial response")
+}
+
+func TestRouter_ScenarioExpireAfterHits(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	req := httptest.NewRequest("POST", "/api", strings.NewReader(`{"id":"1","source":"storage"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:            "warm-up",
+		RequestPath:     "GET /api/1",
+		StatusCode:      200,
+		ContentType:     "application/json",
+		Data:            `{"source":"scenario"}`,
+		ExpireAfterHits: 2,
+	})
+	require.NoError(t, err)
+
+	bodyFor := func() string {
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/1", nil))
+		require.Equal(t, 200, w.Code)
+		return w.Body.String()
+	}
+
+	assert.JSONEq(t, `{"source":"scenario"}`, bodyFor())
+	assert.JSONEq(t, `{"source":"scenario"}`, bodyFor())
+	assert.JSONEq(t, `{"id":"1","source":"storage"}`, bodyFor(), "expired scenarios fall through to storage")
+
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/_uni/scenarios/warm-up", nil))
+	assert.Equal(t, 200, w.Code, "expired scenarios stay listable")
+
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("POST", "/_uni/scenarios/warm-up/reset", nil))
+	require.Equal(t, 204, w.Code)
+
+	assert.JSONEq(t, `{"source":"scenario"}`, bodyFor(), "reset makes the scenario match again")
+}
+
+func TestRouter_ScenarioHeaderInjection(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "injected",
+		RequestPath: "GET /api/redirect",
+		StatusCode:  302,
+		ContentType: "text/plain",
+		Location:    "/home\r\nSet-Cookie: session=stolen",
+		Headers:     map[string]string{"X-Trace": "abc\ndef"},
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/redirect", nil))
+
+	assert.Equal(t, 302, w.Code)
+	assert.Equal(t, "/homeSet-Cookie: session=stolen", w.Header().Get("Location"))
+	assert.Equal(t, "abcdef", w.Header().Get("X-Trace"))
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+
+	appRouter.EnableHeaderRejection()
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/redirect", nil))
+
+	assert.Equal(t, 500, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+}
+
+func TestRouter_ScenarioCloseConnection(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:            "closing",
+		RequestPath:     "GET /api/closing",
+		StatusCode:      200,
+		ContentType:     "application/json",
+		Data:            `{}`,
+		CloseConnection: true,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/closing", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "close", w.Header().Get("Connection"))
+}
+
+func TestRouter_ScenarioResetUnknown(t *testing.T) {
+	appRouter, _ := setupTestRouterWithReturnBodyFalse(t)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("POST", "/_uni/scenarios/missing/reset", nil))
+
+	assert.Equal(t, 404, w.Code)
+}
+
+func TestRouter_ScenarioWildcardCaptures(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "order-template",
+		RequestPath: "GET /users/*/orders/*",
+		StatusCode:  200,
+		ContentType: "application/json",
+		Data:        `{"userId": "{{.Path1}}", "orderId": "{{.Path2}}"}`,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/users/u-1/orders/o-9", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"userId": "u-1", "orderId": "o-9"}`, w.Body.String())
+}
+
+func TestRouter_ScenarioWildcardCaptures_InvalidTemplateServedAsIs(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "handlebars",
+		RequestPath: "GET /pages/*",
+		StatusCode:  200,
+		ContentType: "text/html",
+		Data:        `<p>{{name}} {{.Path3}}</p>`,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/pages/home", nil))
+
+	assert.Equal(t, `<p>{{name}} {{.Path3}}</p>`, w.Body.String())
+}
+
+func TestRouter_PrettyJSON_Scenario(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	appRouter.EnablePrettyJSON()
+
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "pretty",
+		RequestPath: "GET /api/pretty",
+		StatusCode:  200,
+		ContentType: "application/json",
+		Data:        `{"ok":true}`,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/pretty", nil))
+
+	assert.Equal(t, "{\n  \"ok\": true\n}", w.Body.String())
+}
+
+func TestRouter_ScenarioMatchRequestLine(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:             "search",
+		MatchRequestLine: `^(GET|POST) /api/search\?(?:.*&)?q=(?P<term>[^&]+)`,
+		StatusCode:       200,
+		ContentType:      "application/json",
+		Data:             `{"method":"{{.Line1}}","q":"{{.term}}"}`,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/search?page=2&q=shoes", nil))
+	require.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"method":"GET","q":"shoes"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("POST", "/api/search?q=boots", nil))
+	require.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"method":"POST","q":"boots"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/search?q=shoes", nil))
+	assert.Equal(t, 404, w.Code, "other methods fall through to the mock")
+
+	w = httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/search?page=2", nil))
+	assert.Equal(t, 404, w.Code, "requests without the query parameter fall through to the mock")
+
+	_, err = scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		MatchRequestLine: `^GET /api/(`,
+		StatusCode:       200,
+	})
+	require.ErrorContains(t, err, "invalid matchRequestLine")
+}
+
+func TestRouter_ScenarioProtoMatch(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	for uuid, proto := range map[string]string{"http2": "HTTP/2", "http11": "http/1.1"} {
+		_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+			UUID:        uuid,
+			RequestPath: "GET /api/proto",
+			ProtoMatch:  proto,
+			StatusCode:  200,
+			ContentType: "text/plain",
+			Data:        uuid,
+		})
+		require.NoError(t, err)
+	}
+
+	request := func(major, minor int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/proto", nil)
+		req.ProtoMajor, req.ProtoMinor = major, minor
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, "http2", request(2, 0).Body.String())
+	assert.Equal(t, "http11", request(1, 1).Body.String())
+	assert.Equal(t, 404, request(1, 0).Code, "other protocols fall through to the mock")
+
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		RequestPath: "GET /api/proto",
+		ProtoMatch:  "HTTP/two",
+		StatusCode:  200,
+	})
+	require.ErrorContains(t, err, "invalid protoMatch")
+}
+
+func TestRouter_ScenarioOptions(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	for _, requestPath := range []string{
+		"GET /payments/*", "POST /payments/*", "DELETE /payments/42", "PATCH /api/*",
+	} {
+		_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+			RequestPath: requestPath,
+			StatusCode:  200,
+		})
+		require.NoError(t, err)
+	}
+	options := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		appRouter.ServeHTTP(w, httptest.NewRequest("OPTIONS", path, nil))
+		return w
+	}
+
+	w := options("/payments/42")
+	assert.Equal(t, 204, w.Code)
+	assert.Equal(t, "GET, POST, DELETE, OPTIONS", w.Header().Get("Allow"))
+
+	w = options("/payments/7")
+	assert.Equal(t, 204, w.Code)
+	assert.Equal(t, "GET, POST, OPTIONS", w.Header().Get("Allow"))
+
+	w = options("/api/1")
+	assert.Equal(t, 204, w.Code)
+	assert.Equal(t, "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS", w.Header().Get("Allow"),
+		"scenario methods are added to those of the section serving the path")
+
+	assert.Equal(t, 404, options("/refunds/1").Code, "paths without scenarios or sections")
+}
+
+func TestRouter_ScenarioResponseSchema(t *testing.T) {
+	appRouter, scenarioService := setupTestRouterWithReturnBodyFalse(t)
+	_, err := scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		UUID:        "generated",
+		RequestPath: "GET /api/users/*",
+		StatusCode:  200,
+		ContentType: "application/json",
+		ResponseSchema: json.RawMessage(`{"type": "object", "required": ["id"], "properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"name": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string", "enum": ["new"]}}
+		}}`),
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	appRouter.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/7", nil))
+	require.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"id": "00000000-0000-4000-8000-000000000000", "name": "string", "tags": ["new"]}`,
+		w.Body.String())
+
+	_, err = scenarioService.CreateScenario(context.TODO(), model.Scenario{
+		RequestPath:    "GET /api/broken",
+		StatusCode:     200,
+		ResponseSchema: json.RawMessage(`"string"`),
+	})
+	require.ErrorContains(t, err, "invalid responseSchema")
+}
@@ -62,11 +62,16 @@ func setupTestRouter(_ *testing.T) (*router.Router, *service.ScenarioService) {
 
 	// Create handlers
 	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, cfg)
-	techHandler := handler.NewTechHandler(techService, logger)
+	techHandler := handler.NewTechHandler(techService, uniService, scenarioService, logger, cfg)
 	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
+	failureService := service.NewFailureService()
+	failureHandler := handler.NewFailureHandler(failureService, logger)
 
 	// Create router
-	appRouter := router.NewRouter(uniHandler, techHandler, scenarioHandler, scenarioService, techService, logger, cfg)
+	appRouter := router.NewRouter(
+		uniHandler, techHandler, scenarioHandler, failureHandler,
+		scenarioService, failureService, techService, logger, cfg,
+	)
 
 	return appRouter, scenarioService
 }
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bmcszk/unimock/internal/handler"
+)
+
+// writeScenarioOptions answers OPTIONS for a path that scenarios are defined for with 204 and an
+// Allow header listing their methods, along with those of a section serving the path too.
+// It reports false, writing nothing, when no scenario matches the path.
+func (r *Router) writeScenarioOptions(w http.ResponseWriter, req *http.Request, requestPath string) bool {
+	include := []string{http.MethodOptions}
+	if r.uniConfig != nil {
+		if _, section, err := r.uniConfig.MatchPath(requestPath); err == nil && section != nil {
+			include = strings.Split(handler.AllowedMethods, ", ")
+		}
+	}
+	methods := r.scenarioService.MethodsForPath(req.Context(), requestPath, req, include...)
+	if len(methods) == 0 {
+		return false
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// renderScenarioCaptures fills {{.Path1}}, {{.Path2}}, ... in the data of a scenario with a wildcard
+// path with the request path segments its wildcards matched. Scenarios matching the request line
+// fill the groups of their expression instead: {{.Line1}}, ... and named groups by name.
+// Data that is no valid template or references other fields is served unchanged.
+func renderScenarioCaptures(scenario model.Scenario, requestPath, requestLine string) string {
+	if !strings.Contains(scenario.Data, "{{") {
+		return scenario.Data
+	}
+	fields, ok := scenarioCaptureFields(scenario, requestPath, requestLine)
+	if !ok {
+		return scenario.Data
+	}
+
+	tmpl, err := template.New("scenario").Option("missingkey=error").Parse(scenario.Data)
+	if err != nil {
+		return scenario.Data
+	}
+	var data strings.Builder
+	if err := tmpl.Execute(&data, fields); err != nil {
+		return scenario.Data
+	}
+	return data.String()
+}
+
+// scenarioCaptureFields returns the template fields captured by the scenario's request line expression
+// or wildcard path, and false when it captures nothing
+func scenarioCaptureFields(scenario model.Scenario, requestPath, requestLine string) (map[string]any, bool) {
+	if scenario.MatchRequestLine != "" {
+		return config.MatchRequestLine(scenario.MatchRequestLine, requestLine)
+	}
+	_, scenarioPath, _ := strings.Cut(scenario.RequestPath, " ")
+	if !strings.Contains(scenarioPath, config.WildcardChar) {
+		return nil, false
+	}
+	captures, ok := config.MatchCaptures(scenarioPath, requestPath, true)
+	if !ok {
+		return nil, false
+	}
+	return config.PathCaptureFields(captures), true
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// livenessPath is the health check that keeps answering while the server warms up
+const livenessPath = "/_uni/health"
+
+// EnableStartupDelay answers every request but the liveness probe with 503 Service Unavailable and
+// a Retry-After header until the delay has elapsed, simulating a backend that is slow to warm up.
+// The readiness probe /_uni/ready fails during the window like any other endpoint.
+func (r *Router) EnableStartupDelay(delay time.Duration) {
+	r.readyAt = time.Now().Add(delay)
+}
+
+// startupDelayMiddleware rejects requests until the server is ready
+func (r *Router) startupDelayMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		remaining := time.Until(r.readyAt)
+		if remaining <= 0 || req.URL.Path == livenessPath {
+			next.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(remaining.Seconds()))))
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// EnableTrailingSlashRedirect answers requests to mock paths ending in a slash with 301 Moved
+// Permanently to the same path without it, keeping the query string. The root path and /_uni
+// endpoints are served as they are.
+func (r *Router) EnableTrailingSlashRedirect() {
+	r.redirectTrailingSlash = true
+}
+
+// trailingSlashMiddleware redirects paths with a trailing slash to their canonical form when enabled
+func (r *Router) trailingSlashMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.redirectTrailingSlash || req.URL.Path == "/" || !strings.HasSuffix(req.URL.Path, "/") ||
+			strings.HasPrefix(req.URL.Path, "/_uni/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+		// Collapse leading slashes so that e.g. //example.com/ cannot redirect to another host
+		target := "/" + strings.Trim(req.URL.EscapedPath(), "/")
+		if req.URL.RawQuery != "" {
+			target += "?" + req.URL.RawQuery
+		}
+		http.Redirect(w, req, target, http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/google/uuid"
+)
+
+// FailureService manages forced failures, kept apart from scenarios so that they can be registered
+// and removed without touching scenario definitions
+type FailureService struct {
+	mu       sync.RWMutex
+	failures map[string]model.Failure
+	order    []string // failure IDs in creation order
+}
+
+// NewFailureService creates a new instance of FailureService
+func NewFailureService() *FailureService {
+	return &FailureService{failures: make(map[string]model.Failure)}
+}
+
+// CreateFailure registers a failure, generating its ID if not provided
+func (s *FailureService) CreateFailure(_ context.Context, failure model.Failure) (model.Failure, error) {
+	failure.Method = strings.ToUpper(failure.Method)
+	if err := validateFailure(failure); err != nil {
+		return model.Failure{}, err
+	}
+	if failure.ID == "" {
+		failure.ID = uuid.New().String()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.failures[failure.ID]; exists {
+		return model.Failure{}, errors.New("resource already exists")
+	}
+	s.failures[failure.ID] = failure
+	s.order = append(s.order, failure.ID)
+	return failure, nil
+}
+
+// ListFailures returns all failures in creation order
+func (s *FailureService) ListFailures(_ context.Context) []model.Failure {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	failures := make([]model.Failure, 0, len(s.order))
+	for _, id := range s.order {
+		failures = append(failures, s.failures[id])
+	}
+	return failures
+}
+
+// DeleteFailure removes a failure
+func (s *FailureService) DeleteFailure(_ context.Context, id string) error {
+	if id == "" {
+		return errors.New("invalid request: failure ID cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.failures[id]; !exists {
+		return errors.New("resource not found")
+	}
+	delete(s.failures, id)
+	for i, existing := range s.order {
+		if existing == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetFailureForRequest finds the failure for a request's method and path.
+// An exact path wins over wildcards, a longer wildcard prefix over a shorter one.
+func (s *FailureService) GetFailureForRequest(method, path string) (model.Failure, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var best model.Failure
+	bestSpecificity := -1
+	for _, id := range s.order {
+		failure := s.failures[id]
+		if failure.Method != method {
+			continue
+		}
+		if specificity, matches := failurePathSpecificity(failure.Path, path); matches && specificity > bestSpecificity {
+			best, bestSpecificity = failure, specificity
+		}
+	}
+	return best, bestSpecificity >= 0
+}
+
+// failurePathSpecificity reports whether the failure path matches and how specific the match is
+func failurePathSpecificity(failurePath, path string) (int, bool) {
+	if failurePath == path {
+		return len(failurePath) + 1, true
+	}
+	basePath, isWildcard := strings.CutSuffix(failurePath, "/*")
+	if isWildcard && (path == basePath || strings.HasPrefix(path, basePath+"/")) {
+		return len(basePath), true
+	}
+	return 0, false
+}
+
+// validateFailure validates a failure
+func validateFailure(failure model.Failure) error {
+	switch failure.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete,
+		http.MethodPatch, http.MethodHead, http.MethodOptions:
+	default:
+		return fmt.Errorf("invalid HTTP method: %q", failure.Method)
+	}
+	if !strings.HasPrefix(failure.Path, "/") {
+		return fmt.Errorf("invalid path: %q must start with /", failure.Path)
+	}
+	if failure.StatusCode < http.StatusBadRequest || failure.StatusCode > maxStatusCode {
+		return fmt.Errorf("invalid status code: %d must be between 400 and %d", failure.StatusCode, maxStatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureService_MostSpecificPathWins(t *testing.T) {
+	failureSvc := service.NewFailureService()
+	ctx := context.Background()
+	for _, failure := range []model.Failure{
+		{ID: "all", Method: "GET", Path: "/users/*", StatusCode: 500},
+		{ID: "admins", Method: "GET", Path: "/users/admins/*", StatusCode: 503},
+		{ID: "one", Method: "GET", Path: "/users/1", StatusCode: 404},
+	} {
+		_, err := failureSvc.CreateFailure(ctx, failure)
+		require.NoError(t, err)
+	}
+
+	tests := map[string]string{"/users/1": "one", "/users/2": "all", "/users/admins/7": "admins", "/users": "all"}
+	for path, expectedID := range tests {
+		failure, found := failureSvc.GetFailureForRequest("GET", path)
+		assert.True(t, found, path)
+		assert.Equal(t, expectedID, failure.ID, path)
+	}
+
+	_, found := failureSvc.GetFailureForRequest("POST", "/users/1")
+	assert.False(t, found)
+	_, found = failureSvc.GetFailureForRequest("GET", "/orders/1")
+	assert.False(t, found)
+
+	require.NoError(t, failureSvc.DeleteFailure(ctx, "one"))
+	failure, _ := failureSvc.GetFailureForRequest("GET", "/users/1")
+	assert.Equal(t, "all", failure.ID)
+	assert.Len(t, failureSvc.ListFailures(ctx), 2)
+}
+
+func TestFailureService_RejectsInvalidFailures(t *testing.T) {
+	failureSvc := service.NewFailureService()
+	ctx := context.Background()
+
+	invalid := []model.Failure{
+		{Method: "FETCH", Path: "/users", StatusCode: 500},
+		{Method: "GET", Path: "users", StatusCode: 500},
+		{Method: "GET", Path: "/users", StatusCode: 302},
+	}
+	for _, failure := range invalid {
+		_, err := failureSvc.CreateFailure(ctx, failure)
+		assert.Error(t, err, "%+v", failure)
+	}
+	assert.Error(t, failureSvc.DeleteFailure(ctx, "missing"))
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/service"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newScenarioServiceWith creates a scenario service preloaded with the given scenarios
+func newScenarioServiceWith(t *testing.T, scenarios ...model.Scenario) *service.ScenarioService {
+	t.Helper()
+	scenarioSvc := service.NewScenarioService(storage.NewScenarioStorage())
+	for _, scenario := range scenarios {
+		_, err := scenarioSvc.CreateScenario(context.Background(), scenario)
+		require.NoError(t, err)
+	}
+	return scenarioSvc
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestScenarioService_GetScenarioForRequest_ContentLength(t *testing.T) {
+	scenarioSvc := newScenarioServiceWith(t,
+		model.Scenario{
+			UUID:               "small-body",
+			RequestPath:        "POST /upload",
+			StatusCode:         http.StatusCreated,
+			MatchContentLength: &model.ContentLengthRange{Min: 1, Max: int64Ptr(10)},
+		},
+		model.Scenario{
+			UUID:               "large-body",
+			RequestPath:        "POST /upload",
+			StatusCode:         http.StatusRequestEntityTooLarge,
+			MatchContentLength: &model.ContentLengthRange{Min: 11},
+		},
+	)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedUUID string
+		expectFound  bool
+	}{
+		{name: "small body", body: `{"a":1}`, expectedUUID: "small-body", expectFound: true},
+		{name: "large body", body: strings.Repeat("x", 100), expectedUUID: "large-body", expectFound: true},
+		{name: "empty body matches nothing", body: "", expectFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(tt.body))
+
+			scenario, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/upload", req)
+
+			assert.Equal(t, tt.expectFound, found)
+			assert.Equal(t, tt.expectedUUID, scenario.UUID)
+		})
+	}
+}
+
+func TestScenarioService_GetScenarioForRequest_UnknownContentLength(t *testing.T) {
+	scenarioSvc := newScenarioServiceWith(t, model.Scenario{
+		UUID:               "empty-body",
+		RequestPath:        "POST /upload",
+		StatusCode:         http.StatusBadRequest,
+		MatchContentLength: &model.ContentLengthRange{Max: int64Ptr(0)},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("payload"))
+	req.ContentLength = -1
+
+	_, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/upload", req)
+
+	assert.False(t, found)
+	assert.Equal(t, int64(len("payload")), req.ContentLength)
+}
+
+func TestScenarioService_GetScenarioForRequest_FeatureFlag(t *testing.T) {
+	scenarioSvc := newScenarioServiceWith(t,
+		model.Scenario{
+			UUID:        "new-checkout",
+			RequestPath: "GET /checkout",
+			StatusCode:  http.StatusOK,
+			Data:        `{"version":"new"}`,
+			RequireFlag: "new-checkout",
+		},
+		model.Scenario{
+			UUID:        "old-checkout",
+			RequestPath: "GET /checkout",
+			StatusCode:  http.StatusOK,
+			Data:        `{"version":"old"}`,
+		},
+	)
+
+	tests := []struct {
+		name         string
+		flags        string
+		expectedData string
+	}{
+		{name: "flag present", flags: "dark-mode, new-checkout", expectedData: `{"version":"new"}`},
+		{name: "other flags only", flags: "dark-mode", expectedData: `{"version":"old"}`},
+		{name: "no flags header", expectedData: `{"version":"old"}`},
+		{name: "flag as substring does not match", flags: "new-checkout-v2", expectedData: `{"version":"old"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/checkout", nil)
+			if tt.flags != "" {
+				req.Header.Set("X-Feature-Flags", tt.flags)
+			}
+
+			scenario, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/checkout", req)
+
+			require.True(t, found)
+			assert.Equal(t, tt.expectedData, scenario.Data)
+		})
+	}
+}
+
+func TestScenarioService_GetScenarioForRequest_CookieMatch(t *testing.T) {
+	scenarioSvc := newScenarioServiceWith(t,
+		model.Scenario{
+			UUID:        "anonymous",
+			RequestPath: "GET /profile",
+			StatusCode:  http.StatusUnauthorized,
+		},
+		model.Scenario{
+			UUID:        "any-session",
+			RequestPath: "GET /profile",
+			StatusCode:  http.StatusOK,
+			CookieMatch: map[string]string{"session": "*"},
+		},
+		model.Scenario{
+			UUID:        "admin-session",
+			RequestPath: "GET /profile",
+			StatusCode:  http.StatusOK,
+			Priority:    1,
+			CookieMatch: map[string]string{"session": "admin", "theme": "*"},
+		},
+	)
+
+	tests := []struct {
+		name         string
+		cookies      []*http.Cookie
+		expectedUUID string
+	}{
+		{name: "no cookies", expectedUUID: "anonymous"},
+		{name: "other cookie only", cookies: []*http.Cookie{{Name: "theme", Value: "dark"}}, expectedUUID: "anonymous"},
+		{name: "any session", cookies: []*http.Cookie{{Name: "session", Value: "abc"}}, expectedUUID: "any-session"},
+		{
+			name:         "admin session without theme",
+			cookies:      []*http.Cookie{{Name: "session", Value: "admin"}},
+			expectedUUID: "any-session",
+		},
+		{
+			name:         "admin session with theme",
+			cookies:      []*http.Cookie{{Name: "session", Value: "admin"}, {Name: "theme", Value: "dark"}},
+			expectedUUID: "admin-session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+			for _, cookie := range tt.cookies {
+				req.AddCookie(cookie)
+			}
+
+			scenario, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/profile", req)
+
+			require.True(t, found)
+			assert.Equal(t, tt.expectedUUID, scenario.UUID)
+		})
+	}
+}
+
+func timePtr(v time.Time) *time.Time {
+	return &v
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestScenarioService_GetScenarioForRequest_Activation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		scenario    model.Scenario
+		expectFound bool
+	}{
+		{name: "enabled by default", expectFound: true},
+		{name: "explicitly enabled", scenario: model.Scenario{Enabled: boolPtr(true)}, expectFound: true},
+		{name: "disabled", scenario: model.Scenario{Enabled: boolPtr(false)}},
+		{name: "window not yet open", scenario: model.Scenario{ActiveFrom: timePtr(now.Add(time.Hour))}},
+		{name: "window closed", scenario: model.Scenario{ActiveUntil: timePtr(now.Add(-time.Hour))}},
+		{
+			name: "inside window",
+			scenario: model.Scenario{
+				ActiveFrom:  timePtr(now.Add(-time.Hour)),
+				ActiveUntil: timePtr(now.Add(time.Hour)),
+			},
+			expectFound: true,
+		},
+		{
+			name: "disabled inside window",
+			scenario: model.Scenario{
+				Enabled:     boolPtr(false),
+				ActiveFrom:  timePtr(now.Add(-time.Hour)),
+				ActiveUntil: timePtr(now.Add(time.Hour)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := tt.scenario
+			scenario.UUID = "staged"
+			scenario.RequestPath = "GET /orders"
+			scenario.StatusCode = http.StatusServiceUnavailable
+			scenarioSvc := newScenarioServiceWith(t, scenario)
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+
+			_, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/orders", req)
+
+			assert.Equal(t, tt.expectFound, found)
+		})
+	}
+}
+
+func TestScenarioService_SetScenarioEnabled(t *testing.T) {
+	scenarioSvc := newScenarioServiceWith(t, model.Scenario{
+		UUID:        "toggle",
+		RequestPath: "GET /orders",
+		StatusCode:  http.StatusServiceUnavailable,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+
+	disabled, err := scenarioSvc.SetScenarioEnabled(context.Background(), "toggle", false)
+	require.NoError(t, err)
+	assert.False(t, *disabled.Enabled)
+	_, found := scenarioSvc.GetScenarioForRequest(context.Background(), "/orders", req)
+	assert.False(t, found)
+
+	_, err = scenarioSvc.SetScenarioEnabled(context.Background(), "toggle", true)
+	require.NoError(t, err)
+	_, found = scenarioSvc.GetScenarioForRequest(context.Background(), "/orders", req)
+	assert.True(t, found)
+
+	_, err = scenarioSvc.SetScenarioEnabled(context.Background(), "missing", true)
+	assert.Error(t, err)
+}
+
+func TestScenarioService_GetScenarioByPath_Priority(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarios    []model.Scenario
+		path         string
+		expectedUUID string
+	}{
+		{
+			name: "higher priority wins over more specific path",
+			scenarios: []model.Scenario{
+				{UUID: "specific", RequestPath: "GET /users/1", StatusCode: http.StatusOK},
+				{UUID: "general", RequestPath: "GET /users/*", StatusCode: http.StatusOK, Priority: 10},
+			},
+			path:         "/users/1",
+			expectedUUID: "general",
+		},
+		{
+			name: "exact path wins priority tie",
+			scenarios: []model.Scenario{
+				{UUID: "general", RequestPath: "GET /users/*", StatusCode: http.StatusOK, Priority: 5},
+				{UUID: "specific", RequestPath: "GET /users/1", StatusCode: http.StatusOK, Priority: 5},
+			},
+			path:         "/users/1",
+			expectedUUID: "specific",
+		},
+		{
+			name: "longer wildcard wins priority tie",
+			scenarios: []model.Scenario{
+				{UUID: "api", RequestPath: "GET /api/*", StatusCode: http.StatusOK},
+				{UUID: "api-users", RequestPath: "GET /api/users/*", StatusCode: http.StatusOK},
+			},
+			path:         "/api/users/1",
+			expectedUUID: "api-users",
+		},
+		{
+			name: "earliest created wins full tie",
+			scenarios: []model.Scenario{
+				{UUID: "first", RequestPath: "GET /users/*", StatusCode: http.StatusOK},
+				{UUID: "second", RequestPath: "GET /users/*", StatusCode: http.StatusOK},
+				{UUID: "third", RequestPath: "GET /users/*", StatusCode: http.StatusOK},
+			},
+			path:         "/users/1",
+			expectedUUID: "first",
+		},
+		{
+			name: "negative priority yields to default",
+			scenarios: []model.Scenario{
+				{UUID: "fallback", RequestPath: "GET /users/1", StatusCode: http.StatusOK, Priority: -1},
+				{UUID: "default", RequestPath: "GET /users/*", StatusCode: http.StatusOK},
+			},
+			path:         "/users/1",
+			expectedUUID: "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to catch any dependence on map iteration order
+			for i := 0; i < 20; i++ {
+				scenarioSvc := newScenarioServiceWith(t, tt.scenarios...)
+
+				scenario, found := scenarioSvc.GetScenarioByPath(context.Background(), tt.path, http.MethodGet)
+
+				require.True(t, found)
+				require.Equal(t, tt.expectedUUID, scenario.UUID)
+			}
+		})
+	}
+}
@@ -1,15 +1,23 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	// "log/slog"
 	// "os"
 	"strings"
 
 	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
 	"github.com/bmcszk/unimock/pkg/model"
 	"github.com/google/uuid"
 )
@@ -19,81 +27,253 @@ const (
 	singleItem       = 1
 	minStatusCode    = 100
 	maxStatusCode    = 599
+
+	// featureFlagsHeader carries the comma-separated feature flags matched against Scenario.RequireFlag
+	featureFlagsHeader = "X-Feature-Flags"
 )
 
 // ScenarioService manages test scenarios
 type ScenarioService struct {
 	storage storage.ScenarioStorage
+
+	hitsMu sync.Mutex
+	hits   map[string]*atomic.Int64 // hit counters by scenario UUID
 }
 
 // NewScenarioService creates a new instance of ScenarioService
 func NewScenarioService(scenarioStorage storage.ScenarioStorage) *ScenarioService {
 	return &ScenarioService{
 		storage: scenarioStorage,
+		hits:    make(map[string]*atomic.Int64),
+	}
+}
+
+// RecordHit counts a request served by the scenario and returns its 1-based hit number
+func (s *ScenarioService) RecordHit(id string) int64 {
+	s.hitsMu.Lock()
+	counter, ok := s.hits[id]
+	if !ok {
+		counter = &atomic.Int64{}
+		s.hits[id] = counter
+	}
+	s.hitsMu.Unlock()
+	return counter.Add(1)
+}
+
+// isExpired reports whether the scenario has served all the hits its ExpireAfterHits allows
+func (s *ScenarioService) isExpired(scenario model.Scenario) bool {
+	if scenario.ExpireAfterHits <= 0 {
+		return false
 	}
+	s.hitsMu.Lock()
+	counter, ok := s.hits[scenario.UUID]
+	s.hitsMu.Unlock()
+	return ok && counter.Load() >= int64(scenario.ExpireAfterHits)
+}
+
+// ResetScenario sets the scenario's hit counter back to zero, restoring its initial threshold response
+func (s *ScenarioService) ResetScenario(_ context.Context, id string) error {
+	if id == "" {
+		return errors.New("invalid request: scenario ID cannot be empty")
+	}
+	if _, err := s.storage.Get(id); err != nil {
+		return errors.New("resource not found")
+	}
+	s.hitsMu.Lock()
+	delete(s.hits, id)
+	s.hitsMu.Unlock()
+	return nil
 }
 
 // GetScenarioByPath is a convenience method primarily for testing.
 // It iterates through scenarios to find a match based on method and path (exact or wildcard).
 func (s *ScenarioService) GetScenarioByPath(_ context.Context, path string, method string) (model.Scenario, bool) {
 	scenarios := s.storage.List()
-	return s.findBestScenarioMatch(scenarios, path, method)
+	return s.findBestScenarioMatch(scenarios, path, method, method+" "+path)
 }
 
-// findBestScenarioMatch searches through scenarios to find the best match
-func (s *ScenarioService) findBestScenarioMatch(
-	scenarios []model.Scenario, path, method string,
+// GetScenarioForRequest finds the best scenario for a request.
+// In addition to method and path, it evaluates request-based criteria such as the body size.
+// Disabled scenarios, those outside their activation window and those past their hit limit are skipped.
+func (s *ScenarioService) GetScenarioForRequest(
+	_ context.Context, path string, req *http.Request,
 ) (model.Scenario, bool) {
-	var exactMatch model.Scenario
-	var wildcardMatch model.Scenario
-
-	for _, scenario := range scenarios {
-		if !s.isMethodMatch(scenario, method) {
+	gated := make([]model.Scenario, 0)
+	candidates := make([]model.Scenario, 0)
+	flags := requestFeatureFlags(req)
+	now := time.Now()
+	for _, scenario := range s.storage.List() {
+		if !scenario.IsActive(now) || s.isExpired(scenario) || !s.matchesRequestCriteria(scenario, req, flags) {
 			continue
 		}
+		if scenario.RequireFlag != "" || len(scenario.CookieMatch) > 0 {
+			gated = append(gated, scenario)
+		} else {
+			candidates = append(candidates, scenario)
+		}
+	}
 
-		if s.tryExactMatch(&exactMatch, scenario, path) {
+	// Scenarios gated by a present feature flag or matching cookies take precedence over their ungated counterparts
+	requestLine := config.RequestLine(req)
+	if match, found := s.findBestScenarioMatch(gated, path, req.Method, requestLine); found {
+		return match, true
+	}
+	return s.findBestScenarioMatch(candidates, path, req.Method, requestLine)
+}
+
+// scenarioMethods lists the methods scenarios can be defined for, in the order MethodsForPath reports them
+var scenarioMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+// MethodsForPath returns the methods of the enabled, active and unexpired scenarios matching the path,
+// together with the methods in include, e.g. those of a section serving the path too. Request line
+// expressions are tried with each method. It returns nil when no scenario matches the path.
+func (s *ScenarioService) MethodsForPath(
+	_ context.Context, path string, req *http.Request, include ...string,
+) []string {
+	found := make(map[string]bool, len(scenarioMethods))
+	now := time.Now()
+	for _, scenario := range s.storage.List() {
+		if !scenario.IsActive(now) || s.isExpired(scenario) {
 			continue
 		}
+		for _, method := range scenarioMethods {
+			if _, matches := s.scenarioMatch(scenario, path, method, method+" "+req.URL.RequestURI()); matches {
+				found[method] = true
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	for _, method := range include {
+		found[method] = true
+	}
 
-		s.tryWildcardMatch(&wildcardMatch, scenario, path)
+	methods := make([]string, 0, len(found))
+	for _, method := range scenarioMethods {
+		if found[method] {
+			methods = append(methods, method)
+		}
 	}
+	return methods
+}
 
-	return s.selectBestMatch(exactMatch, wildcardMatch)
+// matchesRequestCriteria checks the request-based criteria of a scenario
+func (*ScenarioService) matchesRequestCriteria(
+	scenario model.Scenario, req *http.Request, flags map[string]bool,
+) bool {
+	if scenario.MatchContentLength != nil &&
+		!scenario.MatchContentLength.Contains(requestContentLength(req)) {
+		return false
+	}
+	if scenario.RequireFlag != "" && !flags[scenario.RequireFlag] {
+		return false
+	}
+	if !matchesCookies(scenario.CookieMatch, req) {
+		return false
+	}
+	return config.ProtoMatches(scenario.ProtoMatch, req.ProtoMajor, req.ProtoMinor)
 }
 
-// isMethodMatch checks if scenario matches the HTTP method
-func (s *ScenarioService) isMethodMatch(scenario model.Scenario, method string) bool {
-	scenarioMethod, _ := s.parseRequestPath(scenario.RequestPath)
-	return scenarioMethod != "" && scenarioMethod == method
+// matchesCookies reports whether the request carries every expected cookie with its value,
+// any value of a present cookie matching "*"
+func matchesCookies(expected map[string]string, req *http.Request) bool {
+	for name, value := range expected {
+		cookie, err := req.Cookie(name)
+		if err != nil || (value != "*" && cookie.Value != value) {
+			return false
+		}
+	}
+	return true
 }
 
-// tryExactMatch attempts to find an exact match
-func (s *ScenarioService) tryExactMatch(exactMatch *model.Scenario, scenario model.Scenario, path string) bool {
-	if exactMatch.UUID != "" {
-		return false // Already have exact match
+// requestFeatureFlags parses the comma-separated X-Feature-Flags header into a set
+func requestFeatureFlags(req *http.Request) map[string]bool {
+	flags := make(map[string]bool)
+	for _, value := range req.Header.Values(featureFlagsHeader) {
+		for _, flag := range strings.Split(value, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				flags[flag] = true
+			}
+		}
 	}
+	return flags
+}
 
-	_, scenarioPath := s.parseRequestPath(scenario.RequestPath)
-	if match, found := s.checkExactMatch(scenario, scenarioPath, path); found {
-		*exactMatch = match
-		return true // Found exact match
+// requestContentLength returns the request body size, reading and restoring the body when unknown
+func requestContentLength(req *http.Request) int64 {
+	if req.ContentLength >= 0 {
+		return req.ContentLength
+	}
+	if req.Body == nil {
+		return 0
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return 0
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return req.ContentLength
+}
+
+// findBestScenarioMatch picks the matching scenario with the highest priority, breaking ties by the most
+// specific path (exact over wildcard, longer wildcard prefix first, request line expressions last)
+// and then by creation order. scenarios must be in creation order.
+func (s *ScenarioService) findBestScenarioMatch(
+	scenarios []model.Scenario, path, method, requestLine string,
+) (model.Scenario, bool) {
+	var best model.Scenario
+	bestSpecificity := 0
+	found := false
+
+	for _, scenario := range scenarios {
+		specificity, matches := s.scenarioMatch(scenario, path, method, requestLine)
+		if !matches {
+			continue
+		}
+		if !found || scenario.Priority > best.Priority ||
+			(scenario.Priority == best.Priority && specificity > bestSpecificity) {
+			best, bestSpecificity, found = scenario, specificity, true
+		}
 	}
-	return false
+
+	return best, found
 }
 
-// tryWildcardMatch attempts to find a wildcard match
-func (s *ScenarioService) tryWildcardMatch(wildcardMatch *model.Scenario, scenario model.Scenario, path string) bool {
-	if wildcardMatch.UUID != "" {
-		return false // Already have wildcard match
+// scenarioMatch reports whether the scenario matches the request and how specific the match is.
+// Scenarios with a request line expression match by it alone, as least specific.
+func (s *ScenarioService) scenarioMatch(scenario model.Scenario, path, method, requestLine string) (int, bool) {
+	if scenario.MatchRequestLine != "" {
+		_, matches := config.MatchRequestLine(scenario.MatchRequestLine, requestLine)
+		return 0, matches
+	}
+	if !s.isMethodMatch(scenario, method) {
+		return 0, false
 	}
+	return s.matchSpecificity(scenario, path)
+}
 
+// matchSpecificity reports whether the scenario's path matches and how specific the match is:
+// exact paths rank above every wildcard, longer wildcard prefixes above shorter ones
+func (s *ScenarioService) matchSpecificity(scenario model.Scenario, path string) (int, bool) {
 	_, scenarioPath := s.parseRequestPath(scenario.RequestPath)
-	if match, found := s.checkWildcardMatch(scenario, scenarioPath, path); found {
-		*wildcardMatch = match
-		return true
+	if _, found := s.checkExactMatch(scenario, scenarioPath, path); found {
+		return math.MaxInt, true
 	}
-	return false
+	if _, found := s.checkWildcardMatch(scenario, scenarioPath, path); found {
+		return len(scenarioPath), true
+	}
+	return 0, false
+}
+
+// isMethodMatch checks if scenario matches the HTTP method
+func (s *ScenarioService) isMethodMatch(scenario model.Scenario, method string) bool {
+	scenarioMethod, _ := s.parseRequestPath(scenario.RequestPath)
+	return scenarioMethod != "" && scenarioMethod == method
 }
 
 // parseRequestPath extracts method and path from scenario request path
@@ -115,25 +295,18 @@ func (*ScenarioService) checkExactMatch(
 	return model.Scenario{}, false
 }
 
-// checkWildcardMatch checks if scenario matches wildcard path and returns the match if found
+// checkWildcardMatch checks if scenario matches wildcard path and returns the match if found.
+// A single trailing /* matches any path below its prefix; other wildcards match like section patterns.
 func (s *ScenarioService) checkWildcardMatch(
 	scenario model.Scenario, scenarioPath, path string,
 ) (model.Scenario, bool) {
-	if strings.HasSuffix(scenarioPath, "/*") {
+	if basePath, ok := strings.CutSuffix(scenarioPath, "/*"); ok && !strings.Contains(basePath, config.WildcardChar) {
 		return s.handleWildcardMatch(scenario, scenarioPath, path)
 	}
-	return model.Scenario{}, false
-}
-
-// selectBestMatch returns the best match, preferring exact over wildcard
-func (*ScenarioService) selectBestMatch(
-	exactMatch model.Scenario, wildcardMatch model.Scenario,
-) (model.Scenario, bool) {
-	if exactMatch.UUID != "" {
-		return exactMatch, true
-	}
-	if wildcardMatch.UUID != "" {
-		return wildcardMatch, true
+	if strings.Contains(scenarioPath, config.WildcardChar) {
+		if _, ok := config.MatchCaptures(scenarioPath, path, true); ok {
+			return scenario, true
+		}
 	}
 	return model.Scenario{}, false
 }
@@ -230,6 +403,22 @@ func (s *ScenarioService) performStorageUpdate(id string, scenario model.Scenari
 	return nil
 }
 
+// SetScenarioEnabled switches a scenario on or off and returns the updated scenario
+func (s *ScenarioService) SetScenarioEnabled(_ context.Context, id string, enabled bool) (model.Scenario, error) {
+	if id == "" {
+		return model.Scenario{}, errors.New("invalid request: scenario ID cannot be empty")
+	}
+	scenario, err := s.storage.Get(id)
+	if err != nil {
+		return model.Scenario{}, errors.New("resource not found")
+	}
+	scenario.Enabled = &enabled
+	if err := s.performStorageUpdate(id, scenario); err != nil {
+		return model.Scenario{}, err
+	}
+	return scenario, nil
+}
+
 // DeleteScenario removes a scenario
 func (s *ScenarioService) DeleteScenario(_ context.Context, id string) error {
 	if id == "" {
@@ -240,11 +429,63 @@ func (s *ScenarioService) DeleteScenario(_ context.Context, id string) error {
 		// Standardized error message for not found resources
 		return errors.New("resource not found")
 	}
+	s.hitsMu.Lock()
+	delete(s.hits, id)
+	s.hitsMu.Unlock()
+	return nil
+}
+
+// ApplyScenarios validates all scenarios first and then stores them at once, replacing every
+// existing scenario when replace is set; in either case a scenario whose UUID exists replaces that
+// one. Invalid scenarios leave the stored scenarios untouched.
+func (s *ScenarioService) ApplyScenarios(_ context.Context, scenarios []model.Scenario, replace bool) error {
+	applied := make([]model.Scenario, 0, len(scenarios))
+	for i, scenario := range scenarios {
+		if err := s.validateScenario(scenario); err != nil {
+			return fmt.Errorf("scenario %d (%s): %w", i+1, scenario.RequestPath, err)
+		}
+		if scenario.UUID == "" {
+			scenario.UUID = uuid.New().String()
+		}
+		applied = append(applied, scenario)
+	}
+	if err := s.storage.Apply(applied, replace); err != nil {
+		return err
+	}
+	if replace {
+		s.hitsMu.Lock()
+		s.hits = make(map[string]*atomic.Int64)
+		s.hitsMu.Unlock()
+	}
 	return nil
 }
 
 // validateScenario validates a scenario
 func (*ScenarioService) validateScenario(scenario model.Scenario) error {
+	if scenario.ExpireAfterHits < 0 {
+		return fmt.Errorf("invalid expireAfterHits: must not be negative, got %d", scenario.ExpireAfterHits)
+	}
+	if len(scenario.ResponseSchema) > 0 {
+		if _, err := config.SchemaExample(scenario.ResponseSchema); err != nil {
+			return fmt.Errorf("invalid responseSchema: %w", err)
+		}
+	}
+	if scenario.ProtoMatch != "" {
+		if _, _, err := config.ParseProtoMatch(scenario.ProtoMatch); err != nil {
+			return fmt.Errorf("invalid protoMatch: %w", err)
+		}
+	}
+
+	// A request line expression replaces the request path
+	if scenario.MatchRequestLine != "" {
+		if _, err := config.CompileRequestLine(scenario.MatchRequestLine); err != nil {
+			return fmt.Errorf("invalid matchRequestLine: %w", err)
+		}
+		if scenario.RequestPath == "" {
+			return nil
+		}
+	}
+
 	// Validate request path format
 	parts := strings.SplitN(scenario.RequestPath, " ", 2)
 	if len(parts) != 2 {
@@ -268,5 +509,9 @@ func (*ScenarioService) validateScenario(scenario model.Scenario) error {
 		return fmt.Errorf("invalid HTTP method in request path: %s", method)
 	}
 
+	if !strings.HasPrefix(parts[1], "/") {
+		return fmt.Errorf("invalid request path: %q must start with /", parts[1])
+	}
+
 	return nil
 }
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// ResourceStats summarizes the stored resources
+type ResourceStats struct {
+	// Resources is the number of stored resources
+	Resources int
+	// ResourcesBySection counts the resources by the name of the section they belong to
+	ResourcesBySection map[string]int
+	// Bytes approximates the memory taken by the resources and their previous versions
+	Bytes int64
+}
+
+// Stats counts the stored resources, per section too, and estimates their memory usage
+func (s *UniService) Stats(_ context.Context) ResourceStats {
+	storageStats := s.storage.Stats()
+	stats := ResourceStats{
+		Resources:          storageStats.Resources,
+		ResourcesBySection: make(map[string]int),
+		Bytes:              storageStats.Bytes,
+	}
+	for collectionPath, count := range storageStats.ResourcesByPath {
+		if name, ok := s.sectionOf(collectionPath); ok {
+			stats.ResourcesBySection[name] += count
+		}
+	}
+	return stats
+}
+
+// sectionOf returns the name of the section storing resources under the collection path. Resources
+// are stored without a trailing slash, which sections matching only slashed paths expect.
+func (s *UniService) sectionOf(collectionPath string) (string, bool) {
+	if s.uniCfg == nil {
+		return "", false
+	}
+	for _, candidate := range []string{collectionPath, collectionPath + config.PathSeparator} {
+		if name, section, err := s.uniCfg.MatchPath(candidate); err == nil && section != nil {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// CountScenarios returns the number of stored scenarios
+func (s *ScenarioService) CountScenarios(_ context.Context) int {
+	return s.storage.Count()
+}
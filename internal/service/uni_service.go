@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	unimockerrors "github.com/bmcszk/unimock/internal/errors"
 	"github.com/bmcszk/unimock/internal/storage"
@@ -15,6 +16,9 @@ import (
 type UniService struct {
 	storage storage.UniStorage
 	uniCfg *config.UniConfig
+
+	txMu   sync.Mutex
+	staged map[string][]stagedResource
 }
 
 // NewUniService creates a new instance of UniService
@@ -22,9 +26,15 @@ func NewUniService(uniStorage storage.UniStorage, cfg *config.UniConfig) *UniSer
 	return &UniService{
 		storage: uniStorage,
 		uniCfg: cfg,
+		staged: make(map[string][]stagedResource),
 	}
 }
 
+// NewID generates an ID for a resource created without one, using the storage's ID generator
+func (s *UniService) NewID() string {
+	return s.storage.NewID()
+}
+
 // GetResource retrieves a resource by section and ID
 func (s *UniService) GetResource(
 	_ context.Context, sectionName string, isStrictPath bool, id string,
@@ -75,6 +85,9 @@ func (s *UniService) CreateResource(
 		if _, ok := err.(*unimockerrors.InvalidRequestError); ok {
 			return err
 		}
+		if _, ok := err.(*unimockerrors.StorageFullError); ok {
+			return err
+		}
 		return fmt.Errorf("failed to create resource: %v", err)
 	}
 	return nil
@@ -84,13 +97,64 @@ func (s *UniService) CreateResource(
 func (s *UniService) UpdateResource(
 	_ context.Context, sectionName string, isStrictPath bool, id string, data model.UniData,
 ) error {
-	err := s.storage.Update(sectionName, isStrictPath, id, data)
+	err := s.updateStored(sectionName, isStrictPath, id, data)
 	if err != nil {
 		return s.handleUpdateError(err, sectionName, isStrictPath, id, data)
 	}
 	return nil
 }
 
+// updateStored updates the stored resource, keeping the replaced data in versioned sections
+func (s *UniService) updateStored(sectionName string, isStrictPath bool, id string, data model.UniData) error {
+	if s.isVersioned(sectionName) {
+		return s.storage.UpdateWithHistory(sectionName, isStrictPath, id, data)
+	}
+	return s.storage.Update(sectionName, isStrictPath, id, data)
+}
+
+// isVersioned reports whether the section keeps previous versions of its resources
+func (s *UniService) isVersioned(sectionName string) bool {
+	if s.uniCfg == nil {
+		return false
+	}
+	section, _ := s.uniCfg.Section(sectionName)
+	return section.Versioned
+}
+
+// UpdateResourceIfVersion updates an existing resource only while it is at expectedVersion, keeping
+// the replaced data in versioned sections. Unlike UpdateResource it never creates the resource.
+func (s *UniService) UpdateResourceIfVersion(
+	_ context.Context, sectionName string, isStrictPath bool, id string, expectedVersion int, data model.UniData,
+) error {
+	var err error
+	if s.isVersioned(sectionName) {
+		err = s.storage.UpdateIfVersionWithHistory(sectionName, isStrictPath, id, expectedVersion, data)
+	} else {
+		err = s.storage.UpdateIfVersion(sectionName, isStrictPath, id, expectedVersion, data)
+	}
+	if err == nil {
+		return nil
+	}
+	switch err.(type) {
+	case *unimockerrors.NotFoundError, *unimockerrors.VersionConflictError, *unimockerrors.InvalidRequestError:
+		return err
+	default:
+		return fmt.Errorf("failed to update resource: %w", err)
+	}
+}
+
+// GetResourceHistory returns the previous versions of a resource in a versioned section, oldest first
+func (s *UniService) GetResourceHistory(_ context.Context, sectionName string, id string) ([]model.UniData, error) {
+	versions, err := s.storage.History(sectionName, id)
+	if err != nil {
+		if _, ok := err.(*unimockerrors.NotFoundError); ok {
+			return nil, errors.New("resource not found")
+		}
+		return nil, err
+	}
+	return versions, nil
+}
+
 // handleUpdateError handles various update errors including upsert logic
 func (s *UniService) handleUpdateError(
 	err error, sectionName string, isStrictPath bool, id string, data model.UniData,
@@ -125,7 +189,7 @@ func (s *UniService) handleCreateConflict(
 		return fmt.Errorf("failed to create resource after not found on update: %w", createErr)
 	}
 
-	retryErr := s.storage.Update(sectionName, isStrictPath, id, data)
+	retryErr := s.updateStored(sectionName, isStrictPath, id, data)
 	if retryErr != nil {
 		return fmt.Errorf("failed to retry update after create conflict: %w", retryErr)
 	}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// stagedResource is a resource prepared in a transaction but not yet visible in storage
+type stagedResource struct {
+	sectionName  string
+	isStrictPath bool
+	data         model.UniData
+}
+
+// PrepareResource stages a resource under the given transaction ID without storing it.
+// Staged resources stay invisible to reads until the transaction is committed.
+func (s *UniService) PrepareResource(
+	_ context.Context, txID, sectionName string, isStrictPath bool, ids []string, data model.UniData,
+) error {
+	if txID == "" {
+		return unimockerrors.NewInvalidRequestError("transaction ID is required")
+	}
+	if len(ids) == 0 {
+		return unimockerrors.NewInvalidRequestError("no IDs found in request")
+	}
+	data.IDs = ids
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	s.staged[txID] = append(s.staged[txID], stagedResource{
+		sectionName:  sectionName,
+		isStrictPath: isStrictPath,
+		data:         data,
+	})
+	return nil
+}
+
+// CommitTransaction stores all resources staged under the transaction ID and returns them.
+// If any resource cannot be created, resources created by this commit are removed again.
+func (s *UniService) CommitTransaction(ctx context.Context, txID string) ([]model.UniData, error) {
+	resources, err := s.takeStaged(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make([]model.UniData, 0, len(resources))
+	for i, res := range resources {
+		if err := s.CreateResource(ctx, res.sectionName, res.isStrictPath, res.data.IDs, res.data); err != nil {
+			s.undoCommit(ctx, resources[:i])
+			return nil, fmt.Errorf("failed to commit transaction %s: %w", txID, err)
+		}
+		committed = append(committed, res.data)
+	}
+	return committed, nil
+}
+
+// RollbackTransaction discards all resources staged under the transaction ID
+func (s *UniService) RollbackTransaction(_ context.Context, txID string) error {
+	_, err := s.takeStaged(txID)
+	return err
+}
+
+// takeStaged removes and returns the resources staged under the transaction ID
+func (s *UniService) takeStaged(txID string) ([]stagedResource, error) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	resources, ok := s.staged[txID]
+	if !ok {
+		return nil, unimockerrors.NewNotFoundError(txID, "")
+	}
+	delete(s.staged, txID)
+	return resources, nil
+}
+
+// undoCommit deletes resources created by a failed commit
+func (s *UniService) undoCommit(ctx context.Context, created []stagedResource) {
+	for _, res := range created {
+		_ = s.DeleteResource(ctx, res.sectionName, res.isStrictPath, res.data.IDs[0])
+	}
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"path"
+
+	"github.com/bmcszk/unimock/internal/errors"
+)
+
+// capacity bounds the number of stored resources
+type capacity struct {
+	maxResources int  // 0 means unlimited
+	reject       bool // reject creates when full instead of evicting the oldest resource
+
+	// created maps the creation sequence of every stored resource to its primary composite key.
+	// Sequences only grow, so the oldest resource is found by advancing oldest past the sequences of
+	// resources since deleted, purged or evicted, each of which is skipped once.
+	created map[uint64]string
+	oldest  uint64
+}
+
+// LimitResources caps the number of stored resources at maxResources (0 for unlimited). When the
+// limit is reached, Create evicts the earliest created resource to make room, or fails with a
+// StorageFullError when reject is set. Updates keep a resource's place in the creation order.
+func (s *uniStorage) LimitResources(maxResources int, reject bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capacity.maxResources = maxResources
+	s.capacity.reject = reject
+}
+
+// makeRoomLocked evicts resources, oldest first, until one more fits under the limit, or returns a
+// StorageFullError under the reject policy. Callers must hold the write lock.
+func (s *uniStorage) makeRoomLocked() error {
+	if s.capacity.maxResources <= 0 {
+		return nil
+	}
+	for s.resources >= s.capacity.maxResources {
+		if s.capacity.reject {
+			return errors.NewStorageFullError(s.capacity.maxResources)
+		}
+		if !s.evictOldestLocked() {
+			return nil
+		}
+	}
+	return nil
+}
+
+// evictOldestLocked removes the resource with the lowest creation sequence under all of its
+// composite keys, together with its path mappings and previous versions. It reports whether
+// there was a resource to evict.
+func (s *uniStorage) evictOldestLocked() bool {
+	for ; s.capacity.oldest <= s.seq; s.capacity.oldest++ {
+		if primaryKey, ok := s.capacity.created[s.capacity.oldest]; ok {
+			s.evictLocked(primaryKey)
+			return true
+		}
+	}
+	return false
+}
+
+// evictLocked removes the resource stored under primaryKey. Callers must hold the write lock.
+func (s *uniStorage) evictLocked(primaryKey string) {
+	data := s.data[primaryKey]
+	sectionName := s.sections[primaryKey]
+	if len(data.IDs) > 0 {
+		delete(s.history, historyKey(sectionName, data.IDs[0]))
+		if primaryKey == s.buildStrictCompositeKey(data.Path, data.IDs[0]) {
+			s.removeAllCompositeKeysForResourceStrict(sectionName, data)
+		} else {
+			s.removeAllCompositeKeysForResourceFlexible(sectionName, data)
+		}
+	}
+	s.removeLocked(primaryKey)
+	s.removeCompositeKeyFromPath(primaryKey, data.Path)
+	s.removeCompositeKeyFromPath(primaryKey, data.Location)
+	for _, id := range data.IDs {
+		s.removeCompositeKeyFromPath(primaryKey, path.Join(data.Path, id))
+	}
+}
@@ -0,0 +1,96 @@
+package storage_test
+
+import (
+	"testing"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createItem(t *testing.T, store storage.UniStorage, ids ...string) error {
+	t.Helper()
+	return store.Create("items", false, model.UniData{Path: "/items", IDs: ids, Body: []byte(ids[0])})
+}
+
+func TestUniStorage_LimitResources_EvictsOldest(t *testing.T) {
+	store := storage.NewUniStorage()
+	store.LimitResources(2, false)
+
+	require.NoError(t, createItem(t, store, "1", "one"))
+	require.NoError(t, createItem(t, store, "2"))
+	require.NoError(t, store.Update("items", false, "1", model.UniData{Path: "/items", Body: []byte("1b")}))
+	require.NoError(t, createItem(t, store, "3"))
+
+	_, err := store.Get("items", false, "one")
+	assert.Error(t, err, "the oldest resource is evicted under all of its IDs despite the later update")
+	_, err = store.Get("items", false, "2")
+	assert.NoError(t, err)
+	_, err = store.Get("items", false, "3")
+	assert.NoError(t, err)
+
+	remaining, err := store.GetByPath("/items")
+	require.NoError(t, err)
+	assert.Len(t, remaining, 2)
+	assert.Equal(t, 2, store.Stats().Resources)
+}
+
+func TestUniStorage_LimitResources_Reject(t *testing.T) {
+	store := storage.NewUniStorage()
+	store.LimitResources(1, true)
+
+	require.NoError(t, createItem(t, store, "1"))
+	err := createItem(t, store, "2")
+
+	var full *unimockerrors.StorageFullError
+	require.ErrorAs(t, err, &full)
+	assert.Equal(t, 1, full.Limit)
+	require.NoError(t, store.Update("items", false, "1", model.UniData{Path: "/items", Body: []byte("1b")}),
+		"updates of stored resources are not limited")
+
+	require.NoError(t, store.Delete("items", false, "1"))
+	assert.NoError(t, createItem(t, store, "2"), "deleting makes room again")
+}
+
+func TestUniStorage_LimitResources_EvictionDropsHistory(t *testing.T) {
+	store := storage.NewUniStorage()
+	store.LimitResources(1, false)
+	order := func(body string) model.UniData {
+		return model.UniData{Path: "/orders", IDs: []string{"1"}, Body: []byte(body)}
+	}
+
+	require.NoError(t, store.Create("orders", true, order("a")))
+	require.NoError(t, store.UpdateWithHistory("orders", true, "1", order("b")))
+	require.NoError(t, store.Create("orders", true, model.UniData{Path: "/orders", IDs: []string{"2"}}))
+	require.NoError(t, store.Create("orders", true, order("c")))
+
+	versions, err := store.History("orders", "1")
+	require.NoError(t, err)
+	assert.Empty(t, versions, "the history of the evicted strict_path resource is gone")
+	assert.Equal(t, 1, store.Stats().Resources)
+}
+
+func TestUniStorage_LimitResources_EvictsMovedAndSkipsDeleted(t *testing.T) {
+	store := storage.NewUniStorage()
+	store.LimitResources(2, false)
+
+	require.NoError(t, store.Create("orders", true, model.UniData{Path: "/orders", IDs: []string{"1"}}))
+	require.NoError(t, createItem(t, store, "2"))
+	require.NoError(t, store.Delete("items", false, "2"))
+	require.NoError(t, createItem(t, store, "3"))
+	require.NoError(t, store.Update("orders", true, "1", model.UniData{Path: "/archive", Body: []byte("moved")}))
+	require.NoError(t, createItem(t, store, "4"))
+
+	_, err := store.GetByPath("/archive")
+	assert.Error(t, err, "the oldest resource is evicted after moving to another path")
+	remaining, err := store.GetByPath("/items")
+	require.NoError(t, err)
+	assert.Len(t, remaining, 2)
+
+	require.NoError(t, createItem(t, store, "5"))
+	_, err = store.Get("items", false, "3")
+	assert.Error(t, err, "the deleted resource is skipped and the next oldest evicted")
+	assert.Equal(t, 2, store.Stats().Resources)
+}
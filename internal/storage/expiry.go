@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"container/heap"
+	"path"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// expiryEntry records when the entry stored under key expires
+type expiryEntry struct {
+	at  time.Time
+	key string
+}
+
+// expiryQueue is a min-heap of expiry entries, earliest first. Entries of keys since deleted or
+// rewritten with another expiry are left in place and skipped when they come up.
+type expiryQueue []expiryEntry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *expiryQueue) Push(x any) { *q = append(*q, x.(expiryEntry)) }
+
+func (q *expiryQueue) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// putLocked stores data under compositeKey in the given section, counting and tracking it for
+// eviction if it is a resource's primary key and queueing its expiry if it has one. Callers must
+// hold the write lock.
+func (s *uniStorage) putLocked(sectionName, compositeKey string, data model.UniData) {
+	s.removeLocked(compositeKey)
+	if isPrimaryKey(compositeKey, data) {
+		s.resources++
+		s.capacity.created[data.Seq] = compositeKey
+	}
+	s.data[compositeKey] = data
+	s.sections[compositeKey] = sectionName
+	if !data.ExpiresAt.IsZero() {
+		heap.Push(&s.expiries, expiryEntry{at: data.ExpiresAt, key: compositeKey})
+	}
+}
+
+// removeLocked removes the data stored under compositeKey, uncounting it if it is a resource's primary key.
+// Callers must hold the write lock.
+func (s *uniStorage) removeLocked(compositeKey string) {
+	if old, ok := s.data[compositeKey]; ok && isPrimaryKey(compositeKey, old) {
+		s.resources--
+		delete(s.capacity.created, old.Seq)
+	}
+	delete(s.data, compositeKey)
+	delete(s.sections, compositeKey)
+}
+
+// PurgeExpired removes resources whose expiry has passed and returns the number of removed entries
+func (s *uniStorage) PurgeExpired(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.purgeExpiredLocked(now)
+}
+
+// purgeExpiredLocked removes expired resources together with their path mappings and previous
+// versions. Only entries whose expiry has passed are visited, so without TTLs it does nothing.
+// Callers must hold the write lock.
+func (s *uniStorage) purgeExpiredLocked(now time.Time) int {
+	purged := 0
+	for len(s.expiries) > 0 && !now.Before(s.expiries[0].at) {
+		entry := heap.Pop(&s.expiries).(expiryEntry)
+		data, ok := s.data[entry.key]
+		if !ok || !data.ExpiresAt.Equal(entry.at) {
+			continue
+		}
+		if len(data.IDs) > 0 {
+			delete(s.history, historyKey(s.sections[entry.key], data.IDs[0]))
+		}
+		s.removeLocked(entry.key)
+		s.removeCompositeKeyFromPath(entry.key, data.Path)
+		for _, id := range data.IDs {
+			s.removeCompositeKeyFromPath(entry.key, path.Join(data.Path, id))
+		}
+		purged++
+	}
+	return purged
+}
@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniStorage_ExpiredResourcesAreNotFound(t *testing.T) {
+	store := storage.NewUniStorage()
+	expired := model.UniData{
+		Path: "/sessions", IDs: []string{"s1"}, Body: []byte(`{"id":"s1"}`),
+		ExpiresAt: time.Now().Add(-time.Second),
+	}
+	require.NoError(t, store.Create("sessions", false, expired))
+
+	_, err := store.Get("sessions", false, "s1")
+	assert.Error(t, err)
+	_, err = store.GetByPath("/sessions")
+	assert.Error(t, err)
+	assert.Error(t, store.Update("sessions", false, "s1", expired))
+	assert.Error(t, store.Delete("sessions", false, "s1"))
+
+	// The expired entry no longer blocks its ID
+	require.NoError(t, store.Create("sessions", false, model.UniData{
+		Path: "/sessions", IDs: []string{"s1"}, Body: []byte(`{"id":"s1","renewed":true}`),
+	}))
+	data, err := store.Get("sessions", false, "s1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"s1","renewed":true}`, string(data.Body))
+}
+
+func TestUniStorage_PurgeExpired(t *testing.T) {
+	store := storage.NewUniStorage()
+	now := time.Now()
+	require.NoError(t, store.Create("sessions", false, model.UniData{
+		Path: "/sessions", IDs: []string{"short"}, Body: []byte("a"), ExpiresAt: now.Add(time.Minute),
+	}))
+	require.NoError(t, store.Create("sessions", false, model.UniData{
+		Path: "/sessions", IDs: []string{"forever"}, Body: []byte("b"),
+	}))
+
+	assert.Equal(t, 0, store.PurgeExpired(now))
+	assert.Equal(t, 1, store.PurgeExpired(now.Add(time.Hour)))
+
+	remaining, err := store.GetByPath("/sessions")
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, []byte("b"), remaining[0].Body)
+}
+
+func TestUniStorage_PurgeExpiredDropsHistory(t *testing.T) {
+	store := storage.NewUniStorage()
+	expiresAt := time.Now().Add(time.Minute)
+	session := func(body string) model.UniData {
+		return model.UniData{Path: "/sessions", IDs: []string{"s1"}, Body: []byte(body), ExpiresAt: expiresAt}
+	}
+	require.NoError(t, store.Create("sessions", true, session("a")))
+	require.NoError(t, store.UpdateWithHistory("sessions", true, "s1", session("b")))
+
+	assert.Equal(t, 1, store.PurgeExpired(expiresAt))
+
+	require.NoError(t, store.Create("sessions", true, model.UniData{
+		Path: "/sessions", IDs: []string{"s1"}, Body: []byte("c"),
+	}))
+	versions, err := store.History("sessions", "s1")
+	require.NoError(t, err)
+	assert.Empty(t, versions, "a resource created again does not inherit the purged one's history")
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// UpdateWithHistory updates like Update and keeps the replaced data as a previous version
+func (s *uniStorage) UpdateWithHistory(sectionName string, isStrictPath bool, id string, data model.UniData) error {
+	return s.update(sectionName, isStrictPath, id, data, true, anyVersion)
+}
+
+// History returns the previous versions of a resource kept by UpdateWithHistory, oldest first
+func (s *uniStorage) History(sectionName string, id string) ([]model.UniData, error) {
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	current, _, err := s.findResourceForUpdate(sectionName, id, false)
+	if err != nil || current.IsExpired(time.Now()) {
+		return nil, errors.NewNotFoundError(id, sectionName)
+	}
+	versions := s.history[historyKey(sectionName, current.IDs[0])]
+	return append([]model.UniData(nil), versions...), nil
+}
+
+// historyKey builds the key of a resource's previous versions from its section and primary ID
+func historyKey(sectionName, primaryID string) string {
+	return sectionName + keySeparator + primaryID
+}
+
+// update updates existing data, optionally keeping the replaced data as a previous version. Unless
+// expectedVersion is anyVersion, the update fails with a VersionConflictError when the resource is at
+// another version.
+func (s *uniStorage) update(
+	sectionName string, isStrictPath bool, id string, data model.UniData, keepHistory bool, expectedVersion int,
+) error {
+	if err := s.validateID(id); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
+
+	// Find the appropriate resource to update
+	oldData, useStrictMode, err := s.findResourceForUpdate(sectionName, id, isStrictPath)
+	if err != nil {
+		return err
+	}
+	if expectedVersion != anyVersion && oldData.Version != expectedVersion {
+		return errors.NewVersionConflictError(id, expectedVersion, oldData.Version)
+	}
+
+	if keepHistory {
+		key := historyKey(sectionName, oldData.IDs[0])
+		s.history[key] = append(s.history[key], oldData)
+	}
+
+	// Perform the update operation
+	if useStrictMode {
+		s.performResourceUpdateStrict(sectionName, id, data, oldData)
+	} else {
+		s.performResourceUpdateFlexible(sectionName, id, data, oldData)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator produces IDs for resources created without one
+type IDGenerator interface {
+	NewID() string
+}
+
+// randomIDGenerator generates random UUIDs
+type randomIDGenerator struct{}
+
+// NewRandomIDGenerator creates a generator of random UUIDs (the default)
+func NewRandomIDGenerator() IDGenerator {
+	return randomIDGenerator{}
+}
+
+// NewID returns a random UUID
+func (randomIDGenerator) NewID() string {
+	return uuid.New().String()
+}
+
+// sequentialIDGenerator generates UUID-formatted IDs from a counter for reproducible tests
+type sequentialIDGenerator struct {
+	counter atomic.Uint64
+}
+
+// NewSequentialIDGenerator creates a generator of deterministic UUID-formatted IDs.
+// The first ID is seed+1, e.g. 00000000-0000-0000-0000-000000000001 for seed 0.
+func NewSequentialIDGenerator(seed uint64) IDGenerator {
+	gen := &sequentialIDGenerator{}
+	gen.counter.Store(seed)
+	return gen
+}
+
+// NewID returns the next ID of the sequence
+func (g *sequentialIDGenerator) NewID() string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", g.counter.Add(1))
+}
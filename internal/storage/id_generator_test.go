@@ -0,0 +1,29 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequentialIDGenerator(t *testing.T) {
+	gen := storage.NewSequentialIDGenerator(0)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", gen.NewID())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", gen.NewID())
+
+	seeded := storage.NewSequentialIDGenerator(41)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000042", seeded.NewID())
+}
+
+func TestUniStorage_CreateWithoutIDUsesGenerator(t *testing.T) {
+	store := storage.NewUniStorageWithIDGenerator(storage.NewSequentialIDGenerator(0))
+
+	for _, want := range []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"} {
+		require.NoError(t, store.Create("files", false, model.UniData{Path: "/files", Body: []byte("x")}))
+		_, err := store.Get("files", false, want)
+		assert.NoError(t, err)
+	}
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/bmcszk/unimock/internal/errors"
@@ -18,13 +19,19 @@ type ScenarioStorage interface {
 	Get(id string) (model.Scenario, error)
 	Update(id string, scenario model.Scenario) error
 	Delete(id string) error
+	// List returns all scenarios in creation order
 	List() []model.Scenario
+	// Count returns the number of stored scenarios
+	Count() int
+	// Apply stores all scenarios at once, dropping every other scenario when replace is set
+	Apply(scenarios []model.Scenario, replace bool) error
 }
 
 // scenarioStorage implements the ScenarioStorage interface
 type scenarioStorage struct {
 	mu        *sync.RWMutex
 	scenarios map[string]model.Scenario
+	order     []string // scenario IDs in creation order
 }
 
 // NewScenarioStorage creates a new instance of ScenarioStorage
@@ -50,6 +57,7 @@ func (s *scenarioStorage) Create(id string, scenario model.Scenario) error {
 
 	// Store the scenario
 	s.scenarios[id] = scenario
+	s.order = append(s.order, id)
 
 	return nil
 }
@@ -104,18 +112,54 @@ func (s *scenarioStorage) Delete(id string) error {
 
 	// Remove scenario
 	delete(s.scenarios, id)
+	s.order = slices.DeleteFunc(s.order, func(orderedID string) bool { return orderedID == id })
 
 	return nil
 }
 
+// List returns all scenarios in creation order
 func (s *scenarioStorage) List() []model.Scenario {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	scenarios := make([]model.Scenario, 0, len(s.scenarios))
-	for _, scenario := range s.scenarios {
-		scenarios = append(scenarios, scenario)
+	scenarios := make([]model.Scenario, 0, len(s.order))
+	for _, id := range s.order {
+		scenarios = append(scenarios, s.scenarios[id])
 	}
 
 	return scenarios
 }
+
+// Count returns the number of stored scenarios
+func (s *scenarioStorage) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.scenarios)
+}
+
+// Apply stores all scenarios under one lock, so readers see either the old or the new set.
+// A scenario whose ID exists replaces that one in place; the others are appended in order.
+func (s *scenarioStorage) Apply(scenarios []model.Scenario, replace bool) error {
+	for _, scenario := range scenarios {
+		if scenario.UUID == "" {
+			return errors.NewInvalidRequestError(errScenarioIDEmpty)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if replace {
+		s.scenarios = make(map[string]model.Scenario, len(scenarios))
+		s.order = nil
+	}
+	for _, scenario := range scenarios {
+		if _, exists := s.scenarios[scenario.UUID]; !exists {
+			s.order = append(s.order, scenario.UUID)
+		}
+		s.scenarios[scenario.UUID] = scenario
+	}
+
+	return nil
+}
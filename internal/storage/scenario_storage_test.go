@@ -1,6 +1,7 @@
 package storage_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/bmcszk/unimock/internal/storage"
@@ -153,3 +154,86 @@ func TestScenarioStorage_ErrorCases(t *testing.T) {
 		t.Error("Expected error when creating scenario with empty ID, got nil")
 	}
 }
+
+func TestScenarioStorage_ListInCreationOrder(t *testing.T) {
+	storageInstance := storage.NewScenarioStorage()
+	for _, id := range []string{"c", "a", "d", "b"} {
+		if err := storageInstance.Create(id, model.Scenario{UUID: id, RequestPath: "GET /" + id}); err != nil {
+			t.Fatalf("Failed to create scenario %s: %v", id, err)
+		}
+	}
+	if err := storageInstance.Update("a", model.Scenario{UUID: "a", RequestPath: "GET /updated"}); err != nil {
+		t.Fatalf("Failed to update scenario: %v", err)
+	}
+	if err := storageInstance.Delete("d"); err != nil {
+		t.Fatalf("Failed to delete scenario: %v", err)
+	}
+
+	var ids []string
+	for _, scenario := range storageInstance.List() {
+		ids = append(ids, scenario.UUID)
+	}
+	if strings.Join(ids, ",") != "c,a,b" {
+		t.Errorf("Expected scenarios in creation order c,a,b, got %v", ids)
+	}
+}
+
+func TestScenarioStorage_Count(t *testing.T) {
+	storageInstance := storage.NewScenarioStorage()
+	for _, id := range []string{"a", "b"} {
+		if err := storageInstance.Create(id, model.Scenario{UUID: id, RequestPath: "GET /" + id}); err != nil {
+			t.Fatalf("Failed to create scenario %s: %v", id, err)
+		}
+	}
+	if err := storageInstance.Delete("a"); err != nil {
+		t.Fatalf("Failed to delete scenario: %v", err)
+	}
+
+	if got := storageInstance.Count(); got != 1 {
+		t.Errorf("Expected 1 scenario, got %d", got)
+	}
+}
+
+func TestScenarioStorage_Apply(t *testing.T) {
+	storageInstance := storage.NewScenarioStorage()
+	for _, id := range []string{"a", "b"} {
+		if err := storageInstance.Create(id, model.Scenario{UUID: id, RequestPath: "GET /" + id}); err != nil {
+			t.Fatalf("Failed to create scenario %s: %v", id, err)
+		}
+	}
+	listIDs := func() string {
+		var ids []string
+		for _, scenario := range storageInstance.List() {
+			ids = append(ids, scenario.UUID+" "+scenario.RequestPath)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// A scenario without ID fails the whole set and leaves the stored scenarios untouched
+	err := storageInstance.Apply([]model.Scenario{{UUID: "c"}, {RequestPath: "GET /none"}}, true)
+	if err == nil {
+		t.Error("Expected error when applying scenario with empty ID, got nil")
+	}
+	if got := listIDs(); got != "a GET /a,b GET /b" {
+		t.Errorf("Expected scenarios untouched, got %v", got)
+	}
+
+	// Merging replaces scenarios of the same ID in place and appends the others
+	err = storageInstance.Apply([]model.Scenario{
+		{UUID: "c", RequestPath: "GET /c"}, {UUID: "a", RequestPath: "GET /merged"},
+	}, false)
+	if err != nil {
+		t.Fatalf("Failed to merge scenarios: %v", err)
+	}
+	if got := listIDs(); got != "a GET /merged,b GET /b,c GET /c" {
+		t.Errorf("Expected merged scenarios, got %v", got)
+	}
+
+	// Replacing drops every other scenario
+	if err := storageInstance.Apply([]model.Scenario{{UUID: "d", RequestPath: "GET /d"}}, true); err != nil {
+		t.Fatalf("Failed to replace scenarios: %v", err)
+	}
+	if got := listIDs(); got != "d GET /d" {
+		t.Errorf("Expected replaced scenarios, got %v", got)
+	}
+}
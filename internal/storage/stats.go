@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// Stats summarizes the resources held by a UniStorage
+type Stats struct {
+	// Resources is the number of stored resources; a resource reachable by several IDs counts once
+	Resources int
+	// ResourcesByPath counts the resources by the collection path they were stored under
+	ResourcesByPath map[string]int
+	// Bytes approximates the memory taken by the resources and their previous versions
+	Bytes int64
+}
+
+// Stats counts the stored resources that have not expired and estimates their memory usage
+func (s *uniStorage) Stats() Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := Stats{ResourcesByPath: make(map[string]int)}
+	now := time.Now()
+	for compositeKey, data := range s.data {
+		if data.IsExpired(now) || !isPrimaryKey(compositeKey, data) {
+			continue
+		}
+		stats.Resources++
+		stats.ResourcesByPath[data.Path]++
+		stats.Bytes += dataSize(data)
+	}
+	for _, versions := range s.history {
+		for _, version := range versions {
+			stats.Bytes += dataSize(version)
+		}
+	}
+	return stats
+}
+
+// isPrimaryKey reports whether the composite key is the one built from the resource's first ID,
+// as a resource is stored once under every one of its IDs
+func isPrimaryKey(compositeKey string, data model.UniData) bool {
+	return len(data.IDs) == 0 || strings.HasSuffix(compositeKey, keySeparator+data.IDs[0])
+}
+
+// dataSize approximates the bytes held by a resource: its struct plus the strings and body it references
+func dataSize(data model.UniData) int64 {
+	size := int64(unsafe.Sizeof(data)) + int64(len(data.Path)+len(data.Location)+len(data.ContentType)+cap(data.Body))
+	for _, id := range data.IDs {
+		size += int64(unsafe.Sizeof(id)) + int64(len(id))
+	}
+	return size
+}
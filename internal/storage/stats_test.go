@@ -0,0 +1,41 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniStorage_Stats(t *testing.T) {
+	store := storage.NewUniStorage()
+	assert.Equal(t, storage.Stats{ResourcesByPath: map[string]int{}}, store.Stats())
+
+	require.NoError(t, store.Create("users", false, model.UniData{
+		Path: "/users", IDs: []string{"1", "ann@example.com"}, Body: []byte(`{"id":"1"}`),
+	}))
+	require.NoError(t, store.Create("users", false, model.UniData{
+		Path: "/users", IDs: []string{"2"}, Body: []byte(`{"id":"2"}`),
+	}))
+	require.NoError(t, store.Create("orders", true, model.UniData{
+		Path: "/users/1/orders", IDs: []string{"9"}, Body: []byte(`{"id":"9"}`),
+	}))
+	require.NoError(t, store.Create("sessions", false, model.UniData{
+		Path: "/sessions", IDs: []string{"s1"}, ExpiresAt: time.Now().Add(-time.Second),
+	}))
+
+	stats := store.Stats()
+	assert.Equal(t, 3, stats.Resources, "a resource with several IDs counts once, expired ones not at all")
+	assert.Equal(t, map[string]int{"/users": 2, "/users/1/orders": 1}, stats.ResourcesByPath)
+	assert.Positive(t, stats.Bytes)
+
+	require.NoError(t, store.UpdateWithHistory("users", false, "2", model.UniData{
+		Path: "/users", IDs: []string{"2"}, Body: []byte(`{"id":"2","name":"Bob"}`),
+	}))
+	updated := store.Stats()
+	assert.Equal(t, 3, updated.Resources)
+	assert.Greater(t, updated.Bytes, stats.Bytes, "previous versions take memory too")
+}
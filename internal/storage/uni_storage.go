@@ -4,11 +4,10 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bmcszk/unimock/internal/errors"
 	"github.com/bmcszk/unimock/pkg/model"
-
-	"github.com/google/uuid"
 )
 
 const (
@@ -22,6 +21,16 @@ const (
 type UniStorage interface {
 	Create(sectionName string, isStrictPath bool, data model.UniData) error
 	Update(sectionName string, isStrictPath bool, id string, data model.UniData) error
+	// UpdateWithHistory updates like Update and keeps the replaced data as a previous version
+	UpdateWithHistory(sectionName string, isStrictPath bool, id string, data model.UniData) error
+	// UpdateIfVersion updates like Update only while the resource is at expectedVersion
+	UpdateIfVersion(sectionName string, isStrictPath bool, id string, expectedVersion int, data model.UniData) error
+	// UpdateIfVersionWithHistory updates like UpdateIfVersion and keeps the replaced data as a previous version
+	UpdateIfVersionWithHistory(
+		sectionName string, isStrictPath bool, id string, expectedVersion int, data model.UniData,
+	) error
+	// History returns the previous versions of a resource kept by UpdateWithHistory, oldest first
+	History(sectionName string, id string) ([]model.UniData, error)
 	Get(sectionName string, isStrictPath bool, id string) (model.UniData, error)
 	GetByPath(requestPath string) ([]model.UniData, error)
 	Delete(sectionName string, isStrictPath bool, id string) error
@@ -34,24 +43,63 @@ type UniStorage interface {
 	GetFlexible(sectionName string, id string) (model.UniData, error)
 	DeleteStrict(sectionName string, id string) error
 	DeleteFlexible(sectionName string, id string) error
+
+	// NewID generates an ID for a resource created without one
+	NewID() string
+
+	// PurgeExpired removes resources whose expiry has passed and returns the number of removed entries
+	PurgeExpired(now time.Time) int
+
+	// Stats counts the stored resources and estimates their memory usage
+	Stats() Stats
+
+	// LimitResources caps the number of stored resources, evicting the oldest or rejecting creates
+	LimitResources(maxResources int, reject bool)
 }
 
 // uniStorage implements the Storage interface
 type uniStorage struct {
 	mu      *sync.RWMutex
-	data    map[string]model.UniData // compositeKey -> data
-	pathMap map[string][]string      // path -> []compositeKey
+	data    map[string]model.UniData   // compositeKey -> data
+	pathMap map[string][]string        // path -> []compositeKey
+	history map[string][]model.UniData // section:primaryID -> previous versions, oldest first
+	seq     uint64                     // creation sequence of the most recently created resource
+	idGen   IDGenerator
+
+	// sections maps every compositeKey to the section it is stored in, for dropping the history of
+	// purged and evicted resources
+	sections map[string]string
+	expiries expiryQueue // expiry times of stored entries, earliest first
+
+	resources int // stored resources, counted once however many IDs they have
+
+	capacity capacity
 }
 
-// NewUniStorage creates a new instance of storage
+// NewUniStorage creates a new instance of storage generating random UUIDs
 func NewUniStorage() UniStorage {
+	return NewUniStorageWithIDGenerator(NewRandomIDGenerator())
+}
+
+// NewUniStorageWithIDGenerator creates a new instance of storage using idGen for generated IDs
+func NewUniStorageWithIDGenerator(idGen IDGenerator) UniStorage {
 	return &uniStorage{
 		mu:      &sync.RWMutex{},
 		data:    make(map[string]model.UniData),
 		pathMap: make(map[string][]string),
+		history: make(map[string][]model.UniData),
+		idGen:   idGen,
+
+		sections: make(map[string]string),
+		capacity: capacity{created: make(map[uint64]string)},
 	}
 }
 
+// NewID generates an ID for a resource created without one
+func (s *uniStorage) NewID() string {
+	return s.idGen.NewID()
+}
+
 // validateID checks if the ID is valid
 func (*uniStorage) validateID(id string) error {
 	if id == "" {
@@ -93,15 +141,22 @@ func (*uniStorage) extractIDFromCompositeKey(compositeKey string) string {
 func (s *uniStorage) Create(sectionName string, isStrictPath bool, data model.UniData) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Validate IDs and check for conflicts based on strict_path mode
 	effectiveIDs, err := s.prepareIDsWithConflictCheck(sectionName, isStrictPath, data)
 	if err != nil {
 		return err
 	}
+	if err := s.makeRoomLocked(); err != nil {
+		return err
+	}
 
 	// Prepare data for storage
 	finalIDs := s.prepareDataForStorage(effectiveIDs, &data)
+	s.seq++
+	data.Seq = s.seq
+	data.Version = 1
 
 	// Store the data with composite keys
 	s.storeDataWithCompositeKeys(sectionName, isStrictPath, finalIDs, data)
@@ -128,16 +183,18 @@ func (s *uniStorage) prepareIDsWithConflictCheck(
 }
 
 // prepareDataForStorage sets up data location and handles ID generation
-func (*uniStorage) prepareDataForStorage(effectiveIDs []string, data *model.UniData) []string {
+func (s *uniStorage) prepareDataForStorage(effectiveIDs []string, data *model.UniData) []string {
 	// Ensure path doesn't have trailing slash
 	data.Path = strings.TrimRight(data.Path, pathSeparator)
 
-	// Set location based on path and first ID
+	// Set location based on path and first ID, keeping a location already set by the caller
 	if len(effectiveIDs) > 0 {
-		data.Location = data.Path + pathSeparator + effectiveIDs[0]
+		if data.Location == "" {
+			data.Location = data.Path + pathSeparator + effectiveIDs[0]
+		}
 	} else {
 		// Generate UUID for path-based storage
-		generatedID := uuid.New().String()
+		generatedID := s.idGen.NewID()
 		data.Location = data.Path + pathSeparator + generatedID
 		effectiveIDs = []string{generatedID}
 	}
@@ -154,13 +211,13 @@ func (s *uniStorage) storeDataWithCompositeKeys(
 ) {
 	// Store data using the primary composite key (first ID)
 	primaryCompositeKey := s.buildCompositeKey(sectionName, isStrictPath, data.Path, effectiveIDs[0])
-	s.data[primaryCompositeKey] = data
+	s.putLocked(sectionName, primaryCompositeKey, data)
 
 	// For multiple IDs, all should point to the same data entry
 	// We achieve this by having all composite keys reference the same data object
 	for _, id := range effectiveIDs {
 		compositeKey := s.buildCompositeKey(sectionName, isStrictPath, data.Path, id)
-		s.data[compositeKey] = data
+		s.putLocked(sectionName, compositeKey, data)
 	}
 
 	// Update pathMap for path-based lookups using primary key
@@ -173,16 +230,16 @@ func (s *uniStorage) storeDataWithCompositeKeys(
 
 // storeDataWithCompositeKeysStrict stores the data using strict composite keys
 func (s *uniStorage) storeDataWithCompositeKeysStrict(
-	_ string, effectiveIDs []string, data model.UniData,
+	sectionName string, effectiveIDs []string, data model.UniData,
 ) {
 	// Store data using the primary composite key (first ID)
 	primaryCompositeKey := s.buildStrictCompositeKey(data.Path, effectiveIDs[0])
-	s.data[primaryCompositeKey] = data
+	s.putLocked(sectionName, primaryCompositeKey, data)
 
 	// For multiple IDs, all should point to the same data entry
 	for _, id := range effectiveIDs {
 		compositeKey := s.buildStrictCompositeKey(data.Path, id)
-		s.data[compositeKey] = data
+		s.putLocked(sectionName, compositeKey, data)
 	}
 
 	// Update pathMap for path-based lookups using primary key
@@ -199,12 +256,12 @@ func (s *uniStorage) storeDataWithCompositeKeysFlexible(
 ) {
 	// Store data using the primary composite key (first ID)
 	primaryCompositeKey := s.buildNonStrictCompositeKey(sectionName, effectiveIDs[0])
-	s.data[primaryCompositeKey] = data
+	s.putLocked(sectionName, primaryCompositeKey, data)
 
 	// For multiple IDs, all should point to the same data entry
 	for _, id := range effectiveIDs {
 		compositeKey := s.buildNonStrictCompositeKey(sectionName, id)
-		s.data[compositeKey] = data
+		s.putLocked(sectionName, compositeKey, data)
 	}
 
 	// Update pathMap for path-based lookups using primary key
@@ -223,6 +280,7 @@ func (s *uniStorage) UpdateStrict(sectionName string, id string, data model.UniD
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Find the existing data
 	oldData, err := s.findExistingDataOnlyStrict(sectionName, id)
@@ -230,8 +288,10 @@ func (s *uniStorage) UpdateStrict(sectionName string, id string, data model.UniD
 		return err
 	}
 
-	// Preserve original IDs from the old data
+	// Preserve original IDs and creation sequence from the old data and advance the version
 	data.IDs = oldData.IDs
+	data.Seq = oldData.Seq
+	data.Version = oldData.Version + 1
 	data.Location = data.Path + pathSeparator + data.IDs[0]
 	data.Path = strings.TrimRight(data.Path, pathSeparator)
 
@@ -259,6 +319,7 @@ func (s *uniStorage) UpdateFlexible(sectionName string, id string, data model.Un
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Find the existing data
 	oldData, err := s.findExistingDataOnlyFlexible(sectionName, id)
@@ -266,8 +327,10 @@ func (s *uniStorage) UpdateFlexible(sectionName string, id string, data model.Un
 		return err
 	}
 
-	// Preserve original IDs from the old data
+	// Preserve original IDs and creation sequence from the old data and advance the version
 	data.IDs = oldData.IDs
+	data.Seq = oldData.Seq
+	data.Version = oldData.Version + 1
 	data.Location = data.Path + pathSeparator + data.IDs[0]
 	data.Path = strings.TrimRight(data.Path, pathSeparator)
 
@@ -309,8 +372,10 @@ func (s *uniStorage) findResourceForUpdate(
 func (s *uniStorage) performResourceUpdateStrict(
 	sectionName string, _ string, data, oldData model.UniData,
 ) {
-	// Preserve original IDs from the old data
+	// Preserve original IDs and creation sequence from the old data and advance the version
 	data.IDs = oldData.IDs
+	data.Seq = oldData.Seq
+	data.Version = oldData.Version + 1
 	data.Location = data.Path + pathSeparator + data.IDs[0]
 	data.Path = strings.TrimRight(data.Path, pathSeparator)
 
@@ -330,8 +395,10 @@ func (s *uniStorage) performResourceUpdateStrict(
 func (s *uniStorage) performResourceUpdateFlexible(
 	sectionName string, _ string, data, oldData model.UniData,
 ) {
-	// Preserve original IDs from the old data
+	// Preserve original IDs and creation sequence from the old data and advance the version
 	data.IDs = oldData.IDs
+	data.Seq = oldData.Seq
+	data.Version = oldData.Version + 1
 	data.Location = data.Path + pathSeparator + data.IDs[0]
 	data.Path = strings.TrimRight(data.Path, pathSeparator)
 
@@ -349,26 +416,7 @@ func (s *uniStorage) performResourceUpdateFlexible(
 
 // Update updates existing data with path validation scope control
 func (s *uniStorage) Update(sectionName string, isStrictPath bool, id string, data model.UniData) error {
-	if err := s.validateID(id); err != nil {
-		return err
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Find the appropriate resource to update
-	oldData, useStrictMode, err := s.findResourceForUpdate(sectionName, id, isStrictPath)
-	if err != nil {
-		return err
-	}
-
-	// Perform the update operation
-	if useStrictMode {
-		s.performResourceUpdateStrict(sectionName, id, data, oldData)
-	} else {
-		s.performResourceUpdateFlexible(sectionName, id, data, oldData)
-	}
-	return nil
+	return s.update(sectionName, isStrictPath, id, data, false, anyVersion)
 }
 
 // findExistingResourceStrict finds an existing resource by ID within strict path scope
@@ -519,10 +567,11 @@ func (*uniStorage) checkResourceMatch(
 func (s *uniStorage) findMatchingResources(
 	sectionName, id string,
 ) (strictMatches []model.UniData, flexibleMatches []model.UniData) {
-	// Collect all matching resources from both modes
+	// Collect all unexpired matching resources from both modes
+	now := time.Now()
 	for compositeKey, data := range s.data {
 		keyID := s.extractIDFromCompositeKey(compositeKey)
-		if keyID != id {
+		if keyID != id || data.IsExpired(now) {
 			continue
 		}
 
@@ -648,7 +697,7 @@ func (s *uniStorage) getExactPathMatches(requestPath string) []model.UniData {
 
 	if compositeKeys, ok := s.pathMap[requestPath]; ok {
 		for _, key := range compositeKeys {
-			if data, exists := s.data[key]; exists && !seen[key] {
+			if data, exists := s.data[key]; exists && !seen[key] && !data.IsExpired(time.Now()) {
 				seen[key] = true
 				result = append(result, data)
 			}
@@ -677,7 +726,7 @@ func (s *uniStorage) addKeysToResult(
 	result []model.UniData, seen map[string]bool, compositeKeys []string,
 ) []model.UniData {
 	for _, key := range compositeKeys {
-		if data, exists := s.data[key]; exists && !seen[key] {
+		if data, exists := s.data[key]; exists && !seen[key] && !data.IsExpired(time.Now()) {
 			seen[key] = true
 			result = append(result, data)
 		}
@@ -693,6 +742,7 @@ func (s *uniStorage) DeleteStrict(_ string, id string) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Find the existing resource
 	mockData, err := s.findExistingDataOnlyStrict("", id)
@@ -721,6 +771,7 @@ func (s *uniStorage) DeleteFlexible(sectionName string, id string) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Find the existing resource
 	mockData, err := s.findExistingDataOnlyFlexible(sectionName, id)
@@ -849,6 +900,8 @@ func (s *uniStorage) performResourceDeletion(sectionName string, resourcesToDele
 	isStrict bool
 }) {
 	for _, resource := range resourcesToDelete {
+		delete(s.history, historyKey(sectionName, resource.data.IDs[0]))
+
 		// Remove composite keys based on where the resource was found
 		if resource.isStrict {
 			s.removeAllCompositeKeysForResourceStrict(sectionName, resource.data)
@@ -879,6 +932,7 @@ func (s *uniStorage) Delete(sectionName string, isStrictPath bool, id string) er
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.purgeExpiredLocked(time.Now())
 
 	// Find all matching resources
 	matches, err := s.findResourcesForDelete(sectionName, id)
@@ -901,7 +955,7 @@ func (s *uniStorage) removeAllCompositeKeysForResourceStrict(
 ) {
 	for _, resourceID := range mockData.IDs {
 		compositeKey := s.buildStrictCompositeKey(mockData.Path, resourceID)
-		delete(s.data, compositeKey)
+		s.removeLocked(compositeKey)
 	}
 }
 
@@ -911,7 +965,7 @@ func (s *uniStorage) removeAllCompositeKeysForResourceFlexible(
 ) {
 	for _, resourceID := range mockData.IDs {
 		compositeKey := s.buildNonStrictCompositeKey(sectionName, resourceID)
-		delete(s.data, compositeKey)
+		s.removeLocked(compositeKey)
 	}
 }
 
@@ -925,7 +979,11 @@ func (s *uniStorage) ForEach(fn func(id string, data model.UniData) error) error
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	now := time.Now()
 	for compositeKey, data := range s.data {
+		if data.IsExpired(now) {
+			continue
+		}
 		if err := fn(compositeKey, data); err != nil {
 			return errors.NewStorageError("forEach", err)
 		}
@@ -402,3 +402,69 @@ func TestUniStorage_MultiID(t *testing.T) {
 	// 4. ConflictOnCreateWithExistingExternalID
 	testConflictOnCreateWithExistingExternalID(t, storageInstance)
 }
+
+func TestUniStorage_UpdateWithHistory(t *testing.T) {
+	storageInstance := storage.NewUniStorage()
+	original := model.UniData{Path: "/docs/1", IDs: []string{"1"}, ContentType: "application/json",
+		Body: []byte(`{"v":1}`)}
+	if err := storageInstance.Create("docs", false, original); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for _, body := range []string{`{"v":2}`, `{"v":3}`} {
+		update := original
+		update.Body = []byte(body)
+		if err := storageInstance.UpdateWithHistory("docs", false, "1", update); err != nil {
+			t.Fatalf("UpdateWithHistory failed: %v", err)
+		}
+	}
+
+	versions, err := storageInstance.History("docs", "1")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(versions) != 2 || string(versions[0].Body) != `{"v":1}` || string(versions[1].Body) != `{"v":2}` {
+		t.Errorf("Expected versions v1 and v2 oldest first, got %v", versions)
+	}
+
+	plain := original
+	plain.Body = []byte(`{"v":4}`)
+	if err := storageInstance.Update("docs", false, "1", plain); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if versions, _ := storageInstance.History("docs", "1"); len(versions) != 2 {
+		t.Errorf("Expected Update not to keep history, got %d versions", len(versions))
+	}
+
+	if err := storageInstance.Delete("docs", false, "1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := storageInstance.History("docs", "1"); err == nil {
+		t.Error("Expected History of a deleted resource to fail")
+	}
+}
+
+func TestUniStorage_CreationSequence(t *testing.T) {
+	storageInstance := storage.NewUniStorage()
+	for _, id := range []string{"b", "a", "c"} {
+		data := model.UniData{Path: "/items/" + id, IDs: []string{id}, Body: []byte(`{}`)}
+		if err := storageInstance.Create("items", false, data); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	update := model.UniData{Path: "/items/b", IDs: []string{"b"}, Body: []byte(`{"updated":true}`)}
+	if err := storageInstance.Update("items", false, "b", update); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	for id, want := range map[string]uint64{"b": 1, "a": 2, "c": 3} {
+		data, err := storageInstance.Get("items", false, id)
+		if err != nil {
+			t.Fatalf("Get %s failed: %v", id, err)
+		}
+		if data.Seq != want {
+			t.Errorf("Expected %s to have sequence %d, got %d", id, want, data.Seq)
+		}
+	}
+}
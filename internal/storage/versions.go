@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// anyVersion makes update replace the resource whatever its version
+const anyVersion = 0
+
+// UpdateIfVersion updates the resource like Update, but only while it is at expectedVersion. The
+// version is compared under the write lock, so of concurrent updates expecting the same version only
+// one succeeds and the others fail with a VersionConflictError.
+func (s *uniStorage) UpdateIfVersion(
+	sectionName string, isStrictPath bool, id string, expectedVersion int, data model.UniData,
+) error {
+	if expectedVersion < 1 {
+		return errors.NewInvalidRequestError("expected version must be positive")
+	}
+	return s.update(sectionName, isStrictPath, id, data, false, expectedVersion)
+}
+
+// UpdateIfVersionWithHistory updates like UpdateIfVersion and keeps the replaced data as a previous version
+func (s *uniStorage) UpdateIfVersionWithHistory(
+	sectionName string, isStrictPath bool, id string, expectedVersion int, data model.UniData,
+) error {
+	if expectedVersion < 1 {
+		return errors.NewInvalidRequestError("expected version must be positive")
+	}
+	return s.update(sectionName, isStrictPath, id, data, true, expectedVersion)
+}
@@ -0,0 +1,60 @@
+package storage_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	unimockerrors "github.com/bmcszk/unimock/internal/errors"
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniStorage_UpdateIfVersion(t *testing.T) {
+	store := storage.NewUniStorage()
+	require.NoError(t, createItem(t, store, "1"))
+
+	stored, err := store.Get("items", false, "1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Version, "resources start at version 1")
+
+	require.NoError(t, store.UpdateIfVersion("items", false, "1", 1, model.UniData{Path: "/items", Body: []byte("b")}))
+	err = store.UpdateIfVersion("items", false, "1", 1, model.UniData{Path: "/items", Body: []byte("c")})
+
+	var conflict *unimockerrors.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 1, conflict.Expected)
+	assert.Equal(t, 2, conflict.Actual)
+
+	require.NoError(t, store.Update("items", false, "1", model.UniData{Path: "/items", Body: []byte("d")}))
+	stored, err = store.Get("items", false, "1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.Version, "unconditional updates advance the version too")
+	assert.Equal(t, "d", string(stored.Body))
+
+	var notFound *unimockerrors.NotFoundError
+	assert.ErrorAs(t, store.UpdateIfVersion("items", false, "2", 1, model.UniData{Path: "/items"}), &notFound)
+}
+
+func TestUniStorage_UpdateIfVersion_Concurrent(t *testing.T) {
+	store := storage.NewUniStorage()
+	require.NoError(t, createItem(t, store, "1"))
+
+	const writers = 20
+	var succeeded atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if store.UpdateIfVersion("items", false, "1", 1, model.UniData{Path: "/items"}) == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), succeeded.Load(), "only one update expecting the same version succeeds")
+}
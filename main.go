@@ -4,6 +4,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -30,13 +32,21 @@ func main() {
 	// Load configuration from environment variables
 	serverConfig := config.FromEnv()
 
+	flag.BoolVar(&serverConfig.ValidateOnly, "validate", serverConfig.ValidateOnly,
+		"validate the configuration file, print a report and exit instead of starting the server")
+	flag.Parse()
+	if serverConfig.ValidateOnly {
+		os.Exit(validateConfig(serverConfig))
+	}
+
 	logger.Info("starting unimock server",
 		"port", serverConfig.Port,
 		"config_path", serverConfig.ConfigPath,
-		"log_level", serverConfig.LogLevel)
+		"log_level", serverConfig.LogLevel,
+		"scheme", serverConfig.Scheme())
 
 	// Load unified configuration from file
-	uniConfig, err := config.LoadFromYAML(serverConfig.ConfigPath)
+	uniConfig, err := config.LoadFromYAML(serverConfig.ConfigPath, serverConfig.LoadOptions()...)
 	if err != nil {
 		logger.Error("failed to load configuration", "error", err)
 		panic(err)
@@ -51,8 +61,20 @@ func main() {
 
 	// Start server in a goroutine
 	go func() {
-		logger.Info("server listening", "address", srv.Addr)
-		if err := srv.ListenAndServe(); err != nil && err != context.Canceled {
+		ln, err := pkg.Listen(serverConfig, srv)
+		if err != nil {
+			logger.Error("failed to listen", "address", srv.Addr, "error", err)
+			panic(err)
+		}
+		logger.Info("server listening", "address", srv.Addr, "scheme", serverConfig.Scheme(),
+			"max_connections", serverConfig.MaxConnections)
+		if srv.TLSConfig != nil {
+			// The certificate is already loaded into srv.TLSConfig
+			err = srv.ServeTLS(ln, "", "")
+		} else {
+			err = srv.Serve(ln)
+		}
+		if err != nil && err != context.Canceled {
 			logger.Error("failed to start server", "error", err)
 			panic(err)
 		}
@@ -70,10 +92,33 @@ func main() {
 	defer cancel()
 
 	// Attempt graceful shutdown
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := pkg.Shutdown(ctx, srv); err != nil {
 		logger.Error("server forced to shutdown", "error", err)
 		panic(err)
 	}
 
 	logger.Info("server exited properly")
 }
+
+// validateConfig loads and checks the configuration file without starting the server, printing
+// every problem found, and returns the process exit code: 0 for a valid configuration, 1 otherwise
+func validateConfig(serverConfig *config.ServerConfig) int {
+	opts := append(serverConfig.LoadOptions(), config.WithoutValidation())
+	uniConfig, err := config.LoadFromYAML(serverConfig.ConfigPath, opts...)
+	if err != nil {
+		fmt.Printf("%s: failed to load configuration: %v\n", serverConfig.ConfigPath, err)
+		return 1
+	}
+
+	problems := pkg.ValidateConfig(uniConfig)
+	for _, problem := range problems {
+		fmt.Printf("%s: %v\n", serverConfig.ConfigPath, problem)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%s: %d problem(s) found\n", serverConfig.ConfigPath, len(problems))
+		return 1
+	}
+	fmt.Printf("%s: OK (%d sections, %d scenarios)\n",
+		serverConfig.ConfigPath, len(uniConfig.Sections), len(uniConfig.Scenarios))
+	return 0
+}
@@ -0,0 +1,246 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/client"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+func TestAdminAPIKey(t *testing.T) {
+	var gotKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKeys = append(gotKeys, r.URL.Path+"="+r.Header.Get(client.AdminKeyHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL, client.WithAdminAPIKey("secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := apiClient.ListScenarios(ctx); err != nil {
+		t.Fatalf("ListScenarios failed: %v", err)
+	}
+	if _, err := apiClient.Get(ctx, "/api/users", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	expected := []string{"/_uni/scenarios=secret-key", "/api/users="}
+	if strings.Join(gotKeys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected admin key only on management requests %v, got %v", expected, gotKeys)
+	}
+}
+
+func TestEnableDisableScenario(t *testing.T) {
+	var gotRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequests = append(gotRequests, r.Method+" "+r.URL.Path)
+		if strings.HasPrefix(r.URL.Path, "/_uni/scenarios/missing/") {
+			http.Error(w, "Scenario not found", http.StatusNotFound)
+			return
+		}
+		enabled := strings.HasSuffix(r.URL.Path, "/enable")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(model.Scenario{UUID: "test-uuid", Enabled: &enabled})
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	disabled, err := apiClient.DisableScenario(ctx, "test-uuid")
+	if err != nil {
+		t.Fatalf("DisableScenario failed: %v", err)
+	}
+	if disabled.Enabled == nil || *disabled.Enabled {
+		t.Errorf("Expected disabled scenario, got enabled=%v", disabled.Enabled)
+	}
+
+	enabled, err := apiClient.EnableScenario(ctx, "test-uuid")
+	if err != nil {
+		t.Fatalf("EnableScenario failed: %v", err)
+	}
+	if enabled.Enabled == nil || !*enabled.Enabled {
+		t.Errorf("Expected enabled scenario, got enabled=%v", enabled.Enabled)
+	}
+
+	if _, err := apiClient.EnableScenario(ctx, "missing"); err == nil {
+		t.Error("Expected error for missing scenario")
+	}
+
+	expected := []string{
+		"POST /_uni/scenarios/test-uuid/disable",
+		"POST /_uni/scenarios/test-uuid/enable",
+		"POST /_uni/scenarios/missing/enable",
+	}
+	if strings.Join(gotRequests, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected requests %v, got %v", expected, gotRequests)
+	}
+}
+
+func TestResetScenario(t *testing.T) {
+	var gotRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequests = append(gotRequests, r.Method+" "+r.URL.Path)
+		if strings.HasPrefix(r.URL.Path, "/_uni/scenarios/missing/") {
+			http.Error(w, "Scenario not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := apiClient.ResetScenario(ctx, "test-uuid"); err != nil {
+		t.Fatalf("ResetScenario failed: %v", err)
+	}
+	if err := apiClient.ResetScenario(ctx, "missing"); err == nil {
+		t.Error("Expected error for missing scenario")
+	}
+
+	expected := []string{"POST /_uni/scenarios/test-uuid/reset", "POST /_uni/scenarios/missing/reset"}
+	if strings.Join(gotRequests, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected requests %v, got %v", expected, gotRequests)
+	}
+}
+
+func TestAddAndRemoveFailure(t *testing.T) {
+	var gotRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequests = append(gotRequests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost:
+			var failure model.Failure
+			_ = json.NewDecoder(r.Body).Decode(&failure)
+			failure.ID = "failure-1"
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(failure)
+		case r.URL.Path == "/_uni/failures/missing":
+			http.Error(w, "Failure not found", http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := apiClient.AddFailure(ctx, model.Failure{Method: "GET", Path: "/users/1", StatusCode: 503})
+	if err != nil {
+		t.Fatalf("AddFailure failed: %v", err)
+	}
+	if created.ID != "failure-1" || created.StatusCode != 503 {
+		t.Errorf("Expected created failure with ID failure-1 and status 503, got %+v", created)
+	}
+	if err := apiClient.RemoveFailure(ctx, created.ID); err != nil {
+		t.Fatalf("RemoveFailure failed: %v", err)
+	}
+	if err := apiClient.RemoveFailure(ctx, "missing"); err == nil {
+		t.Error("Expected error for missing failure")
+	}
+
+	expected := []string{"POST /_uni/failures", "DELETE /_uni/failures/failure-1", "DELETE /_uni/failures/missing"}
+	if strings.Join(gotRequests, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected requests %v, got %v", expected, gotRequests)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	var gotItems []model.SeedItem
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_uni/seed" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotItems)
+		if len(gotItems) > 1 {
+			http.Error(w, "item 2: no matching section found for path: /unknown/1", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	user := model.SeedItem{Path: "/users/1", Body: json.RawMessage(`{"id":"1"}`)}
+	if err := apiClient.Seed(ctx, []model.SeedItem{user}); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	if len(gotItems) != 1 || gotItems[0].Path != "/users/1" || string(gotItems[0].Body) != `{"id":"1"}` {
+		t.Errorf("Expected the seed item to be sent, got %+v", gotItems)
+	}
+
+	unknown := model.SeedItem{Path: "/unknown/1", Body: json.RawMessage(`{}`)}
+	err = apiClient.Seed(ctx, []model.SeedItem{user, unknown})
+	if err == nil || !strings.Contains(err.Error(), "item 2") {
+		t.Errorf("Expected the rejected item in the error, got %v", err)
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	var gotMode, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_uni/config" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotMode, gotBody = r.URL.Query().Get("mode"), string(body)
+		if strings.Contains(gotBody, "invalid") {
+			http.Error(w, "invalid configuration: path pattern \"invalid\" must start with /", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	yamlConfig := "sections:\n  users:\n    path_pattern: /users/*\n"
+	if err := apiClient.ApplyConfig(ctx, []byte(yamlConfig), "merge"); err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+	if gotMode != "merge" || gotBody != yamlConfig {
+		t.Errorf("Expected the merge mode and configuration to be sent, got mode %q and body %q", gotMode, gotBody)
+	}
+
+	err = apiClient.ApplyConfig(ctx, []byte("sections:\n  users:\n    path_pattern: invalid\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "must start with /") {
+		t.Errorf("Expected the server's validation error, got %v", err)
+	}
+	if gotMode != "" {
+		t.Errorf("Expected no mode for the default, got %q", gotMode)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/bmcszk/unimock/pkg/model"
@@ -22,6 +23,21 @@ const (
 	// scenarioBasePath is the base path for the scenario API
 	scenarioBasePath = "/_uni/scenarios"
 
+	// failureBasePath is the base path for the forced failure API
+	failureBasePath = "/_uni/failures"
+
+	// seedPath is the bulk resource seeding endpoint
+	seedPath = "/_uni/seed"
+
+	// configPath is the reconfiguration endpoint
+	configPath = "/_uni/config"
+
+	// managementPathPrefix prefixes the Unimock management endpoints
+	managementPathPrefix = "/_uni/"
+
+	// AdminKeyHeader carries the admin API key on management requests
+	AdminKeyHeader = "X-Unimock-Key"
+
 	// HTTP client timeout
 	httpClientTimeout = 10 * time.Second
 
@@ -43,6 +59,29 @@ type Client struct {
 
 	// HTTPClient is the underlying HTTP client used to make requests
 	HTTPClient *http.Client
+
+	// AdminAPIKey is sent in the X-Unimock-Key header on /_uni management requests when set
+	AdminAPIKey string
+
+	// MaxRetries is how many times a transient failure is retried (see WithRetries)
+	MaxRetries int
+
+	// BackoffBase and BackoffMax shape the exponential delay between retries (see WithBackoff)
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+
+	// RetryNonIdempotent also retries POST and PATCH requests to mock endpoints
+	RetryNonIdempotent bool
+}
+
+// Option configures optional Client settings in NewClient
+type Option func(*Client)
+
+// WithAdminAPIKey sets the admin API key sent on scenario and other management requests
+func WithAdminAPIKey(key string) Option {
+	return func(c *Client) {
+		c.AdminAPIKey = key
+	}
 }
 
 // Response represents an HTTP response from the server
@@ -57,8 +96,8 @@ type Response struct {
 	Body []byte
 }
 
-// NewClient creates a new client with the given base URL
-func NewClient(baseURL string) (*Client, error) {
+// NewClient creates a new client with the given base URL and options
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
 	if baseURL == "" {
 		baseURL = DefaultBaseURL
 	}
@@ -68,12 +107,16 @@ func NewClient(baseURL string) (*Client, error) {
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 
-	return &Client{
+	c := &Client{
 		BaseURL: parsedURL,
 		HTTPClient: &http.Client{
 			Timeout: httpClientTimeout,
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // ========================================
@@ -163,7 +206,7 @@ func (c *Client) doRequest(
 	}
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -248,7 +291,7 @@ func (c *Client) CreateScenario(ctx context.Context, scenario model.Scenario) (m
 	req.Header.Set("Content-Type", "application/json")
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.Scenario{}, fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -280,7 +323,7 @@ func (c *Client) GetScenario(ctx context.Context, uuid string) (model.Scenario,
 	}
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.Scenario{}, fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -315,7 +358,7 @@ func (c *Client) ListScenarios(ctx context.Context) ([]model.Scenario, error) {
 	}
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -354,7 +397,7 @@ func (c *Client) UpdateScenario(ctx context.Context, uuid string, scenario model
 	req.Header.Set("Content-Type", "application/json")
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.Scenario{}, fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -389,7 +432,7 @@ func (c *Client) DeleteScenario(ctx context.Context, uuid string) error {
 	}
 
 	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf(msgFailedSendRequest, err)
 	}
@@ -407,6 +450,193 @@ func (c *Client) DeleteScenario(ctx context.Context, uuid string) error {
 	return nil
 }
 
+// EnableScenario switches a scenario on so it matches requests again
+func (c *Client) EnableScenario(ctx context.Context, uuid string) (model.Scenario, error) {
+	return c.setScenarioEnabled(ctx, uuid, "enable")
+}
+
+// DisableScenario switches a scenario off without deleting it; matching requests fall through to mock handling
+func (c *Client) DisableScenario(ctx context.Context, uuid string) (model.Scenario, error) {
+	return c.setScenarioEnabled(ctx, uuid, "disable")
+}
+
+// ResetScenario sets the scenario's hit counter back to zero, restoring its initial threshold response
+func (c *Client) ResetScenario(ctx context.Context, uuid string) error {
+	requestURL := c.buildURL(path.Join(scenarioBasePath, uuid, "reset"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf(msgFailedCreateRequest, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("scenario not found: %s", uuid)
+	}
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// AddFailure registers a failure that makes every request with the failure's method and path
+// return its status code, taking precedence over scenarios and stored resources
+func (c *Client) AddFailure(ctx context.Context, failure model.Failure) (model.Failure, error) {
+	body, err := json.Marshal(failure)
+	if err != nil {
+		return model.Failure{}, fmt.Errorf("failed to serialize failure: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.buildURL(failureBasePath), bytes.NewReader(body))
+	if err != nil {
+		return model.Failure{}, fmt.Errorf(msgFailedCreateRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return model.Failure{}, fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return model.Failure{}, fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+
+	var created model.Failure
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return model.Failure{}, fmt.Errorf(msgFailedParseResponse, err)
+	}
+	return created, nil
+}
+
+// RemoveFailure removes a failure registered with AddFailure
+func (c *Client) RemoveFailure(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.buildURL(path.Join(failureBasePath, id)), nil)
+	if err != nil {
+		return fmt.Errorf(msgFailedCreateRequest, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("failure not found: %s", id)
+	}
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Seed inserts the resources directly into the mock storage in a single request, each under the last
+// segment of its path as ID, replacing resources already stored under that ID. Nothing is seeded when
+// any item is rejected, e.g. because no section matches its path.
+func (c *Client) Seed(ctx context.Context, items []model.SeedItem) error {
+	body, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to serialize seed items: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.buildURL(seedPath), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf(msgFailedCreateRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// ApplyConfig reconfigures the running server with the YAML configuration, in the format of the
+// configuration file. Mode "replace" (or "") replaces all sections and scenarios, "merge" adds them,
+// replacing sections of the same name. An invalid configuration fails with the server's list of
+// problems and leaves the current configuration in place; stored resources are kept either way.
+func (c *Client) ApplyConfig(ctx context.Context, yamlConfig []byte, mode string) error {
+	u := *c.BaseURL
+	u.Path = configPath
+	if mode != "" {
+		u.RawQuery = url.Values{"mode": {mode}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(yamlConfig))
+	if err != nil {
+		return fmt.Errorf(msgFailedCreateRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// setScenarioEnabled posts to the scenario's enable or disable endpoint
+func (c *Client) setScenarioEnabled(ctx context.Context, uuid, action string) (model.Scenario, error) {
+	requestURL := c.buildURL(path.Join(scenarioBasePath, uuid, action))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
+	if err != nil {
+		return model.Scenario{}, fmt.Errorf(msgFailedCreateRequest, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return model.Scenario{}, fmt.Errorf(msgFailedSendRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return model.Scenario{}, fmt.Errorf("scenario not found: %s", uuid)
+	}
+	if resp.StatusCode < httpStatusOKMin || resp.StatusCode >= httpStatusOKMax {
+		respBody, _ := io.ReadAll(resp.Body)
+		return model.Scenario{}, fmt.Errorf(msgServerError, resp.StatusCode, string(respBody))
+	}
+
+	var scenario model.Scenario
+	if err := json.NewDecoder(resp.Body).Decode(&scenario); err != nil {
+		return model.Scenario{}, fmt.Errorf(msgFailedParseResponse, err)
+	}
+	return scenario, nil
+}
+
+// do sends the request, adding the admin API key to management requests and retrying
+// transient failures as configured
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.AdminAPIKey != "" && strings.HasPrefix(req.URL.Path, managementPathPrefix) {
+		req.Header.Set(AdminKeyHeader, c.AdminAPIKey)
+	}
+	return c.sendWithRetry(req)
+}
+
 // Helper method to build a URL
 func (c *Client) buildURL(urlPath string) string {
 	u := *c.BaseURL
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBackoffBase is the delay before the first retry unless WithBackoff sets another
+	DefaultBackoffBase = 100 * time.Millisecond
+
+	// DefaultBackoffMax caps the delay between retries unless WithBackoff sets another
+	DefaultBackoffMax = 2 * time.Second
+)
+
+// WithRetries retries failed requests up to n more times. Only idempotent requests
+// (GET, HEAD, PUT, DELETE, OPTIONS) and scenario operations are retried, on connection errors
+// and 5xx responses; POSTs to mock endpoints are retried only with WithRetryNonIdempotent.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.MaxRetries = n
+	}
+}
+
+// WithBackoff sets the exponential backoff between retries: the first retry waits base,
+// each following one twice as long, capped at maxDelay
+func WithBackoff(base, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.BackoffBase = base
+		c.BackoffMax = maxDelay
+	}
+}
+
+// WithRetryNonIdempotent also retries POST and PATCH requests, which may then be applied twice
+func WithRetryNonIdempotent() Option {
+	return func(c *Client) {
+		c.RetryNonIdempotent = true
+	}
+}
+
+// sendWithRetry sends the request, retrying transient failures as configured.
+// It stops early when the context is done or its deadline would pass during the backoff.
+func (c *Client) sendWithRetry(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if c.retryable(req) {
+		retries = c.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if attempt >= retries || !transientFailure(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if waitErr := sleepContext(req.Context(), delay); waitErr != nil {
+			return nil, waitErr
+		}
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether the request may be sent more than once
+func (c *Client) retryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return c.RetryNonIdempotent || strings.HasPrefix(req.URL.Path, scenarioBasePath)
+}
+
+// backoff returns the delay before the retry following the given attempt
+func (c *Client) backoff(attempt int) time.Duration {
+	base, maxDelay := c.BackoffBase, c.BackoffMax
+	if base <= 0 {
+		base = DefaultBackoffBase
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultBackoffMax
+	}
+
+	delay := base
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// transientFailure reports whether a result is worth retrying: a connection error or a 5xx response
+func transientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// sleepContext waits for the delay or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindRequest prepares the request to be sent again with a fresh copy of its body
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
@@ -0,0 +1,149 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/client"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+// newFlakyServer fails the first failures requests with 503 and then answers 200
+func newFlakyServer(failures int32, attempts *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(attempts, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/_uni/scenarios") {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestRetries(t *testing.T) {
+	retry1 := []client.Option{client.WithRetries(1)}
+	retry3 := []client.Option{client.WithRetries(3)}
+	tests := []struct {
+		name         string
+		method       string
+		opts         []client.Option
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{name: "no retries by default", method: http.MethodGet, wantStatus: 503, wantAttempts: 1},
+		{name: "GET retried", method: http.MethodGet, opts: retry3, wantStatus: 200, wantAttempts: 3},
+		{name: "PUT retried", method: http.MethodPut, opts: retry3, wantStatus: 200, wantAttempts: 3},
+		{name: "retries exhausted", method: http.MethodDelete, opts: retry1, wantStatus: 503, wantAttempts: 2},
+		{name: "POST not retried", method: http.MethodPost, opts: retry3, wantStatus: 503, wantAttempts: 1},
+		{
+			name:       "POST retried when allowed",
+			method:     http.MethodPost,
+			opts:       append(retry3, client.WithRetryNonIdempotent()),
+			wantStatus: 200, wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := newFlakyServer(2, &attempts)
+			defer server.Close()
+
+			opts := append([]client.Option{client.WithBackoff(time.Millisecond, 5*time.Millisecond)}, tt.opts...)
+			apiClient, err := client.NewClient(server.URL, opts...)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			var body []byte
+			if tt.method == http.MethodPost || tt.method == http.MethodPut {
+				body = []byte(`{"id":"1"}`)
+			}
+			resp, err := doMethod(context.Background(), apiClient, tt.method, body)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if tt.wantStatus == http.StatusOK && string(resp.Body) != string(body) {
+				t.Errorf("Expected body %q to be resent on retry, got %q", body, resp.Body)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+// doMethod sends a request with the given method to a mock endpoint
+func doMethod(ctx context.Context, apiClient *client.Client, method string, body []byte) (*client.Response, error) {
+	switch method {
+	case http.MethodPost:
+		return apiClient.Post(ctx, "/api/users", nil, body)
+	case http.MethodPut:
+		return apiClient.Put(ctx, "/api/users/1", nil, body)
+	case http.MethodDelete:
+		return apiClient.Delete(ctx, "/api/users/1", nil)
+	default:
+		return apiClient.Get(ctx, "/api/users/1", nil)
+	}
+}
+
+func TestRetriesScenarioOperations(t *testing.T) {
+	var attempts int32
+	server := newFlakyServer(1, &attempts)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(server.URL,
+		client.WithRetries(2), client.WithBackoff(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	created, err := apiClient.CreateScenario(context.Background(), model.Scenario{UUID: "s1", RequestPath: "GET /a"})
+	if err != nil {
+		t.Fatalf("CreateScenario failed: %v", err)
+	}
+	if created.UUID != "s1" {
+		t.Errorf("Expected scenario s1, got %q", created.UUID)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetriesConnectionErrorHonorsDeadline(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverURL := server.URL
+	server.Close()
+
+	apiClient, err := client.NewClient(serverURL,
+		client.WithRetries(100), client.WithBackoff(50*time.Millisecond, time.Second))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := apiClient.Get(ctx, "/api/users/1", nil); err == nil {
+		t.Fatal("Expected connection error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retries to stop at the context deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import "fmt"
+
+// Modes of UniConfig.Apply
+const (
+	// ApplyReplace replaces all sections and scenarios with those of the update (default)
+	ApplyReplace = "replace"
+	// ApplyMerge adds the update's sections, replacing sections of the same name, and scenarios
+	ApplyMerge = "merge"
+)
+
+// ValidateApplyMode checks that mode is empty or one of the apply modes
+func ValidateApplyMode(mode string) error {
+	switch mode {
+	case "", ApplyReplace, ApplyMerge:
+		return nil
+	default:
+		return fmt.Errorf("mode must be %q or %q, got %q", ApplyReplace, ApplyMerge, mode)
+	}
+}
+
+// BaseDir returns the directory fixture references of the configuration are resolved against
+func (uc *UniConfig) BaseDir() string {
+	return uc.baseDir
+}
+
+// Apply reconfigures a running server's configuration with the sections and scenarios of update,
+// parsed and validated beforehand (see ParseYAML). The sections map is swapped rather than
+// modified, so requests already matching sections keep the ones they found. While the server runs,
+// read sections through MatchPath, Section and SectionCount, which are safe to call concurrently
+// with Apply. Scenarios served by the scenario service are updated separately. Functions
+// registered with OnApply are called once the update is in place.
+func (uc *UniConfig) Apply(update *UniConfig, mode string) {
+	// Transforms of the update generate IDs like those of the configuration it is applied to
+	update.newID = uc.newID
+	uc.mu.Lock()
+	sections := make(map[string]Section, len(uc.Sections)+len(update.Sections))
+	scenarios := append([]ScenarioConfig(nil), update.Scenarios...)
+	if mode == ApplyMerge {
+		for name, section := range uc.Sections {
+			sections[name] = section
+		}
+		scenarios = append(append([]ScenarioConfig(nil), uc.Scenarios...), update.Scenarios...)
+	}
+	for name, section := range update.Sections {
+		sections[name] = section
+	}
+	uc.Sections = sections
+	uc.Scenarios = scenarios
+	onApply := uc.onApply
+	uc.mu.Unlock()
+
+	for _, fn := range onApply {
+		fn()
+	}
+}
+
+// OnApply registers fn to be called after every Apply, e.g. to start background work that the
+// applied sections need
+func (uc *UniConfig) OnApply(fn func()) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.onApply = append(uc.onApply, fn)
+}
+
+// Section returns the section with the given name
+func (uc *UniConfig) Section(name string) (Section, bool) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	section, ok := uc.Sections[name]
+	return section, ok
+}
+
+// SectionCount returns the number of sections
+func (uc *UniConfig) SectionCount() int {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return len(uc.Sections)
+}
+
+// HasTTLSections reports whether any section lets its resources expire
+func (uc *UniConfig) HasTTLSections() bool {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	for _, section := range uc.Sections {
+		if section.TTL > 0 {
+			return true
+		}
+	}
+	return false
+}
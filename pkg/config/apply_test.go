@@ -0,0 +1,38 @@
+package config_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniConfig_ApplyWhileMatching(t *testing.T) {
+	cfg := &config.UniConfig{Sections: map[string]config.Section{
+		"users": {PathPattern: "/users/*", Versioned: true},
+	}}
+	update := &config.UniConfig{Sections: map[string]config.Section{
+		"orders": {PathPattern: "/orders/*"},
+	}}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cfg.Apply(update, config.ApplyMerge)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		name, _, err := cfg.MatchPath("/users/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "users", name)
+		section, ok := cfg.Section("users")
+		assert.True(t, ok)
+		assert.True(t, section.Versioned)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, cfg.SectionCount())
+}
@@ -0,0 +1,32 @@
+package config
+
+import "errors"
+
+// AuthConfig describes the credentials a section requires.
+// Configure either Username/Password for HTTP Basic auth or BearerToken for a bearer token.
+type AuthConfig struct {
+	// Username and Password are the HTTP Basic credentials requests must present
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+
+	// BearerToken is the token requests must present as "Authorization: Bearer <token>"
+	BearerToken string `yaml:"bearer_token,omitempty" json:"bearer_token,omitempty"`
+}
+
+// IsBasic reports whether the section requires HTTP Basic credentials
+func (a *AuthConfig) IsBasic() bool {
+	return a.Username != ""
+}
+
+// Validate checks that exactly one credential kind is configured
+func (a *AuthConfig) Validate() error {
+	switch {
+	case a.Username == "" && a.BearerToken == "":
+		return errors.New("auth requires either username/password or bearer_token")
+	case a.Username != "" && a.BearerToken != "":
+		return errors.New("auth accepts either username/password or bearer_token, not both")
+	case a.Username == "" && a.Password != "":
+		return errors.New("auth password requires a username")
+	}
+	return nil
+}
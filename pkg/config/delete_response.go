@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DeleteResponse shapes a section's successful DELETE responses to match the semantics of the API
+// being mocked, e.g. 202 Accepted for asynchronous deletes or 200 OK with a body
+type DeleteResponse struct {
+	// StatusCode is one of 200, 202, 204 or 304 (default: 204). A 304 never carries the
+	// return_body body.
+	StatusCode int `yaml:"status_code,omitempty" json:"status_code,omitempty"`
+
+	// Headers are added to every successful DELETE response, e.g. X-Deleted-Count: "1"
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// Status returns the configured status code or 204 No Content
+func (d *DeleteResponse) Status() int {
+	if d == nil || d.StatusCode == 0 {
+		return http.StatusNoContent
+	}
+	return d.StatusCode
+}
+
+// Validate checks that the status code is one a DELETE may answer with
+func (d *DeleteResponse) Validate() error {
+	switch d.Status() {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("delete_response status_code must be 200, 202, 204 or 304, got %d", d.StatusCode)
+	}
+}
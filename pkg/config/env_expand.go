@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// envDefaultSeparator separates a variable name from its default in ${VAR:-default}
+const envDefaultSeparator = ":-"
+
+// literalDollar is what a $ that does not start a ${...} reference is rewritten to before expansion,
+// so that it maps back to itself instead of being expanded as $VAR (e.g. in "$.user.id" or "$ref")
+const literalDollar = "${$}"
+
+// LoadOption customizes LoadFromYAML
+type LoadOption func(*loadOptions)
+
+// loadOptions holds the settings applied by LoadOption functions
+type loadOptions struct {
+	lenientEnv     bool
+	skipEnv        bool
+	skipFixtures   bool
+	skipValidation bool
+
+	fixtureFetchTimeout time.Duration
+	fixtureCacheDir     string
+}
+
+// WithLenientEnv expands references to undefined environment variables without a default to an
+// empty string instead of failing the load
+func WithLenientEnv() LoadOption {
+	return func(o *loadOptions) {
+		o.lenientEnv = true
+	}
+}
+
+// WithoutEnvExpansion leaves ${VAR} references in the configuration as they are, for configurations
+// from untrusted sources such as the /_uni/config endpoint, which must not read the server's environment
+func WithoutEnvExpansion() LoadOption {
+	return func(o *loadOptions) {
+		o.skipEnv = true
+	}
+}
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references in the configuration with environment
+// variable values. The default is used when the variable is unset or empty. References to undefined
+// variables without a default are reported as an error unless lenient is set.
+func expandEnv(data []byte, lenient bool) ([]byte, error) {
+	missing := make(map[string]bool)
+	expanded := os.Expand(protectLiteralDollars(string(data)), func(reference string) string {
+		if reference == "$" {
+			return "$"
+		}
+		name, fallback, hasDefault := strings.Cut(reference, envDefaultSeparator)
+		if value, ok := os.LookupEnv(name); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return fallback
+		}
+		missing[name] = true
+		return ""
+	})
+
+	if len(missing) > 0 && !lenient {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("config references undefined environment variables %s; "+
+			"set them or provide defaults with ${VAR:-default}", strings.Join(names, ", "))
+	}
+	return []byte(expanded), nil
+}
+
+// protectLiteralDollars rewrites every $ not followed by { to literalDollar
+func protectLiteralDollars(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '$' && (i+1 == len(s) || s[i+1] != '{') {
+			b.WriteString(literalDollar)
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
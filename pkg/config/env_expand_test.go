@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envConfigYAML = `sections:
+  users:
+    path_pattern: "${UNIMOCK_TEST_PREFIX:-/api}/users/*"
+    body_id_paths: ["/id"]
+    response_transforms:
+      - path: "$.password"
+        action: redact
+scenarios:
+  - uuid: "backend-link"
+    method: "GET"
+    path: "/api/links/backend"
+    data: '{"url": "${UNIMOCK_TEST_BACKEND_URL}"}'
+`
+
+func writeEnvConfig(t *testing.T) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(envConfigYAML), 0600))
+	return configPath
+}
+
+func TestLoadFromYAML_ExpandsEnvVars(t *testing.T) {
+	t.Setenv("UNIMOCK_TEST_PREFIX", "/v2")
+	t.Setenv("UNIMOCK_TEST_BACKEND_URL", "http://backend:8080/?a=1&b=2")
+
+	cfg, err := config.LoadFromYAML(writeEnvConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v2/users/*", cfg.Sections["users"].PathPattern)
+	require.Len(t, cfg.Scenarios, 1)
+	assert.Equal(t, `{"url": "http://backend:8080/?a=1&b=2"}`, cfg.Scenarios[0].Data)
+	assert.Equal(t, "$.password", cfg.Sections["users"].ResponseTransforms[0].Path)
+}
+
+func TestLoadFromYAML_EnvDefaultUsedWhenUnsetOrEmpty(t *testing.T) {
+	t.Setenv("UNIMOCK_TEST_PREFIX", "")
+	t.Setenv("UNIMOCK_TEST_BACKEND_URL", "http://backend")
+
+	cfg, err := config.LoadFromYAML(writeEnvConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/users/*", cfg.Sections["users"].PathPattern)
+}
+
+func TestLoadFromYAML_UndefinedEnvVarFails(t *testing.T) {
+	require.NoError(t, os.Unsetenv("UNIMOCK_TEST_BACKEND_URL"))
+
+	_, err := config.LoadFromYAML(writeEnvConfig(t))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UNIMOCK_TEST_BACKEND_URL")
+}
+
+func TestLoadFromYAML_LenientEnvExpandsUndefinedToEmpty(t *testing.T) {
+	require.NoError(t, os.Unsetenv("UNIMOCK_TEST_BACKEND_URL"))
+
+	cfg, err := config.LoadFromYAML(writeEnvConfig(t), config.WithLenientEnv())
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Scenarios, 1)
+	assert.Equal(t, `{"url": ""}`, cfg.Scenarios[0].Data)
+}
+
+func TestParseYAML_WithoutEnvExpansionKeepsReferences(t *testing.T) {
+	t.Setenv("UNIMOCK_TEST_PREFIX", "/v2")
+	require.NoError(t, os.Unsetenv("UNIMOCK_TEST_BACKEND_URL"))
+
+	cfg, err := config.ParseYAML([]byte(envConfigYAML), t.TempDir(),
+		config.WithoutEnvExpansion(), config.WithoutValidation())
+	require.NoError(t, err)
+
+	assert.Equal(t, "${UNIMOCK_TEST_PREFIX:-/api}/users/*", cfg.Sections["users"].PathPattern)
+	require.Len(t, cfg.Scenarios, 1)
+	assert.Equal(t, `{"url": "${UNIMOCK_TEST_BACKEND_URL}"}`, cfg.Scenarios[0].Data)
+}
+
+func TestFromEnv_LenientEnv(t *testing.T) {
+	t.Setenv("UNIMOCK_LENIENT_ENV", "true")
+
+	cfg := config.FromEnv()
+
+	assert.True(t, cfg.LenientEnv)
+	assert.Len(t, cfg.LoadOptions(), 1)
+}
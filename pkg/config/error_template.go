@@ -0,0 +1,31 @@
+package config
+
+import "errors"
+
+// Placeholders interpolated into ErrorTemplate bodies
+const (
+	// ErrorPlaceholderStatus is replaced with the numeric HTTP status, e.g. 404
+	ErrorPlaceholderStatus = "{{status}}"
+	// ErrorPlaceholderCode is replaced with the upper snake case status text, e.g. NOT_FOUND
+	ErrorPlaceholderCode = "{{code}}"
+	// ErrorPlaceholderMessage is replaced with the error message, escaped for the content type
+	ErrorPlaceholderMessage = "{{message}}"
+)
+
+// ErrorTemplate shapes error response bodies to match a real API's error envelope,
+// e.g. {"error": {"code": "{{code}}", "message": "{{message}}"}}
+type ErrorTemplate struct {
+	// ContentType of the error body (default: application/json)
+	ContentType string `yaml:"content_type,omitempty" json:"content_type,omitempty"`
+
+	// Body is the error body with {{status}}, {{code}} and {{message}} placeholders
+	Body string `yaml:"body" json:"body"`
+}
+
+// Validate checks that the template has a body
+func (t *ErrorTemplate) Validate() error {
+	if t.Body == "" {
+		return errors.New("error_template requires a body")
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package config
+
+import "fmt"
+
+// Eviction policies of ServerConfig.EvictionPolicy, applied when MaxResources is reached
+const (
+	// EvictionOldest removes the earliest created resource to make room for a new one (default)
+	EvictionOldest = "oldest"
+	// EvictionReject keeps the stored resources and answers new creates with 507 Insufficient Storage
+	EvictionReject = "reject"
+)
+
+// ValidateEvictionPolicy checks that policy is empty or one of the eviction policies
+func ValidateEvictionPolicy(policy string) error {
+	switch policy {
+	case "", EvictionOldest, EvictionReject:
+		return nil
+	default:
+		return fmt.Errorf("eviction policy must be %q or %q, got %q", EvictionOldest, EvictionReject, policy)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bmcszk/unimock/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioConfigFromModel converts a model.Scenario back into its configuration form.
+// It is the inverse of ScenarioConfig.ToModelScenario; the data is kept inline.
+func ScenarioConfigFromModel(scenario model.Scenario) ScenarioConfig {
+	method, path, found := strings.Cut(scenario.RequestPath, " ")
+	if !found {
+		method, path = "", scenario.RequestPath
+	}
+
+	return ScenarioConfig{
+		UUID:        scenario.UUID,
+		Method:      method,
+		Path:        path,
+		StatusCode:  scenario.StatusCode,
+		ContentType: scenario.ContentType,
+		Location:    scenario.Location,
+		Data:        scenario.Data,
+		Headers:     scenario.Headers,
+
+		MatchContentLength: scenario.MatchContentLength,
+		RequireFlag:        scenario.RequireFlag,
+		CookieMatch:        scenario.CookieMatch,
+		Priority:           scenario.Priority,
+		PadToBytes:         scenario.PadToBytes,
+		RandomBytes:        scenario.RandomBytes,
+
+		ThrottleBytesPerSec: scenario.ThrottleBytesPerSec,
+
+		Enabled:     scenario.Enabled,
+		ActiveFrom:  scenario.ActiveFrom,
+		ActiveUntil: scenario.ActiveUntil,
+
+		ThresholdResponses: scenario.ThresholdResponses,
+		ExpireAfterHits:    scenario.ExpireAfterHits,
+		CloseConnection:    scenario.CloseConnection,
+		MatchRequestLine:   scenario.MatchRequestLine,
+		ProtoMatch:         scenario.ProtoMatch,
+		ResponseSchema:     responseSchemaFromJSON(scenario.ResponseSchema),
+	}
+}
+
+// ExportYAML serializes the sections together with the given scenarios in the unified YAML format.
+// The output is self-contained (scenario data is emitted inline) and can be loaded with LoadFromYAML.
+func (uc *UniConfig) ExportYAML(scenarios []model.Scenario) ([]byte, error) {
+	uc.mu.RLock()
+	sections := uc.Sections
+	uc.mu.RUnlock()
+	export := UniConfig{
+		Sections:  sections,
+		Scenarios: make([]ScenarioConfig, 0, len(scenarios)),
+	}
+	for _, scenario := range scenarios {
+		export.Scenarios = append(export.Scenarios, ScenarioConfigFromModel(scenario))
+	}
+	sort.Slice(export.Scenarios, func(i, j int) bool {
+		a, b := export.Scenarios[i], export.Scenarios[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.UUID < b.UUID
+	})
+
+	data, err := yaml.Marshal(&export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/google/uuid"
+)
+
+const (
+	// TransformActionRemove deletes the field at the configured path
+	TransformActionRemove = "remove"
+	// TransformActionSet replaces (or creates) the field at the configured path with Value
+	TransformActionSet = "set"
+	// TransformActionRedact replaces the field value at the configured path with RedactedValue
+	TransformActionRedact = "redact"
+	// TransformActionSetTimestamp sets the field at the configured path to the current UTC time (RFC 3339)
+	TransformActionSetTimestamp = "setTimestamp"
+	// TransformActionGenerateUUID sets the field at the configured path to a freshly generated UUID
+	TransformActionGenerateUUID = "generateUUID"
+
+	// RedactedValue is the placeholder written by the redact action
+	RedactedValue = "[REDACTED]"
+)
+
+// FieldTransform describes a declarative transformation of a single JSON body field.
+// It allows shaping request and response bodies from YAML without writing Go code.
+type FieldTransform struct {
+	// Path selects the field to transform.
+	// Both XPath-like ("/user/ssn") and JSONPath-like ("$.user.ssn") notations are accepted.
+	// Numeric segments index arrays and "*" matches every element of an array or object.
+	Path string `yaml:"path" json:"path"`
+
+	// Action is the operation to perform: "remove", "set", "redact", "setTimestamp", "generateUUID",
+	// or one of the whole-body conversions "jsonToXML" and "xmlToJSON", which ignore Path
+	Action string `yaml:"action" json:"action"`
+
+	// Value is the value written by the "set" action
+	Value any `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+// fieldMutator mutates the value found at a path; it returns the new value and whether to keep the field
+type fieldMutator func(current any, exists bool) (any, bool)
+
+// NewResponseFieldTransform compiles a declarative FieldTransform into a ResponseTransformFunc.
+// The generateUUID action generates random UUIDs.
+func NewResponseFieldTransform(ft FieldTransform) (ResponseTransformFunc, error) {
+	return newResponseFieldTransform(ft, randomUUID)
+}
+
+// NewRequestFieldTransform compiles a declarative FieldTransform into a RequestTransformFunc.
+// The generateUUID action generates random UUIDs.
+func NewRequestFieldTransform(ft FieldTransform) (RequestTransformFunc, error) {
+	return newRequestFieldTransform(ft, randomUUID)
+}
+
+// newResponseFieldTransform compiles the transform with the generateUUID action taking its IDs from newID
+func newResponseFieldTransform(ft FieldTransform, newID func() string) (ResponseTransformFunc, error) {
+	if convert, ok := formatConversions[ft.Action]; ok {
+		return convert, nil
+	}
+	mutate, err := ft.mutator(newID)
+	if err != nil {
+		return nil, err
+	}
+	segments := parseFieldPath(ft.Path)
+	return func(data model.UniData) (model.UniData, error) {
+		return applyFieldMutation(data, segments, mutate)
+	}, nil
+}
+
+// newRequestFieldTransform compiles the transform with the generateUUID action taking its IDs from newID
+func newRequestFieldTransform(ft FieldTransform, newID func() string) (RequestTransformFunc, error) {
+	transform, err := newResponseFieldTransform(ft, newID)
+	if err != nil {
+		return nil, err
+	}
+	return RequestTransformFunc(transform), nil
+}
+
+// mutator returns the field mutator for the transform action; generateUUID takes its IDs from newID
+func (ft FieldTransform) mutator(newID func() string) (fieldMutator, error) {
+	if len(parseFieldPath(ft.Path)) == 0 {
+		return nil, fmt.Errorf("invalid field transform: path %q selects no field", ft.Path)
+	}
+	switch ft.Action {
+	case TransformActionRemove:
+		return func(any, bool) (any, bool) { return nil, false }, nil
+	case TransformActionSet:
+		value := ft.Value
+		return func(any, bool) (any, bool) { return value, true }, nil
+	case TransformActionRedact:
+		return func(_ any, exists bool) (any, bool) { return RedactedValue, exists }, nil
+	case TransformActionSetTimestamp:
+		return func(any, bool) (any, bool) { return time.Now().UTC().Format(time.RFC3339), true }, nil
+	case TransformActionGenerateUUID:
+		return func(any, bool) (any, bool) { return newID(), true }, nil
+	default:
+		return nil, fmt.Errorf("invalid field transform: unsupported action %q", ft.Action)
+	}
+}
+
+// randomUUID generates a random UUID, the default IDs of the generateUUID action
+func randomUUID() string {
+	return uuid.New().String()
+}
+
+// parseFieldPath splits a field path into its segments
+func parseFieldPath(fieldPath string) []string {
+	p := strings.TrimSpace(fieldPath)
+	if strings.HasPrefix(p, "$") {
+		p = strings.TrimPrefix(p, "$")
+		p = strings.ReplaceAll(p, "[", ".")
+		p = strings.ReplaceAll(p, "]", "")
+		p = strings.ReplaceAll(p, ".", PathSeparator)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(p, PathSeparator) {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// applyFieldMutation decodes a JSON body, mutates the selected fields and re-encodes it.
+// Non-JSON and empty bodies are returned unchanged.
+func applyFieldMutation(data model.UniData, segments []string, mutate fieldMutator) (model.UniData, error) {
+	if len(data.Body) == 0 || !strings.Contains(strings.ToLower(data.ContentType), "json") {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data.Body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return model.UniData{}, fmt.Errorf("failed to parse JSON body: %w", err)
+	}
+
+	doc = mutateAtPath(doc, segments, mutate)
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return model.UniData{}, fmt.Errorf("failed to encode JSON body: %w", err)
+	}
+	data.Body = body
+	return data, nil
+}
+
+// mutateAtPath walks the document and applies the mutator to every node matching the segments
+func mutateAtPath(node any, segments []string, mutate fieldMutator) any {
+	if len(segments) == 0 {
+		return node
+	}
+	switch typed := node.(type) {
+	case map[string]any:
+		return mutateObject(typed, segments, mutate)
+	case []any:
+		return mutateArray(typed, segments, mutate)
+	default:
+		return node
+	}
+}
+
+// mutateObject applies the mutation to matching keys of a JSON object
+func mutateObject(obj map[string]any, segments []string, mutate fieldMutator) map[string]any {
+	segment, rest := segments[0], segments[1:]
+	keys := []string{segment}
+	if segment == WildcardChar {
+		keys = keys[:0]
+		for key := range obj {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		current, exists := obj[key]
+		if len(rest) > 0 {
+			if exists {
+				obj[key] = mutateAtPath(current, rest, mutate)
+			}
+			continue
+		}
+		if !exists && segment == WildcardChar {
+			continue
+		}
+		if value, keep := mutate(current, exists); keep {
+			obj[key] = value
+		} else {
+			delete(obj, key)
+		}
+	}
+	return obj
+}
+
+// mutateArray applies the mutation to matching elements of a JSON array
+func mutateArray(arr []any, segments []string, mutate fieldMutator) []any {
+	segment, rest := segments[0], segments[1:]
+	indexes := make([]int, 0, len(arr))
+	if segment == WildcardChar {
+		for i := range arr {
+			indexes = append(indexes, i)
+		}
+	} else if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(arr) {
+		indexes = append(indexes, i)
+	}
+
+	removed := make(map[int]bool)
+	for _, i := range indexes {
+		if len(rest) > 0 {
+			arr[i] = mutateAtPath(arr[i], rest, mutate)
+			continue
+		}
+		if value, keep := mutate(arr[i], true); keep {
+			arr[i] = value
+		} else {
+			removed[i] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return arr
+	}
+	kept := make([]any, 0, len(arr)-len(removed))
+	for i, item := range arr {
+		if !removed[i] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,237 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponseFieldTransform_Actions(t *testing.T) {
+	tests := []struct {
+		name     string
+		ft       config.FieldTransform
+		body     string
+		expected string
+	}{
+		{
+			name:     "redact nested field",
+			ft:       config.FieldTransform{Path: "/user/ssn", Action: config.TransformActionRedact},
+			body:     `{"user":{"name":"John","ssn":"123-45-6789"}}`,
+			expected: `{"user":{"name":"John","ssn":"[REDACTED]"}}`,
+		},
+		{
+			name:     "remove field with JSONPath notation",
+			ft:       config.FieldTransform{Path: "$.user.ssn", Action: config.TransformActionRemove},
+			body:     `{"user":{"name":"John","ssn":"123-45-6789"}}`,
+			expected: `{"user":{"name":"John"}}`,
+		},
+		{
+			name:     "set creates missing field",
+			ft:       config.FieldTransform{Path: "/source", Action: config.TransformActionSet, Value: "unimock"},
+			body:     `{"id":1}`,
+			expected: `{"id":1,"source":"unimock"}`,
+		},
+		{
+			name:     "wildcard over array elements",
+			ft:       config.FieldTransform{Path: "/items/*/secret", Action: config.TransformActionRemove},
+			body:     `{"items":[{"id":1,"secret":"a"},{"id":2,"secret":"b"}]}`,
+			expected: `{"items":[{"id":1},{"id":2}]}`,
+		},
+		{
+			name:     "redact does not create missing field",
+			ft:       config.FieldTransform{Path: "/ssn", Action: config.TransformActionRedact},
+			body:     `{"id":1}`,
+			expected: `{"id":1}`,
+		},
+		{
+			name:     "missing path leaves body untouched",
+			ft:       config.FieldTransform{Path: "/user/ssn", Action: config.TransformActionRedact},
+			body:     `{"id":1}`,
+			expected: `{"id":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transform, err := config.NewResponseFieldTransform(tt.ft)
+			require.NoError(t, err)
+
+			result, err := transform(model.UniData{ContentType: "application/json", Body: []byte(tt.body)})
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result.Body))
+		})
+	}
+}
+
+func TestNewResponseFieldTransform_NonJSONBodyUnchanged(t *testing.T) {
+	transform, err := config.NewResponseFieldTransform(
+		config.FieldTransform{Path: "/id", Action: config.TransformActionRemove})
+	require.NoError(t, err)
+
+	result, err := transform(model.UniData{ContentType: "application/xml", Body: []byte("<id>1</id>")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "<id>1</id>", string(result.Body))
+}
+
+func TestNewResponseFieldTransform_InvalidSpec(t *testing.T) {
+	_, err := config.NewResponseFieldTransform(config.FieldTransform{Path: "/id", Action: "explode"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported action")
+
+	_, err = config.NewResponseFieldTransform(config.FieldTransform{Path: "/", Action: config.TransformActionRemove})
+	require.Error(t, err)
+}
+
+func TestLoadFromYAML_ResponseTransforms(t *testing.T) {
+	yamlContent := `sections:
+  users:
+    path_pattern: "/users/*"
+    body_id_paths: ["/id"]
+    response_transforms:
+      - path: "/user/ssn"
+        action: redact
+      - path: "/internal"
+        action: remove
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	cfg, err := config.LoadFromYAML(configPath)
+	require.NoError(t, err)
+
+	section := cfg.Sections["users"]
+	require.True(t, section.Transformations.HasResponseTransforms())
+	assert.Len(t, section.Transformations.ResponseTransforms, 2)
+
+	data := model.UniData{
+		ContentType: "application/json",
+		Body:        []byte(`{"user":{"ssn":"123"},"internal":true}`),
+	}
+	for _, transform := range section.Transformations.ResponseTransforms {
+		data, err = transform(data)
+		require.NoError(t, err)
+	}
+	assert.JSONEq(t, `{"user":{"ssn":"[REDACTED]"}}`, string(data.Body))
+}
+
+func TestLoadFromYAML_InvalidResponseTransform(t *testing.T) {
+	yamlContent := `sections:
+  users:
+    path_pattern: "/users/*"
+    response_transforms:
+      - path: "/id"
+        action: shuffle
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	_, err := config.LoadFromYAML(configPath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "users")
+}
+
+func TestNewRequestFieldTransform_EnrichesBody(t *testing.T) {
+	setTimestamp, err := config.NewRequestFieldTransform(
+		config.FieldTransform{Path: "/createdAt", Action: config.TransformActionSetTimestamp})
+	require.NoError(t, err)
+	generateUUID, err := config.NewRequestFieldTransform(
+		config.FieldTransform{Path: "/meta/requestId", Action: config.TransformActionGenerateUUID})
+	require.NoError(t, err)
+
+	data := model.UniData{ContentType: "application/json", Body: []byte(`{"name":"Alice","meta":{}}`)}
+	data, err = setTimestamp(data)
+	require.NoError(t, err)
+	data, err = generateUUID(data)
+	require.NoError(t, err)
+
+	var body struct {
+		Name      string `json:"name"`
+		CreatedAt string `json:"createdAt"`
+		Meta      struct {
+			RequestID string `json:"requestId"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(data.Body, &body))
+	assert.Equal(t, "Alice", body.Name)
+	_, err = time.Parse(time.RFC3339, body.CreatedAt)
+	assert.NoError(t, err)
+	_, err = uuid.Parse(body.Meta.RequestID)
+	assert.NoError(t, err)
+}
+
+func TestLoadFromYAML_RequestTransforms(t *testing.T) {
+	yamlContent := `sections:
+  users:
+    path_pattern: "/users/*"
+    request_transforms:
+      - path: "/status"
+        action: set
+        value: "active"
+      - path: "/createdAt"
+        action: setTimestamp
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	cfg, err := config.LoadFromYAML(configPath)
+	require.NoError(t, err)
+
+	section := cfg.Sections["users"]
+	assert.True(t, section.Transformations.HasRequestTransforms())
+	assert.False(t, section.Transformations.HasResponseTransforms())
+	assert.Len(t, section.Transformations.RequestTransforms, 2)
+}
+
+func TestLoadFromYAML_GenerateUUIDUsesIDGenerator(t *testing.T) {
+	yamlContent := `sections:
+  users:
+    path_pattern: "/users/*"
+    request_transforms:
+      - path: "/requestId"
+        action: generateUUID
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+	cfg, err := config.LoadFromYAML(configPath)
+	require.NoError(t, err)
+	cfg.UseIDGenerator(func() string { return "00000000-0000-0000-0000-000000000001" })
+
+	data, err := cfg.Sections["users"].Transformations.RequestTransforms[0](
+		model.UniData{ContentType: "application/json", Body: []byte(`{}`)})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"requestId": "00000000-0000-0000-0000-000000000001"}`, string(data.Body),
+		"transforms compiled before the generator was set use it too")
+}
+
+func TestUniConfig_CompileTransformsTwice(t *testing.T) {
+	custom := config.NewTransformationConfig()
+	custom.AddResponseTransform(func(data model.UniData) (model.UniData, error) { return data, nil })
+	cfg := &config.UniConfig{Sections: map[string]config.Section{
+		"users": {
+			PathPattern:        "/users/*",
+			RequestTransforms:  []config.FieldTransform{{Path: "/source", Action: config.TransformActionSet, Value: "api"}},
+			ResponseTransforms: []config.FieldTransform{{Path: "/ssn", Action: config.TransformActionRedact}},
+			Transformations:    custom,
+		},
+	}}
+
+	require.NoError(t, cfg.CompileTransforms())
+	require.NoError(t, cfg.CompileTransforms())
+
+	transforms := cfg.Sections["users"].Transformations
+	assert.Len(t, transforms.RequestTransforms, 1)
+	assert.Len(t, transforms.ResponseTransforms, 2, "the custom transform followed by the compiled one")
+	assert.Len(t, custom.ResponseTransforms, 1, "transforms set in code are left as they are")
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExpandFixedResponses moves the fixed responses of every section into the top-level scenarios, in
+// section name order after the existing scenarios. A fixed response without a path gets the section's
+// path pattern; one with a path outside the section's patterns is an error.
+// It is called by LoadFromYAML; library users building UniConfig in code should call it once themselves.
+func (uc *UniConfig) ExpandFixedResponses() error {
+	names := make([]string, 0, len(uc.Sections))
+	for name, section := range uc.Sections {
+		if len(section.FixedResponses) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		section := uc.Sections[name]
+		for i, response := range section.FixedResponses {
+			if err := section.scopeFixedResponse(&response); err != nil {
+				return fmt.Errorf("section %s: fixed_responses %d: %w", name, i+1, err)
+			}
+			uc.Scenarios = append(uc.Scenarios, response)
+		}
+		section.FixedResponses = nil
+		uc.Sections[name] = section
+	}
+	return nil
+}
+
+// scopeFixedResponse defaults the fixed response's path to the section's and checks it lies within
+// the section. Responses matched by a request line expression alone are left as they are.
+func (s *Section) scopeFixedResponse(response *ScenarioConfig) error {
+	if response.MatchRequestLine != "" && response.Method == "" && response.Path == "" {
+		return nil
+	}
+	patterns := s.Patterns()
+	if len(patterns) == 0 {
+		return nil
+	}
+	if response.Path == "" {
+		response.Path = patterns[0]
+		return nil
+	}
+	for _, pattern := range patterns {
+		if _, ok := MatchCaptures(pattern, response.Path, s.CaseSensitive); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("path %q is outside the section's path patterns", response.Path)
+}
@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadYAML(t *testing.T, content string) (*config.UniConfig, error) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+	return config.LoadFromYAML(configPath)
+}
+
+func TestLoadFromYAML_FixedResponses(t *testing.T) {
+	uniConfig, err := loadYAML(t, `
+sections:
+  users:
+    path_pattern: "/api/users/*"
+    body_id_paths: ["/id"]
+    fixed_responses:
+      - method: GET
+        path: /api/users/banned
+        status_code: 403
+        data: '{"error": "banned"}'
+      - method: DELETE
+        status_code: 405
+  orders:
+    path_pattern: "/api/orders/*"
+    fixed_responses:
+      - method: GET
+        path: /api/orders
+        data: '[]'
+scenarios:
+  - method: GET
+    path: /api/health
+    data: '{"ok": true}'
+`)
+	require.NoError(t, err)
+
+	var requestPaths []string
+	for _, scenario := range uniConfig.Scenarios {
+		requestPaths = append(requestPaths, scenario.Method+" "+scenario.Path)
+	}
+	assert.Equal(t, []string{
+		"GET /api/health", "GET /api/orders", "GET /api/users/banned", "DELETE /api/users/*",
+	}, requestPaths, "fixed responses follow the scenarios in section name order")
+	assert.Equal(t, 403, uniConfig.Scenarios[2].StatusCode)
+	for name, section := range uniConfig.Sections {
+		assert.Empty(t, section.FixedResponses, name)
+	}
+}
+
+func TestLoadFromYAML_FixedResponseOutsideSection(t *testing.T) {
+	_, err := loadYAML(t, `
+sections:
+  users:
+    path_pattern: "/api/users/*"
+    fixed_responses:
+      - method: GET
+        path: /api/orders/1
+`)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "section users: fixed_responses 1")
+	assert.ErrorContains(t, err, "/api/orders/1")
+}
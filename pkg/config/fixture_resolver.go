@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 )
 
 // FixtureResolver handles loading fixture files referenced in configuration
@@ -17,6 +18,10 @@ type FixtureResolver struct {
 	mutex   sync.RWMutex
 	// Precompiled regex for inline fixture references
 	inlineFixtureRegex *regexp.Regexp
+
+	// fetchTimeout and cacheDir configure URL fixtures (see WithFixtureFetchTimeout, WithFixtureCacheDir)
+	fetchTimeout time.Duration
+	cacheDir     string
 }
 
 // NewFixtureResolver creates a new fixture resolver with the given base directory
@@ -34,6 +39,7 @@ func NewFixtureResolver(baseDir string) *FixtureResolver {
 
 // ResolveFixture resolves a data string, supporting multiple fixture reference formats:
 // - @fixtures/file.json syntax (backward compatibility)
+// - @https://host/file.json syntax, fetching the body from the URL
 // - < ./fixtures/file.ext syntax (go-restclient compatible) - SPACE AFTER < IS REQUIRED
 // - <@ ./fixtures/file.ext syntax (variable substitution) - @ IMMEDIATELY AFTER <, SPACE AFTER @
 // - Inline fixtures: {"key": < ./fixtures/file.json} syntax within body content
@@ -56,6 +62,24 @@ func (fr *FixtureResolver) ResolveFixture(data string) (string, error) {
 	return fr.resolveInlineFixtures(data), nil
 }
 
+// CheckFixture reports why a whole-body fixture reference (@file, < file or <@ file) in data cannot
+// be resolved, including the missing files ResolveFixture falls back on. Data that only looks like
+// a reference, such as an XML body, and other data is not checked.
+func (fr *FixtureResolver) CheckFixture(data string) error {
+	trimmedData := strings.TrimSpace(data)
+	var err error
+	switch {
+	case strings.HasPrefix(trimmedData, "@"):
+		_, err = fr.resolveAtSyntax(trimmedData)
+	case strings.HasPrefix(trimmedData, "<") && !strings.Contains(trimmedData, "}"):
+		_, err = fr.resolveLessThanSyntax(trimmedData)
+	}
+	if err != nil && isFixtureSyntaxError(err) {
+		return nil
+	}
+	return err
+}
+
 // resolveAtSyntaxWithFallback handles @ syntax with graceful fallback
 func (fr *FixtureResolver) resolveAtSyntaxWithFallback(trimmedData, originalData string) (string, error) {
 	result, err := fr.resolveAtSyntax(trimmedData)
@@ -82,7 +106,7 @@ func handleResolutionError(err error, trimmedData, originalData string) (string,
 		return originalData, nil
 	}
 	// Graceful fallback for invalid syntax errors (e.g., no space after <)
-	if strings.Contains(err.Error(), "invalid") && strings.Contains(err.Error(), "syntax") {
+	if isFixtureSyntaxError(err) {
 		log.Printf("invalid fixture syntax %q: %v (falling back to original data)", trimmedData, err)
 		return originalData, nil
 	}
@@ -90,8 +114,17 @@ func handleResolutionError(err error, trimmedData, originalData string) (string,
 	return "", err
 }
 
-// resolveAtSyntax handles @fixtures/file.json syntax
+// isFixtureSyntaxError reports whether err rejects the syntax of data that is then taken literally
+func isFixtureSyntaxError(err error) bool {
+	return strings.Contains(err.Error(), "invalid") && strings.Contains(err.Error(), "syntax")
+}
+
+// resolveAtSyntax handles @fixtures/file.json and @https://... syntax
 func (fr *FixtureResolver) resolveAtSyntax(data string) (string, error) {
+	if url, ok := urlFixture(data); ok {
+		return fr.loadURLFixture(url)
+	}
+
 	// Remove @ prefix to get file path
 	filePath := strings.TrimSpace(data[1:])
 
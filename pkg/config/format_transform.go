@@ -0,0 +1,197 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/bmcszk/unimock/pkg/model"
+)
+
+const (
+	// TransformActionJSONToXML converts a whole JSON body to XML (see JSONToXML); it takes no path
+	TransformActionJSONToXML = "jsonToXML"
+	// TransformActionXMLToJSON converts a whole XML body to JSON (see XMLToJSON); it takes no path
+	TransformActionXMLToJSON = "xmlToJSON"
+
+	// xmlAttributePrefix marks JSON keys that map to XML attributes
+	xmlAttributePrefix = "@"
+	// xmlTextKey holds the text of XML elements that also have attributes or child elements
+	xmlTextKey = "#text"
+	// xmlDefaultRoot wraps JSON documents that do not consist of a single named object
+	xmlDefaultRoot = "root"
+	// xmlContentType and jsonContentType are set on converted bodies
+	xmlContentType  = "application/xml"
+	jsonContentType = "application/json"
+)
+
+// formatConversions maps the body conversion actions to their transform functions
+var formatConversions = map[string]ResponseTransformFunc{
+	TransformActionJSONToXML: JSONToXML,
+	TransformActionXMLToJSON: XMLToJSON,
+}
+
+// JSONToXML converts a JSON body to XML and sets the content type to application/xml.
+// An object with a single key becomes the root element, anything else is wrapped in <root>.
+// Keys prefixed with "@" become attributes, "#text" becomes the element text and arrays become
+// repeated elements. Keys are written in sorted order. Non-JSON and empty bodies are returned unchanged.
+func JSONToXML(data model.UniData) (model.UniData, error) {
+	if len(data.Body) == 0 || !strings.Contains(strings.ToLower(data.ContentType), "json") {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data.Body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return model.UniData{}, fmt.Errorf("failed to parse JSON body: %w", err)
+	}
+
+	rootName, rootValue := xmlDefaultRoot, doc
+	if obj, ok := doc.(map[string]any); ok && len(obj) == 1 {
+		for key, value := range obj {
+			if _, isArray := value.([]any); !isArray && !strings.HasPrefix(key, xmlAttributePrefix) {
+				rootName, rootValue = key, value
+			}
+		}
+	}
+	if _, isArray := rootValue.([]any); isArray {
+		rootValue = map[string]any{"item": rootValue}
+	}
+
+	var buf bytes.Buffer
+	writeXMLElement(&buf, rootName, rootValue)
+	data.Body = buf.Bytes()
+	data.ContentType = xmlContentType
+	return data, nil
+}
+
+// writeXMLElement writes value as one element, or as repeated elements when it is an array
+func writeXMLElement(buf *bytes.Buffer, name string, value any) {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			writeXMLElement(buf, name, item)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteString("<" + name)
+		for _, key := range keys {
+			if attr, ok := strings.CutPrefix(key, xmlAttributePrefix); ok {
+				buf.WriteString(" " + attr + `="`)
+				_ = xml.EscapeText(buf, []byte(xmlScalar(v[key])))
+				buf.WriteString(`"`)
+			}
+		}
+		buf.WriteString(">")
+		for _, key := range keys {
+			switch {
+			case key == xmlTextKey:
+				_ = xml.EscapeText(buf, []byte(xmlScalar(v[key])))
+			case !strings.HasPrefix(key, xmlAttributePrefix):
+				writeXMLElement(buf, key, v[key])
+			}
+		}
+		buf.WriteString("</" + name + ">")
+	case nil:
+		buf.WriteString("<" + name + "/>")
+	default:
+		buf.WriteString("<" + name + ">")
+		_ = xml.EscapeText(buf, []byte(xmlScalar(v)))
+		buf.WriteString("</" + name + ">")
+	}
+}
+
+// xmlScalar formats a JSON scalar as XML text
+func xmlScalar(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// XMLToJSON converts an XML body to JSON and sets the content type to application/json.
+// The root element becomes the single key of the resulting object. Elements holding only text become
+// strings, other elements objects with "@"-prefixed attributes, "#text" and child elements, where
+// repeated children form arrays. Non-XML and empty bodies are returned unchanged.
+func XMLToJSON(data model.UniData) (model.UniData, error) {
+	if len(data.Body) == 0 || !strings.Contains(strings.ToLower(data.ContentType), "xml") {
+		return data, nil
+	}
+
+	doc, err := xmlquery.Parse(bytes.NewReader(data.Body))
+	if err != nil {
+		return model.UniData{}, fmt.Errorf("failed to parse XML body: %w", err)
+	}
+	root := firstXMLElement(doc)
+	if root == nil {
+		return model.UniData{}, fmt.Errorf("failed to parse XML body: no root element")
+	}
+
+	body, err := json.Marshal(map[string]any{root.Data: xmlElementValue(root)})
+	if err != nil {
+		return model.UniData{}, fmt.Errorf("failed to encode JSON body: %w", err)
+	}
+	data.Body = body
+	data.ContentType = jsonContentType
+	return data, nil
+}
+
+// firstXMLElement returns the first element child of the node
+func firstXMLElement(node *xmlquery.Node) *xmlquery.Node {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode {
+			return child
+		}
+	}
+	return nil
+}
+
+// xmlElementValue converts an element to a string when it only holds text, otherwise to an object
+func xmlElementValue(node *xmlquery.Node) any {
+	var text strings.Builder
+	obj := make(map[string]any)
+	for _, attr := range node.Attr {
+		obj[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case xmlquery.ElementNode:
+			addXMLChild(obj, child.Data, xmlElementValue(child))
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			text.WriteString(child.Data)
+		}
+	}
+
+	trimmed := strings.TrimSpace(text.String())
+	if len(obj) == 0 {
+		return trimmed
+	}
+	if trimmed != "" {
+		obj[xmlTextKey] = trimmed
+	}
+	return obj
+}
+
+// addXMLChild adds a child element value, collecting repeated element names into an array
+func addXMLChild(obj map[string]any, name string, value any) {
+	existing, ok := obj[name]
+	if !ok {
+		obj[name] = value
+		return
+	}
+	if list, isList := existing.([]any); isList {
+		obj[name] = append(list, value)
+		return
+	}
+	obj[name] = []any{existing, value}
+}
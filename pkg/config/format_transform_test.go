@@ -0,0 +1,111 @@
+package config_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/bmcszk/unimock/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONToXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "single key becomes root",
+			body:     `{"user":{"name":"John & Jane","age":42,"id":"1"}}`,
+			expected: `<user><age>42</age><id>1</id><name>John &amp; Jane</name></user>`,
+		},
+		{
+			name:     "attributes, text and repeated elements",
+			body:     `{"order":{"@id":"7","item":[{"#text":"apple","@qty":2},"pear"],"note":null}}`,
+			expected: `<order id="7"><item qty="2">apple</item><item>pear</item><note/></order>`,
+		},
+		{
+			name:     "multiple keys are wrapped in root",
+			body:     `{"a":1,"b":true}`,
+			expected: `<root><a>1</a><b>true</b></root>`,
+		},
+		{
+			name:     "top-level array",
+			body:     `[1,2]`,
+			expected: `<root><item>1</item><item>2</item></root>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := config.JSONToXML(model.UniData{ContentType: "application/json", Body: []byte(tt.body)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result.Body))
+			assert.Equal(t, "application/xml", result.ContentType)
+		})
+	}
+}
+
+func TestXMLToJSON(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<order id="7">
+  <item qty="2">apple</item>
+  <item>pear</item>
+  <customer><name>John</name></customer>
+  <note/>
+</order>`
+
+	result, err := config.XMLToJSON(model.UniData{ContentType: "application/xml; charset=utf-8", Body: []byte(body)})
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", result.ContentType)
+	assert.JSONEq(t, `{"order":{
+		"@id":"7",
+		"item":[{"@qty":"2","#text":"apple"},"pear"],
+		"customer":{"name":"John"},
+		"note":""
+	}}`, string(result.Body))
+}
+
+func TestFormatConversions_RoundTrip(t *testing.T) {
+	original := `{"user":{"@id":"1","name":"John","tags":["a","b"]}}`
+
+	asXML, err := config.JSONToXML(model.UniData{ContentType: "application/json", Body: []byte(original)})
+	require.NoError(t, err)
+	asJSON, err := config.XMLToJSON(asXML)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, original, string(asJSON.Body))
+}
+
+func TestFormatConversions_OtherContentTypesUnchanged(t *testing.T) {
+	data := model.UniData{ContentType: "text/plain", Body: []byte("hello")}
+
+	converted, err := config.JSONToXML(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, converted)
+
+	converted, err = config.XMLToJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, converted)
+}
+
+func TestFormatConversions_InvalidBody(t *testing.T) {
+	_, err := config.JSONToXML(model.UniData{ContentType: "application/json", Body: []byte(`{bad`)})
+	assert.Error(t, err)
+
+	_, err = config.XMLToJSON(model.UniData{ContentType: "application/xml", Body: []byte(`not xml`)})
+	assert.Error(t, err)
+}
+
+func TestNewResponseFieldTransform_FormatConversion(t *testing.T) {
+	transform, err := config.NewResponseFieldTransform(config.FieldTransform{Action: config.TransformActionJSONToXML})
+	require.NoError(t, err)
+
+	result, err := transform(model.UniData{ContentType: "application/json", Body: json.RawMessage(`{"a":"b"}`)})
+
+	require.NoError(t, err)
+	assert.Equal(t, `<a>b</a>`, string(result.Body))
+}
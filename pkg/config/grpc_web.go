@@ -0,0 +1,44 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ProtocolHTTP is the default protocol of sections: plain HTTP resources
+	ProtocolHTTP = ""
+	// ProtocolGRPCWeb makes a section answer unary gRPC-Web calls with JSON messages
+	ProtocolGRPCWeb = "grpc-web"
+
+	// maxGRPCStatus is the highest canonical gRPC status code (UNAUTHENTICATED)
+	maxGRPCStatus = 16
+)
+
+// GRPCWebConfig configures the response of a grpc-web section to every unary call it matches
+type GRPCWebConfig struct {
+	// Response is the JSON response message, sent in a data frame when Status is 0 (default: {})
+	Response string `yaml:"response,omitempty" json:"response,omitempty"`
+
+	// Status is the gRPC status code sent in the grpc-status trailer (default: 0, OK).
+	// Calls with a non-zero status carry no response message.
+	Status int `yaml:"status,omitempty" json:"status,omitempty"`
+
+	// Message is sent in the grpc-message trailer, usually describing a non-zero status
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	// Trailers are additional trailers sent after grpc-status and grpc-message
+	Trailers map[string]string `yaml:"trailers,omitempty" json:"trailers,omitempty"`
+}
+
+// Validate checks the status code and that the response message is JSON
+func (g *GRPCWebConfig) Validate() error {
+	if g.Status < 0 || g.Status > maxGRPCStatus {
+		return fmt.Errorf("grpc_web status must be between 0 and %d, got %d", maxGRPCStatus, g.Status)
+	}
+	if g.Response != "" && !json.Valid([]byte(g.Response)) {
+		return errors.New("grpc_web response must be a JSON message")
+	}
+	return nil
+}
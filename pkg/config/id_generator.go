@@ -0,0 +1,16 @@
+package config
+
+// UseIDGenerator makes generateUUID transforms take their IDs from newID, e.g. the storage's ID
+// generator, so deterministic IDs apply to them too. Transforms compiled earlier use it as well.
+// Without it they generate random UUIDs.
+func (uc *UniConfig) UseIDGenerator(newID func() string) {
+	uc.newID = newID
+}
+
+// generateID returns the next ID of the configured generator, or a random UUID without one
+func (uc *UniConfig) generateID() string {
+	if uc.newID == nil {
+		return randomUUID()
+	}
+	return uc.newID()
+}
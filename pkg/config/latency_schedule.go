@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// timeOfDayLayout is the layout of the bounds of latency schedule windows
+	timeOfDayLayout = "15:04"
+
+	minutesPerDay = 24 * 60
+)
+
+// LatencySchedule maps daily time windows, written "HH:MM-HH:MM" in the server's local time with an
+// exclusive end, to the delay added to responses during them, e.g. "09:00-17:00": 800ms for peak
+// hours. A window ending before it starts wraps past midnight, as "22:00-06:00" does.
+type LatencySchedule map[string]time.Duration
+
+// Validate checks that every window is well-formed and non-empty and every delay non-negative
+func (ls LatencySchedule) Validate() error {
+	for window, delay := range ls {
+		if _, _, err := parseTimeWindow(window); err != nil {
+			return err
+		}
+		if delay < 0 {
+			return fmt.Errorf("latency_schedule delay of %q must not be negative, got %s", window, delay)
+		}
+	}
+	return nil
+}
+
+// DelayAt returns the delay of the window containing the time of day of t. Where windows overlap the
+// longest delay applies; outside every window there is none.
+func (ls LatencySchedule) DelayAt(t time.Time) time.Duration {
+	minute := t.Hour()*60 + t.Minute()
+	var delay time.Duration
+	for window, windowDelay := range ls {
+		from, until, err := parseTimeWindow(window)
+		if err != nil || windowDelay <= delay {
+			continue
+		}
+		if inTimeWindow(minute, from, until) {
+			delay = windowDelay
+		}
+	}
+	return delay
+}
+
+// parseTimeWindow parses a "HH:MM-HH:MM" window into its bounds in minutes since midnight
+func parseTimeWindow(window string) (from, until int, err error) {
+	fromText, untilText, found := strings.Cut(window, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("latency_schedule window %q must be written HH:MM-HH:MM", window)
+	}
+	if from, err = parseTimeOfDay(fromText); err != nil {
+		return 0, 0, fmt.Errorf("latency_schedule window %q: %w", window, err)
+	}
+	if until, err = parseTimeOfDay(untilText); err != nil {
+		return 0, 0, fmt.Errorf("latency_schedule window %q: %w", window, err)
+	}
+	if from == until {
+		return 0, 0, fmt.Errorf("latency_schedule window %q is empty", window)
+	}
+	return from, until, nil
+}
+
+// parseTimeOfDay parses "HH:MM" into minutes since midnight; "24:00" is the end of the day
+func parseTimeOfDay(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "24:00" {
+		return minutesPerDay, nil
+	}
+	parsed, err := time.Parse(timeOfDayLayout, text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// inTimeWindow reports whether the minute of the day falls in [from, until), wrapping past midnight
+// when until is before from
+func inTimeWindow(minute, from, until int) bool {
+	if from < until {
+		return minute >= from && minute < until
+	}
+	return minute >= from || minute < until
+}
@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLatencySchedule_DelayAt(t *testing.T) {
+	schedule := config.LatencySchedule{
+		"09:00-17:00": 800 * time.Millisecond,
+		"12:00-13:00": 2 * time.Second,
+		"22:00-06:00": 50 * time.Millisecond,
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 4, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name string
+		time time.Time
+		want time.Duration
+	}{
+		{name: "window start is inclusive", time: at(9, 0), want: 800 * time.Millisecond},
+		{name: "overlap takes the longest delay", time: at(12, 30), want: 2 * time.Second},
+		{name: "window end is exclusive", time: at(17, 0), want: 0},
+		{name: "wrapping window before midnight", time: at(23, 15), want: 50 * time.Millisecond},
+		{name: "wrapping window after midnight", time: at(5, 59), want: 50 * time.Millisecond},
+		{name: "outside every window", time: at(7, 30), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, schedule.DelayAt(tt.time))
+		})
+	}
+}
+
+func TestLatencySchedule_YAML(t *testing.T) {
+	var section config.Section
+	err := yaml.Unmarshal([]byte(`
+path_pattern: /users/*
+latency_schedule:
+  "09:00-17:00": 800ms
+  "22:00-06:00": 1s
+`), &section)
+	require.NoError(t, err)
+	assert.Equal(t, config.LatencySchedule{
+		"09:00-17:00": 800 * time.Millisecond,
+		"22:00-06:00": time.Second,
+	}, section.LatencySchedule)
+}
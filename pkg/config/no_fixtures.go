@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fixtureReferencePattern finds fixture references within data, as resolveInlineFixtures does
+var fixtureReferencePattern = regexp.MustCompile(`<(?:\s+|@\s*)[^\s,}]+`)
+
+// WithoutFixtures rejects configurations whose scenarios reference fixtures (@file, @https://...,
+// < file or <@ file), for configurations from untrusted sources such as the /_uni/config endpoint,
+// which must not make the server read its files or fetch URLs
+func WithoutFixtures() LoadOption {
+	return func(o *loadOptions) {
+		o.skipFixtures = true
+	}
+}
+
+// rejectFixtures returns an error naming the first scenario whose data references a fixture
+func (uc *UniConfig) rejectFixtures() error {
+	for i, scenario := range uc.Scenarios {
+		if hasFixtureReference(scenario.Data) {
+			return fmt.Errorf("scenario %d (%s %s): fixture references are not allowed here",
+				i+1, scenario.Method, scenario.Path)
+		}
+	}
+	return nil
+}
+
+// hasFixtureReference reports whether data references a fixture as a whole or inline
+func hasFixtureReference(data string) bool {
+	return strings.HasPrefix(strings.TrimSpace(data), "@") || fixtureReferencePattern.MatchString(data)
+}
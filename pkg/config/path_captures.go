@@ -0,0 +1,71 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PathCapturePrefix names wildcard captures in templates: {{.Path1}} is the segment matched by the
+// pattern's first wildcard, {{.Path2}} by the second and so on
+const PathCapturePrefix = "Path"
+
+// MatchCaptures matches the path against a pattern and returns the segments matched by its wildcards
+// in order: one segment per *, and the matched segments joined by / per **. A trailing * that the path
+// leaves out, as in collection access of /users/* by /users, captures nothing. ok is false when the
+// path does not match.
+func MatchCaptures(pattern, path string, caseSensitive bool) (captures []string, ok bool) {
+	patternParts := strings.Split(strings.Trim(pattern, PathSeparator), PathSeparator)
+	pathParts := strings.Split(strings.Trim(path, PathSeparator), PathSeparator)
+	matcher := pathMatcher{caseSensitive: caseSensitive}
+	return matcher.captureSegments(patternParts, pathParts, nil)
+}
+
+// MatchPathCaptures finds the section matching the path like MatchPath and also returns the path
+// segments captured by the wildcards of the matched pattern (see MatchCaptures)
+func (uc *UniConfig) MatchPathCaptures(path string) (string, *Section, []string, error) {
+	name, section, err := uc.MatchPath(path)
+	if err != nil || section == nil {
+		return name, section, nil, err
+	}
+	captures, _ := MatchCaptures(section.PathPattern, path, section.CaseSensitive)
+	return name, section, captures, nil
+}
+
+// PathCaptureFields names the captures for templates: Path1 for the first, Path2 for the second...
+func PathCaptureFields(captures []string) map[string]any {
+	fields := make(map[string]any, len(captures))
+	for i, capture := range captures {
+		fields[PathCapturePrefix+strconv.Itoa(i+1)] = capture
+	}
+	return fields
+}
+
+// captureSegments matches the remaining pattern segments against the remaining path segments,
+// appending the wildcard captures
+func (pm pathMatcher) captureSegments(patternParts, pathParts, captures []string) ([]string, bool) {
+	if len(patternParts) == 0 {
+		return captures, len(pathParts) == 0
+	}
+
+	switch patternParts[0] {
+	case RecursiveWildcard:
+		for i := 0; i <= len(pathParts); i++ {
+			joined := strings.Join(pathParts[:i], PathSeparator)
+			withCapture := append(append([]string(nil), captures...), joined)
+			if result, ok := pm.captureSegments(patternParts[1:], pathParts[i:], withCapture); ok {
+				return result, true
+			}
+		}
+		return nil, false
+	case WildcardChar:
+		if len(pathParts) == 0 {
+			return captures, len(patternParts) == 1
+		}
+		return pm.captureSegments(patternParts[1:], pathParts[1:], append(captures, pathParts[0]))
+	default:
+		if len(pathParts) == 0 || !pm.segmentMatches(patternParts[0], pathParts[0]) {
+			return nil, false
+		}
+		return pm.captureSegments(patternParts[1:], pathParts[1:], captures)
+	}
+}
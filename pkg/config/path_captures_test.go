@@ -0,0 +1,50 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCaptures(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		captures []string
+		matches  bool
+	}{
+		{pattern: "/users/*/orders/*", path: "/users/u1/orders/o2", captures: []string{"u1", "o2"}, matches: true},
+		{pattern: "/users/*/orders/*", path: "/users/u1/orders", captures: []string{"u1"}, matches: true},
+		{pattern: "/files/**", path: "/files/a/b/c.txt", captures: []string{"a/b/c.txt"}, matches: true},
+		{pattern: "/files/**/meta", path: "/files/a/b/meta", captures: []string{"a/b"}, matches: true},
+		{pattern: "/users", path: "/users", matches: true},
+		{pattern: "/users/*/orders/*", path: "/users/u1/invoices/o2"},
+		{pattern: "/users/*", path: "/users/u1/extra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			captures, ok := config.MatchCaptures(tt.pattern, tt.path, false)
+
+			assert.Equal(t, tt.matches, ok)
+			if tt.matches {
+				assert.Equal(t, tt.captures, captures)
+			}
+		})
+	}
+}
+
+func TestUniConfig_MatchPathCaptures(t *testing.T) {
+	cfg := &config.UniConfig{Sections: map[string]config.Section{
+		"orders": {PathPattern: "/users/*/orders/*"},
+	}}
+
+	name, section, captures, err := cfg.MatchPathCaptures("/users/u1/orders/o2")
+
+	require.NoError(t, err)
+	require.NotNil(t, section)
+	assert.Equal(t, "orders", name)
+	assert.Equal(t, map[string]any{"Path1": "u1", "Path2": "o2"}, config.PathCaptureFields(captures))
+}
@@ -0,0 +1,170 @@
+package config
+
+import "strings"
+
+// isPatternMatch checks if a path matches a pattern with wildcards
+func isPatternMatch(pattern, path string, caseSensitive bool) bool {
+	matcher := pathMatcher{caseSensitive: caseSensitive}
+	patternParts := strings.Split(strings.Trim(pattern, PathSeparator), PathSeparator)
+	pathParts := strings.Split(strings.Trim(path, PathSeparator), PathSeparator)
+
+	if !strings.Contains(pattern, WildcardChar) {
+		return matcher.matchExactPath(pattern, path)
+	}
+
+	// Check for recursive wildcard patterns
+	if strings.Contains(pattern, RecursiveWildcard) {
+		return matcher.matchRecursivePattern(patternParts, pathParts)
+	}
+
+	return matcher.matchWildcardPattern(patternParts, pathParts)
+}
+
+// pathMatcher handles path matching with configurable case sensitivity
+type pathMatcher struct {
+	caseSensitive bool
+}
+
+// matchExactPath performs exact path matching
+func (pm pathMatcher) matchExactPath(pattern, path string) bool {
+	if pm.caseSensitive {
+		return pattern == path
+	}
+	return strings.EqualFold(pattern, path)
+}
+
+// matchWildcardPattern performs wildcard pattern matching
+func (pm pathMatcher) matchWildcardPattern(patternParts, pathParts []string) bool {
+	if !isValidSegmentCount(patternParts, pathParts) {
+		return false
+	}
+
+	return pm.matchSegments(patternParts, pathParts)
+}
+
+// isValidSegmentCount checks if segment counts are compatible for single wildcards
+func isValidSegmentCount(patternParts, pathParts []string) bool {
+	// For single wildcard patterns, the segment count must match exactly
+	// OR for collection access, allow one less segment (e.g., /users/* matches /users)
+	return len(patternParts) == len(pathParts) ||
+		(len(patternParts) > 0 && len(pathParts) == len(patternParts)-1 &&
+			patternParts[len(patternParts)-1] == WildcardChar)
+}
+
+// matchSegments compares pattern segments with path segments
+func (pm pathMatcher) matchSegments(patternParts, pathParts []string) bool {
+	// Handle collection access case: /users/* matches /users
+	if pm.isCollectionAccess(patternParts, pathParts) {
+		return pm.matchCollectionSegments(patternParts, pathParts)
+	}
+
+	return pm.matchNormalSegments(patternParts, pathParts)
+}
+
+// isCollectionAccess checks if this is a collection access pattern
+func (*pathMatcher) isCollectionAccess(patternParts, pathParts []string) bool {
+	return len(pathParts) == len(patternParts)-1 && len(patternParts) > 0 &&
+		patternParts[len(patternParts)-1] == WildcardChar
+}
+
+// matchCollectionSegments matches collection access patterns
+func (pm pathMatcher) matchCollectionSegments(patternParts, pathParts []string) bool {
+	for i := 0; i < len(pathParts); i++ {
+		if !pm.segmentMatches(patternParts[i], pathParts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNormalSegments matches normal patterns with exact segment counts
+func (pm pathMatcher) matchNormalSegments(patternParts, pathParts []string) bool {
+	maxLen := len(patternParts)
+	if len(pathParts) < maxLen {
+		maxLen = len(pathParts)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		if patternParts[i] == WildcardChar {
+			continue
+		}
+		if !pm.segmentMatches(patternParts[i], pathParts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// segmentMatches checks if a single segment matches
+func (pm pathMatcher) segmentMatches(pattern, path string) bool {
+	if pm.caseSensitive {
+		return pattern == path
+	}
+	return strings.EqualFold(pattern, path)
+}
+
+// matchRecursivePattern handles patterns with ** recursive wildcards
+func (pm pathMatcher) matchRecursivePattern(patternParts, pathParts []string) bool {
+	return pm.matchRecursiveSegments(patternParts, pathParts, 0, 0)
+}
+
+// matchRecursiveSegments recursively matches pattern segments with path segments
+func (pm pathMatcher) matchRecursiveSegments(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
+	// Check if all patterns consumed
+	if patternIdx >= len(patternParts) {
+		return pathIdx >= len(pathParts)
+	}
+
+	// Check if all paths consumed but patterns remain
+	if pathIdx >= len(pathParts) {
+		return allRemainingAreRecursiveWildcards(patternParts, patternIdx)
+	}
+
+	currentPattern := patternParts[patternIdx]
+
+	switch currentPattern {
+	case RecursiveWildcard:
+		return pm.handleRecursiveWildcard(patternParts, pathParts, patternIdx, pathIdx)
+	case WildcardChar:
+		return pm.handleSingleWildcard(patternParts, pathParts, patternIdx, pathIdx)
+	default:
+		return pm.handleExactMatch(patternParts, pathParts, patternIdx, pathIdx, currentPattern)
+	}
+}
+
+// allRemainingAreRecursiveWildcards checks if remaining pattern parts are all ** wildcards
+func allRemainingAreRecursiveWildcards(patternParts []string, patternIdx int) bool {
+	for i := patternIdx; i < len(patternParts); i++ {
+		if patternParts[i] != RecursiveWildcard {
+			return false
+		}
+	}
+	return true
+}
+
+// handleRecursiveWildcard processes ** wildcards
+func (pm pathMatcher) handleRecursiveWildcard(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
+	// ** can match zero or more segments
+	for i := pathIdx; i <= len(pathParts); i++ {
+		if pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSingleWildcard processes * wildcards
+func (pm pathMatcher) handleSingleWildcard(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
+	// * matches exactly one segment
+	return pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, pathIdx+1)
+}
+
+// handleExactMatch processes exact segment matches
+func (pm pathMatcher) handleExactMatch(
+	patternParts, pathParts []string, patternIdx, pathIdx int, currentPattern string,
+) bool {
+	if pm.segmentMatches(currentPattern, pathParts[pathIdx]) {
+		return pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, pathIdx+1)
+	}
+	return false
+}
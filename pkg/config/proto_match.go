@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// anyMinorVersion marks a protocol expression without a minor version, such as "HTTP/2"
+const anyMinorVersion = -1
+
+// ParseProtoMatch parses a protocol expression of a scenario's proto_match: "HTTP/1.1" matches that
+// exact version while "HTTP/2" leaves out the minor version and matches any 2.x. Case is ignored.
+// minor is -1 when the expression has no minor version.
+func ParseProtoMatch(expression string) (major, minor int, err error) {
+	proto := strings.ToUpper(strings.TrimSpace(expression))
+	if major, minor, ok := http.ParseHTTPVersion(proto); ok {
+		return major, minor, nil
+	}
+	version, found := strings.CutPrefix(proto, "HTTP/")
+	if !found {
+		return 0, 0, fmt.Errorf("%q is not an HTTP version like HTTP/1.1 or HTTP/2", expression)
+	}
+	major, err = strconv.Atoi(version)
+	if err != nil || major < 0 {
+		return 0, 0, fmt.Errorf("%q is not an HTTP version like HTTP/1.1 or HTTP/2", expression)
+	}
+	return major, anyMinorVersion, nil
+}
+
+// ProtoMatches reports whether a request of the given protocol version matches the expression
+// (see ParseProtoMatch). An empty expression matches any protocol, an invalid one none.
+func ProtoMatches(expression string, major, minor int) bool {
+	if expression == "" {
+		return true
+	}
+	wantMajor, wantMinor, err := ParseProtoMatch(expression)
+	if err != nil {
+		return false
+	}
+	return wantMajor == major && (wantMinor == anyMinorVersion || wantMinor == minor)
+}
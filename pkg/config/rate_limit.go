@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Rate limit partitions of RateLimit.Per
+const (
+	// RateLimitPerSection shares one limit among all clients of the section (default)
+	RateLimitPerSection = "section"
+	// RateLimitPerClient gives every client address its own limit
+	RateLimitPerClient = "client"
+	// RateLimitPerHeader gives every value of RateLimit.Header, e.g. an API key, its own limit
+	RateLimitPerHeader = "header"
+)
+
+// DefaultRateLimitHeader is the header partitioning limits per API key when RateLimit.Header is unset
+const DefaultRateLimitHeader = "X-API-Key"
+
+// RateLimit enforces a request rate on a section with a token bucket holding Requests tokens that
+// refills at Requests per Window, so bursts of up to Requests are served at once and the sustained
+// rate is Requests per Window. Requests finding the bucket empty are answered with 429 Too Many Requests.
+type RateLimit struct {
+	// Requests is the number of requests allowed per window, and the largest burst
+	Requests int `yaml:"requests" json:"requests"`
+
+	// Window is the period the requests are allowed in, e.g. 1m
+	Window time.Duration `yaml:"window" json:"window"`
+
+	// Per partitions the limit: RateLimitPerSection, RateLimitPerClient or RateLimitPerHeader
+	Per string `yaml:"per,omitempty" json:"per,omitempty"`
+
+	// Header names the request header whose value identifies the caller when Per is
+	// RateLimitPerHeader (default: DefaultRateLimitHeader)
+	Header string `yaml:"header,omitempty" json:"header,omitempty"`
+}
+
+// HeaderName returns the header partitioning the limit per caller
+func (r *RateLimit) HeaderName() string {
+	if r.Header == "" {
+		return DefaultRateLimitHeader
+	}
+	return http.CanonicalHeaderKey(r.Header)
+}
+
+// Validate checks that the limit and window are positive and the partition is known
+func (r *RateLimit) Validate() error {
+	if r.Requests <= 0 {
+		return fmt.Errorf("rate_limit requests must be positive, got %d", r.Requests)
+	}
+	if r.Window <= 0 {
+		return errors.New("rate_limit window must be positive")
+	}
+	switch r.Per {
+	case "", RateLimitPerSection, RateLimitPerClient, RateLimitPerHeader:
+		return nil
+	default:
+		return fmt.Errorf("rate_limit per must be %q, %q or %q, got %q",
+			RateLimitPerSection, RateLimitPerClient, RateLimitPerHeader, r.Per)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// RequestLineCapturePrefix names the unnamed groups of a request line expression in templates:
+// {{.Line1}} is the first group, {{.Line2}} the second and so on. Named groups also use their name.
+const RequestLineCapturePrefix = "Line"
+
+// requestLineExpressions caches compiled request line expressions by source
+var requestLineExpressions sync.Map
+
+// RequestLine formats the request line that scenarios with match_request_line are matched against:
+// the method and the raw path with query as sent, e.g. "GET /search?q=shoes&page=2"
+func RequestLine(req *http.Request) string {
+	return req.Method + " " + req.URL.RequestURI()
+}
+
+// CompileRequestLine compiles a request line expression, reusing earlier compilations
+func CompileRequestLine(expression string) (*regexp.Regexp, error) {
+	if cached, ok := requestLineExpressions.Load(expression); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	requestLineExpressions.Store(expression, compiled)
+	return compiled, nil
+}
+
+// MatchRequestLine matches the request line against the expression and returns its capture groups
+// as template fields (see RequestLineCapturePrefix). ok is false when the line does not match or the
+// expression is invalid.
+func MatchRequestLine(expression, line string) (fields map[string]any, ok bool) {
+	compiled, err := CompileRequestLine(expression)
+	if err != nil {
+		return nil, false
+	}
+	match := compiled.FindStringSubmatch(line)
+	if match == nil {
+		return nil, false
+	}
+	fields = make(map[string]any, 2*len(match))
+	for i, name := range compiled.SubexpNames() {
+		if i == 0 {
+			continue
+		}
+		fields[RequestLineCapturePrefix+strconv.Itoa(i)] = match[i]
+		if name != "" {
+			fields[name] = match[i]
+		}
+	}
+	return fields, true
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxSchemaDepth bounds the nesting of generated examples, so recursive $refs terminate
+const maxSchemaDepth = 16
+
+// schemaFormatExamples are the sample values of string formats
+var schemaFormatExamples = map[string]string{
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"time":      "00:00:00",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"uuid":      "00000000-0000-4000-8000-000000000000",
+}
+
+// schemaExamples caches the examples generated by SchemaExample by schema source
+var schemaExamples sync.Map
+
+// SchemaExample generates a deterministic JSON example that satisfies a JSON Schema, for scenarios
+// with a response schema but no data. Values come from const, default, example, examples or enum
+// when present, otherwise from the type: formatted sample strings (see schemaFormatExamples), the
+// minimum for numbers, true, one item per array (or minItems) and every property of objects. allOf
+// schemas are merged, the first anyOf/oneOf alternative is used and local $refs are resolved.
+// Examples are generated once per schema.
+func SchemaExample(schema json.RawMessage) ([]byte, error) {
+	if cached, ok := schemaExamples.Load(string(schema)); ok {
+		return cached.([]byte), nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(schema, &root); err != nil || root == nil {
+		return nil, errors.New("schema must be a JSON object")
+	}
+	generator := schemaGenerator{root: root}
+	example, err := json.Marshal(generator.example(root, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema example: %w", err)
+	}
+	schemaExamples.Store(string(schema), example)
+	return example, nil
+}
+
+// schemaGenerator generates examples for the subschemas of a root schema
+type schemaGenerator struct {
+	root map[string]any
+}
+
+// example returns a sample value for the schema
+func (g schemaGenerator) example(schema map[string]any, depth int) any {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+	schema = g.resolve(schema, depth)
+	for _, key := range []string{"const", "default", "example"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+	}
+	for _, key := range []string{"examples", "enum"} {
+		if values, ok := schema[key].([]any); ok && len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return g.objectExample(schema, depth)
+	case "array":
+		return g.arrayExample(schema, depth)
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		return math.Ceil(numberExample(schema))
+	case "number":
+		return numberExample(schema)
+	case "boolean":
+		return true
+	default:
+		return nil
+	}
+}
+
+// resolve follows a local $ref and merges the allOf parts and first anyOf/oneOf alternative into the schema
+func (g schemaGenerator) resolve(schema map[string]any, depth int) map[string]any {
+	if ref, ok := schema["$ref"].(string); ok {
+		if target := g.lookup(ref); target != nil && depth <= maxSchemaDepth {
+			return g.resolve(target, depth+1)
+		}
+	}
+	allOf, _ := schema["allOf"].([]any)
+	parts := append([]any(nil), allOf...)
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if alternatives, ok := schema[key].([]any); ok && len(alternatives) > 0 {
+			parts = append(parts, alternatives[0])
+		}
+	}
+	for _, part := range parts {
+		if partSchema, ok := part.(map[string]any); ok {
+			schema = mergeSchemas(schema, g.resolve(partSchema, depth+1))
+		}
+	}
+	return schema
+}
+
+// lookup finds the subschema a local reference such as "#/$defs/user" points to
+func (g schemaGenerator) lookup(ref string) map[string]any {
+	path, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+	var node any = g.root
+	for _, segment := range strings.Split(path, PathSeparator) {
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = object[strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")]
+	}
+	target, _ := node.(map[string]any)
+	return target
+}
+
+// objectExample generates every property of an object schema
+func (g schemaGenerator) objectExample(schema map[string]any, depth int) any {
+	properties, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	object := make(map[string]any, len(names))
+	for _, name := range names {
+		if property, ok := properties[name].(map[string]any); ok {
+			object[name] = g.example(property, depth+1)
+		}
+	}
+	return object
+}
+
+// arrayExample generates minItems items, or a single one, of an array schema
+func (g schemaGenerator) arrayExample(schema map[string]any, depth int) any {
+	items, _ := schema["items"].(map[string]any)
+	count := 1
+	if minItems, ok := schema["minItems"].(float64); ok && minItems >= 0 {
+		count = int(minItems)
+	}
+	if items == nil {
+		return []any{}
+	}
+	array := make([]any, count)
+	for i := range array {
+		array[i] = g.example(items, depth+1)
+	}
+	return array
+}
+
+// stringExample returns the sample of the string's format, padded to minLength
+func stringExample(schema map[string]any) string {
+	format, _ := schema["format"].(string)
+	value, ok := schemaFormatExamples[format]
+	if !ok {
+		value = "string"
+	}
+	if minLength, ok := schema["minLength"].(float64); ok && len(value) < int(minLength) {
+		value += strings.Repeat("x", int(minLength)-len(value))
+	}
+	return value
+}
+
+// numberExample returns zero moved into the schema's minimum and maximum bounds
+func numberExample(schema map[string]any) float64 {
+	if minimum, ok := schema["minimum"].(float64); ok && minimum > 0 {
+		return minimum
+	}
+	if maximum, ok := schema["maximum"].(float64); ok && maximum < 0 {
+		return maximum
+	}
+	return 0
+}
+
+// schemaType returns the schema's type, the first non-null one of a type list, or object for
+// untyped schemas with properties
+func schemaType(schema map[string]any) string {
+	switch typ := schema["type"].(type) {
+	case string:
+		return typ
+	case []any:
+		for _, candidate := range typ {
+			if name, ok := candidate.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// mergeSchemas combines two schemas, merging their properties; other keywords of extra fill in
+// those base lacks
+func mergeSchemas(base, extra map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(extra))
+	for key, value := range extra {
+		merged[key] = value
+	}
+	for key, value := range base {
+		if key == "allOf" || key == "anyOf" || key == "oneOf" {
+			continue
+		}
+		merged[key] = value
+	}
+	baseProperties, _ := base["properties"].(map[string]any)
+	extraProperties, _ := extra["properties"].(map[string]any)
+	if len(baseProperties) > 0 && len(extraProperties) > 0 {
+		properties := make(map[string]any, len(baseProperties)+len(extraProperties))
+		for name, property := range extraProperties {
+			properties[name] = property
+		}
+		for name, property := range baseProperties {
+			properties[name] = property
+		}
+		merged["properties"] = properties
+	}
+	return merged
+}
+
+// responseSchemaJSON encodes the scenario's response schema for model.Scenario
+func (sf *ScenarioConfig) responseSchemaJSON() json.RawMessage {
+	if sf.ResponseSchema == nil {
+		return nil
+	}
+	schema, err := json.Marshal(sf.ResponseSchema)
+	if err != nil {
+		return nil
+	}
+	return schema
+}
+
+// responseSchemaFromJSON decodes a model.Scenario response schema for ScenarioConfig
+func responseSchemaFromJSON(schema json.RawMessage) map[string]any {
+	var decoded map[string]any
+	if err := json.Unmarshal(schema, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
@@ -0,0 +1,72 @@
+package config_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaExample(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name: "object with typed and formatted properties",
+			schema: `{"type": "object", "properties": {
+				"id": {"type": "string", "format": "uuid"},
+				"email": {"type": "string", "format": "email"},
+				"createdAt": {"type": "string", "format": "date-time"},
+				"age": {"type": "integer", "minimum": 18},
+				"score": {"type": "number"},
+				"active": {"type": "boolean"},
+				"nickname": {"type": ["null", "string"], "minLength": 8}
+			}}`,
+			want: `{"id": "00000000-0000-4000-8000-000000000000", "email": "user@example.com",
+				"createdAt": "2024-01-01T00:00:00Z", "age": 18, "score": 0, "active": true, "nickname": "stringxx"}`,
+		},
+		{
+			name:   "declared values win over the type",
+			schema: `{"properties": {"a": {"type": "string", "enum": ["x", "y"]}, "b": {"const": 7}, "c": {"default": "d"}}}`,
+			want:   `{"a": "x", "b": 7, "c": "d"}`,
+		},
+		{
+			name:   "arrays",
+			schema: `{"type": "array", "minItems": 2, "items": {"type": "integer"}}`,
+			want:   `[0, 0]`,
+		},
+		{
+			name: "local references and composition",
+			schema: `{"$defs": {"user": {"type": "object", "properties": {"name": {"type": "string"}}}},
+				"allOf": [{"$ref": "#/$defs/user"}, {"properties": {"role": {"oneOf": [{"const": "admin"}, {"const": "user"}]}}}]}`,
+			want: `{"name": "string", "role": "admin"}`,
+		},
+		{
+			name:   "recursive reference terminates",
+			schema: `{"$defs": {"node": {"properties": {"next": {"$ref": "#/$defs/node"}}}}, "$ref": "#/$defs/node"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			example, err := config.SchemaExample(json.RawMessage(tt.schema))
+			require.NoError(t, err)
+			require.True(t, json.Valid(example))
+			if tt.want != "" {
+				assert.JSONEq(t, tt.want, string(example))
+			}
+
+			again, err := config.SchemaExample(json.RawMessage(tt.schema))
+			require.NoError(t, err)
+			assert.Equal(t, example, again, "examples are deterministic")
+		})
+	}
+}
+
+func TestSchemaExample_InvalidSchema(t *testing.T) {
+	_, err := config.SchemaExample(json.RawMessage(`["not", "an", "object"]`))
+	assert.ErrorContains(t, err, "JSON object")
+}
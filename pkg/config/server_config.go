@@ -2,7 +2,26 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// DefaultExpirySweepInterval is how often expired resources of sections with a TTL are purged
+	DefaultExpirySweepInterval = time.Minute
+
+	// DefaultLogBodyMaxBytes is how much of a request or response body is logged before truncation
+	DefaultLogBodyMaxBytes = 4096
+
+	// Default HTTP server timeouts, guarding against clients that send or read too slowly
+	DefaultReadTimeout       = 10 * time.Second
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultWriteTimeout      = 10 * time.Second
+	DefaultIdleTimeout       = 120 * time.Second
+
+	// DefaultDrainPeriod is how long a shutting down server answers 503 before it stops accepting connections
+	DefaultDrainPeriod = time.Second
 )
 
 // ServerConfig holds the basic server configuration options
@@ -23,6 +42,133 @@ type ServerConfig struct {
 	// Path to configuration file (default: "config.yaml")
 	// This controls where the mock configuration YAML file is located
 	ConfigPath string `yaml:"config_path" json:"config_path"`
+
+	// TLSCertFile and TLSKeyFile are paths to a PEM certificate and private key.
+	// When both are set the server listens on HTTPS instead of HTTP.
+	TLSCertFile string `yaml:"tls_cert_file" json:"tls_cert_file"`
+	TLSKeyFile  string `yaml:"tls_key_file" json:"tls_key_file"`
+
+	// TLSAutoCert serves HTTPS with a self-signed certificate generated at startup,
+	// valid for localhost and 127.0.0.1. Ignored when TLSCertFile and TLSKeyFile are set.
+	TLSAutoCert bool `yaml:"tls_auto_cert" json:"tls_auto_cert"`
+
+	// AdminAPIKey protects the /_uni management endpoints when set:
+	// requests must carry it in the X-Unimock-Key header or get 401. Mock endpoints stay open.
+	AdminAPIKey string `yaml:"admin_api_key" json:"admin_api_key"`
+
+	// DeterministicIDs replaces random UUIDs for generated resource IDs with a sequence
+	// (00000000-0000-0000-0000-000000000001, ...) so tests can assert exact Location values.
+	// The sequence starts after IDSeed.
+	DeterministicIDs bool   `yaml:"deterministic_ids" json:"deterministic_ids"`
+	IDSeed           uint64 `yaml:"id_seed" json:"id_seed"`
+
+	// ExpirySweepInterval is how often the background sweeper purges expired resources
+	// of sections with a TTL (default: DefaultExpirySweepInterval)
+	ExpirySweepInterval time.Duration `yaml:"expiry_sweep_interval" json:"expiry_sweep_interval"`
+
+	// LogBodies records request and response bodies in the debug log.
+	// Fields of JSON bodies selected by LogRedactPaths ("$.user.password" or "/user/password")
+	// are masked, and bodies longer than LogBodyMaxBytes are truncated (default: DefaultLogBodyMaxBytes).
+	LogBodies       bool     `yaml:"log_bodies" json:"log_bodies"`
+	LogRedactPaths  []string `yaml:"log_redact_paths" json:"log_redact_paths"`
+	LogBodyMaxBytes int      `yaml:"log_body_max_bytes" json:"log_body_max_bytes"`
+
+	// ReadTimeout, ReadHeaderTimeout, WriteTimeout and IdleTimeout are applied to the http.Server;
+	// zero uses DefaultReadTimeout, DefaultReadHeaderTimeout, DefaultWriteTimeout and DefaultIdleTimeout.
+	// Sections that throttle or delay responses need a WriteTimeout longer than the slowest response.
+	ReadTimeout       time.Duration `yaml:"read_timeout" json:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" json:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" json:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
+
+	// MaxConnections caps the number of concurrent client connections, simulating a backend whose
+	// connection pool is exhausted: further connections are not served until others close (default: 0, unlimited)
+	MaxConnections int `yaml:"max_connections" json:"max_connections"`
+
+	// MaxResources caps the number of stored resources, keeping memory bounded when tests create
+	// resources without cleaning up (default: 0, unlimited). When the cap is reached, EvictionPolicy
+	// decides between EvictionOldest, which evicts the earliest created resource (default), and
+	// EvictionReject, which answers further creates with 507 Insufficient Storage.
+	MaxResources   int    `yaml:"max_resources" json:"max_resources"`
+	EvictionPolicy string `yaml:"eviction_policy" json:"eviction_policy"`
+
+	// PrettyJSON indents JSON bodies of mock and scenario responses for readability; other bodies
+	// and invalid JSON are sent unchanged
+	PrettyJSON bool `yaml:"pretty_json" json:"pretty_json"`
+
+	// Gzip compresses textual responses, errors included, for clients accepting gzip
+	Gzip bool `yaml:"gzip" json:"gzip"`
+
+	// RejectUnsafeHeaders answers 500 to responses whose headers contain CR or LF, e.g. from a templated
+	// Location, instead of stripping the line breaks; a test mode for asserting header injection is caught
+	RejectUnsafeHeaders bool `yaml:"reject_unsafe_headers" json:"reject_unsafe_headers"`
+
+	// SniffContentType treats request bodies sent without a Content-Type header as JSON when they start
+	// with { or [ and as XML when they start with <, instead of leaving them untyped, so IDs are extracted
+	SniffContentType bool `yaml:"sniff_content_type" json:"sniff_content_type"`
+
+	// DisableKeepAlives closes every connection after one response, for testing client connection pools
+	DisableKeepAlives bool `yaml:"disable_keep_alives" json:"disable_keep_alives"`
+
+	// MaxRequestsPerConnection closes a connection after it has served this many requests
+	// (default: 0, unlimited)
+	MaxRequestsPerConnection int `yaml:"max_requests_per_connection" json:"max_requests_per_connection"`
+
+	// StartupDelay answers every request but the /_uni/health liveness probe with 503 and Retry-After
+	// for this long after the server is created, simulating a backend that is slow to warm up; the
+	// /_uni/ready readiness probe fails until then (default: 0, ready at once)
+	StartupDelay time.Duration `yaml:"startup_delay" json:"startup_delay"`
+
+	// DrainPeriod is how long Shutdown answers every request but the liveness probe with 503 and
+	// Retry-After before the server stops accepting connections (default: DefaultDrainPeriod)
+	DrainPeriod time.Duration `yaml:"drain_period" json:"drain_period"`
+
+	// TrailingSlash selects how paths ending in a slash are handled: TrailingSlashStrip ignores the
+	// slash (default), TrailingSlashPreserve matches and stores /users/ apart from /users, and
+	// TrailingSlashRedirect answers 301 Moved Permanently to the path without the slash
+	TrailingSlash string `yaml:"trailing_slash" json:"trailing_slash"`
+
+	// LenientEnv lets the configuration file reference undefined environment variables without a
+	// default; they expand to an empty string instead of failing LoadFromYAML (see WithLenientEnv).
+	LenientEnv bool `yaml:"lenient_env" json:"lenient_env"`
+
+	// FixtureFetchTimeout bounds fetching URL fixtures (@https://...) when the configuration is loaded
+	// (default: DefaultFixtureFetchTimeout). FixtureCacheDir keeps fetched fixtures on disk so later
+	// loads read them from there instead of fetching them again (see WithFixtureCacheDir).
+	FixtureFetchTimeout time.Duration `yaml:"fixture_fetch_timeout" json:"fixture_fetch_timeout"`
+	FixtureCacheDir     string        `yaml:"fixture_cache_dir" json:"fixture_cache_dir"`
+
+	// ValidateOnly checks the configuration file, prints a report and exits instead of starting the
+	// server; the exit code is non-zero when the configuration has problems
+	ValidateOnly bool `yaml:"validate_only" json:"validate_only"`
+}
+
+// LoadOptions returns the LoadFromYAML options matching the server configuration
+func (c *ServerConfig) LoadOptions() []LoadOption {
+	var opts []LoadOption
+	if c.LenientEnv {
+		opts = append(opts, WithLenientEnv())
+	}
+	if c.FixtureFetchTimeout > 0 {
+		opts = append(opts, WithFixtureFetchTimeout(c.FixtureFetchTimeout))
+	}
+	if c.FixtureCacheDir != "" {
+		opts = append(opts, WithFixtureCacheDir(c.FixtureCacheDir))
+	}
+	return opts
+}
+
+// TLSEnabled reports whether the server listens on HTTPS
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSAutoCert || c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+// Scheme returns the URL scheme clients use to reach the server: "https" or "http"
+func (c *ServerConfig) Scheme() string {
+	if c.TLSEnabled() {
+		return "https"
+	}
+	return "http"
 }
 
 // NewDefaultServerConfig creates a ServerConfig with default values
@@ -32,14 +178,51 @@ func NewDefaultServerConfig() *ServerConfig {
 		Port:       "8080",
 		LogLevel:   "info",
 		ConfigPath: "config.yaml",
+
+		ExpirySweepInterval: DefaultExpirySweepInterval,
+		LogBodyMaxBytes:     DefaultLogBodyMaxBytes,
+
+		ReadTimeout:       DefaultReadTimeout,
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
+		WriteTimeout:      DefaultWriteTimeout,
+		IdleTimeout:       DefaultIdleTimeout,
+
+		DrainPeriod: DefaultDrainPeriod,
 	}
 }
 
 // FromEnv creates a ServerConfig from environment variables.
 // It reads:
-// - UNIMOCK_PORT: Port to listen on (default: "8080")
-// - UNIMOCK_LOG_LEVEL: Log level (default: "info")
-// - UNIMOCK_CONFIG: Path to configuration file (default: "config.yaml")
+//   - UNIMOCK_PORT: Port to listen on (default: "8080")
+//   - UNIMOCK_LOG_LEVEL: Log level (default: "info")
+//   - UNIMOCK_CONFIG: Path to configuration file (default: "config.yaml")
+//   - UNIMOCK_TLS_CERT_FILE, UNIMOCK_TLS_KEY_FILE: Certificate and key for HTTPS
+//   - UNIMOCK_TLS_AUTO_CERT: "true" to serve HTTPS with a self-signed certificate
+//   - UNIMOCK_ADMIN_API_KEY: Key required by /_uni management endpoints (default: none)
+//   - UNIMOCK_DETERMINISTIC_IDS: "true" to generate sequential instead of random IDs
+//   - UNIMOCK_ID_SEED: Number of IDs the deterministic sequence skips (default: 0)
+//   - UNIMOCK_EXPIRY_SWEEP_INTERVAL: How often expired resources are purged, e.g. "30s" (default: "1m")
+//   - UNIMOCK_LOG_BODIES: "true" to log request and response bodies at debug level
+//   - UNIMOCK_LOG_REDACT_PATHS: Comma-separated JSON paths masked in logged bodies
+//   - UNIMOCK_LOG_BODY_MAX_BYTES: Bytes of a body logged before truncation (default: 4096)
+//   - UNIMOCK_READ_TIMEOUT, UNIMOCK_READ_HEADER_TIMEOUT, UNIMOCK_WRITE_TIMEOUT, UNIMOCK_IDLE_TIMEOUT:
+//     HTTP server timeouts, e.g. "30s" (defaults: "10s", "5s", "10s", "2m")
+//   - UNIMOCK_MAX_CONNECTIONS: Concurrent connections served before others wait (default: 0, unlimited)
+//   - UNIMOCK_MAX_RESOURCES: Number of stored resources before eviction starts (default: 0, unlimited)
+//   - UNIMOCK_EVICTION_POLICY: "oldest" or "reject" once UNIMOCK_MAX_RESOURCES is reached (default: "oldest")
+//   - UNIMOCK_PRETTY_JSON: "true" to indent JSON response bodies
+//   - UNIMOCK_GZIP: "true" to gzip responses for clients accepting it
+//   - UNIMOCK_REJECT_UNSAFE_HEADERS: "true" to answer 500 instead of stripping CR/LF from header values
+//   - UNIMOCK_SNIFF_CONTENT_TYPE: "true" to infer JSON or XML for request bodies without Content-Type
+//   - UNIMOCK_DISABLE_KEEP_ALIVES: "true" to close every connection after one response
+//   - UNIMOCK_MAX_REQUESTS_PER_CONNECTION: Number of requests after which a connection is closed
+//   - UNIMOCK_STARTUP_DELAY: How long non-health endpoints answer 503 after startup, e.g. "5s"
+//   - UNIMOCK_DRAIN_PERIOD: How long shutdown answers 503 before closing, e.g. "5s" (default: "1s")
+//   - UNIMOCK_TRAILING_SLASH: "strip", "preserve" or "redirect" (default: "strip")
+//   - UNIMOCK_LENIENT_ENV: "true" to expand undefined ${VAR} references in the config file to ""
+//   - UNIMOCK_FIXTURE_FETCH_TIMEOUT: How long fetching a URL fixture may take, e.g. "10s" (default: "30s")
+//   - UNIMOCK_FIXTURE_CACHE_DIR: Directory keeping fetched URL fixtures between loads (default: none)
+//   - UNIMOCK_VALIDATE: "true" or "1" to validate the config file and exit instead of serving
 //
 // If an environment variable is not set, the default value is used.
 func FromEnv() *ServerConfig {
@@ -62,7 +245,61 @@ func FromEnv() *ServerConfig {
 		cfg.ConfigPath = configPath
 	}
 
+	cfg.TLSCertFile = os.Getenv("UNIMOCK_TLS_CERT_FILE")
+	cfg.TLSKeyFile = os.Getenv("UNIMOCK_TLS_KEY_FILE")
+	cfg.TLSAutoCert = strings.EqualFold(os.Getenv("UNIMOCK_TLS_AUTO_CERT"), "true")
+	cfg.AdminAPIKey = os.Getenv("UNIMOCK_ADMIN_API_KEY")
+	cfg.DeterministicIDs = strings.EqualFold(os.Getenv("UNIMOCK_DETERMINISTIC_IDS"), "true")
+	if seed, err := strconv.ParseUint(os.Getenv("UNIMOCK_ID_SEED"), 10, 64); err == nil {
+		cfg.IDSeed = seed
+	}
+	if interval, err := time.ParseDuration(os.Getenv("UNIMOCK_EXPIRY_SWEEP_INTERVAL")); err == nil && interval > 0 {
+		cfg.ExpirySweepInterval = interval
+	}
+	cfg.LogBodies = strings.EqualFold(os.Getenv("UNIMOCK_LOG_BODIES"), "true")
+	for _, path := range strings.Split(os.Getenv("UNIMOCK_LOG_REDACT_PATHS"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			cfg.LogRedactPaths = append(cfg.LogRedactPaths, path)
+		}
+	}
+	if maxBytes, err := strconv.Atoi(os.Getenv("UNIMOCK_LOG_BODY_MAX_BYTES")); err == nil && maxBytes > 0 {
+		cfg.LogBodyMaxBytes = maxBytes
+	}
+	if maxConns, err := strconv.Atoi(os.Getenv("UNIMOCK_MAX_CONNECTIONS")); err == nil && maxConns > 0 {
+		cfg.MaxConnections = maxConns
+	}
+	if maxResources, err := strconv.Atoi(os.Getenv("UNIMOCK_MAX_RESOURCES")); err == nil && maxResources > 0 {
+		cfg.MaxResources = maxResources
+	}
+	cfg.EvictionPolicy = strings.ToLower(os.Getenv("UNIMOCK_EVICTION_POLICY"))
+	durationFromEnv("UNIMOCK_READ_TIMEOUT", &cfg.ReadTimeout)
+	durationFromEnv("UNIMOCK_READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout)
+	durationFromEnv("UNIMOCK_WRITE_TIMEOUT", &cfg.WriteTimeout)
+	durationFromEnv("UNIMOCK_IDLE_TIMEOUT", &cfg.IdleTimeout)
+	cfg.PrettyJSON = strings.EqualFold(os.Getenv("UNIMOCK_PRETTY_JSON"), "true")
+	cfg.Gzip = strings.EqualFold(os.Getenv("UNIMOCK_GZIP"), "true")
+	cfg.RejectUnsafeHeaders = strings.EqualFold(os.Getenv("UNIMOCK_REJECT_UNSAFE_HEADERS"), "true")
+	cfg.SniffContentType = strings.EqualFold(os.Getenv("UNIMOCK_SNIFF_CONTENT_TYPE"), "true")
+	cfg.DisableKeepAlives = strings.EqualFold(os.Getenv("UNIMOCK_DISABLE_KEEP_ALIVES"), "true")
+	if maxRequests, err := strconv.Atoi(os.Getenv("UNIMOCK_MAX_REQUESTS_PER_CONNECTION")); err == nil && maxRequests > 0 {
+		cfg.MaxRequestsPerConnection = maxRequests
+	}
+	durationFromEnv("UNIMOCK_STARTUP_DELAY", &cfg.StartupDelay)
+	durationFromEnv("UNIMOCK_DRAIN_PERIOD", &cfg.DrainPeriod)
+	cfg.TrailingSlash = strings.ToLower(os.Getenv("UNIMOCK_TRAILING_SLASH"))
+	cfg.LenientEnv = strings.EqualFold(os.Getenv("UNIMOCK_LENIENT_ENV"), "true")
+	durationFromEnv("UNIMOCK_FIXTURE_FETCH_TIMEOUT", &cfg.FixtureFetchTimeout)
+	cfg.FixtureCacheDir = os.Getenv("UNIMOCK_FIXTURE_CACHE_DIR")
+	cfg.ValidateOnly, _ = strconv.ParseBool(os.Getenv("UNIMOCK_VALIDATE"))
+
 	// UNIMOCK_SCENARIOS_FILE is ignored - scenarios are loaded from unified config
 
 	return cfg
 }
+
+// durationFromEnv overwrites target with the positive duration in the environment variable, if set
+func durationFromEnv(name string, target *time.Duration) {
+	if d, err := time.ParseDuration(os.Getenv(name)); err == nil && d > 0 {
+		*target = d
+	}
+}
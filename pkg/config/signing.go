@@ -0,0 +1,31 @@
+package config
+
+import "errors"
+
+// DefaultSignatureHeader is the response header carrying the signature when none is configured
+const DefaultSignatureHeader = "X-Signature"
+
+// SignConfig configures HMAC signing of response bodies, e.g. to test webhook signature verification
+type SignConfig struct {
+	// Secret is the HMAC-SHA256 key
+	Secret string `yaml:"secret" json:"secret"`
+
+	// Header names the response header carrying the hex-encoded signature (default: X-Signature)
+	Header string `yaml:"header,omitempty" json:"header,omitempty"`
+}
+
+// HeaderName returns the configured signature header or DefaultSignatureHeader
+func (c *SignConfig) HeaderName() string {
+	if c.Header == "" {
+		return DefaultSignatureHeader
+	}
+	return c.Header
+}
+
+// Validate checks that a signing secret is configured
+func (c *SignConfig) Validate() error {
+	if c.Secret == "" {
+		return errors.New("sign_responses requires a secret")
+	}
+	return nil
+}
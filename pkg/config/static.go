@@ -0,0 +1,27 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// StaticFilePath maps a request path onto a file in the section's static_dir.
+// A relative static_dir is resolved against the configuration file's directory, and
+// absolute or traversing request paths are rejected the same way fixture references are.
+func (uc *UniConfig) StaticFilePath(section *Section, requestPath string) (string, error) {
+	relPath := strings.TrimPrefix(requestPath, "/")
+
+	resolver := uc.fixtureResolver
+	if resolver == nil {
+		resolver = NewFixtureResolver(uc.baseDir)
+	}
+	if err := resolver.validateFilePath(relPath); err != nil {
+		return "", err
+	}
+
+	dir := section.StaticDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(uc.baseDir, dir)
+	}
+	return filepath.Join(dir, filepath.FromSlash(relPath)), nil
+}
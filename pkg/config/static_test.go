@@ -0,0 +1,33 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniConfig_StaticFilePath(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yamlContent := `sections:
+  assets:
+    path_pattern: "/assets/**"
+    static_dir: "fixtures"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+	cfg, err := config.LoadFromYAML(configPath)
+	require.NoError(t, err)
+	section := cfg.Sections["assets"]
+
+	filePath, err := cfg.StaticFilePath(&section, "/assets/logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "fixtures", "assets", "logo.png"), filePath)
+
+	_, err = cfg.StaticFilePath(&section, "/assets/../../etc/passwd")
+	var pathErr *config.InvalidFixturePathError
+	require.ErrorAs(t, err, &pathErr)
+}
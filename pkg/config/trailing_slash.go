@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Trailing slash modes of ServerConfig.TrailingSlash
+const (
+	// TrailingSlashStrip ignores trailing slashes, so /users/ and /users are the same path (default)
+	TrailingSlashStrip = "strip"
+	// TrailingSlashPreserve keeps trailing slashes significant: /users/ and /users are different paths
+	TrailingSlashPreserve = "preserve"
+	// TrailingSlashRedirect answers paths with a trailing slash with 301 Moved Permanently to the path without
+	TrailingSlashRedirect = "redirect"
+)
+
+// ValidateTrailingSlash checks that mode is empty or one of the trailing slash modes
+func ValidateTrailingSlash(mode string) error {
+	switch mode {
+	case "", TrailingSlashStrip, TrailingSlashPreserve, TrailingSlashRedirect:
+		return nil
+	default:
+		return fmt.Errorf("trailing slash mode must be %q, %q or %q, got %q",
+			TrailingSlashStrip, TrailingSlashPreserve, TrailingSlashRedirect, mode)
+	}
+}
+
+// PreserveTrailingSlash makes MatchPath tell paths with and without a trailing slash apart: a path
+// ending in a slash only matches patterns ending in one, e.g. /users/ and /users/1/ match "/users/*/",
+// and other paths only patterns without. The root path matches either.
+func (uc *UniConfig) PreserveTrailingSlash() {
+	uc.preserveTrailingSlash = true
+}
+
+// patternFilter returns whether a pattern may serve the path under the trailing slash handling
+func (uc *UniConfig) patternFilter(path string) func(pattern string) bool {
+	if !uc.preserveTrailingSlash || strings.Trim(path, PathSeparator) == "" {
+		return func(string) bool { return true }
+	}
+	slashed := strings.HasSuffix(path, PathSeparator)
+	return func(pattern string) bool {
+		return strings.HasSuffix(pattern, PathSeparator) == slashed
+	}
+}
+
+// PreservesTrailingSlash reports whether PreserveTrailingSlash was called; false for a nil config
+func (uc *UniConfig) PreservesTrailingSlash() bool {
+	return uc != nil && uc.preserveTrailingSlash
+}
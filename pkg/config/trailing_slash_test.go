@@ -0,0 +1,40 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniConfig_PreserveTrailingSlash(t *testing.T) {
+	uniConfig := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"files":   {PathPattern: "/files/*"},
+			"folders": {PathPattern: "/files/*/"},
+		},
+	}
+	matchedSection := func(path string) string {
+		name, _, err := uniConfig.MatchPath(path)
+		require.NoError(t, err)
+		return name
+	}
+
+	assert.NotEmpty(t, matchedSection("/files/a/"), "trailing slashes are ignored by default")
+
+	uniConfig.PreserveTrailingSlash()
+	assert.True(t, uniConfig.PreservesTrailingSlash())
+	assert.Equal(t, "files", matchedSection("/files/a"))
+	assert.Equal(t, "folders", matchedSection("/files/a/"))
+	assert.Equal(t, "folders", matchedSection("/files/"))
+	assert.Equal(t, "files", matchedSection("/files"))
+}
+
+func TestValidateTrailingSlash(t *testing.T) {
+	modes := []string{"", config.TrailingSlashStrip, config.TrailingSlashPreserve, config.TrailingSlashRedirect}
+	for _, mode := range modes {
+		assert.NoError(t, config.ValidateTrailingSlash(mode), mode)
+	}
+	assert.Error(t, config.ValidateTrailingSlash("keep"))
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bmcszk/unimock/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -20,10 +22,19 @@ const (
 	RecursiveWildcard = "**"
 	// PathSeparator represents the separator used in URL paths
 	PathSeparator = "/"
+	// HistorySegment is appended to a resource path of a versioned section to list its previous versions
+	HistorySegment = "history"
 	// noMatch represents an invalid match score
 	noMatch = -1
 )
 
+const (
+	// LocaleFormatNumber formats a field as a locale-grouped number
+	LocaleFormatNumber = "number"
+	// LocaleFormatDate formats a field as a locale date
+	LocaleFormatDate = "date"
+)
+
 // UniConfig represents the configuration for mock behavior
 // It defines how Unimock handles different API endpoints and extracts IDs
 // from various parts of HTTP requests.
@@ -42,6 +53,18 @@ type UniConfig struct {
 
 	// fixtureResolver handles loading fixture files referenced in configuration
 	fixtureResolver *FixtureResolver
+
+	// preserveTrailingSlash makes a trailing slash significant in MatchPath (see PreserveTrailingSlash)
+	preserveTrailingSlash bool
+
+	// newID generates the IDs of generateUUID transforms (see UseIDGenerator); nil for random UUIDs
+	newID func() string
+
+	// onApply holds the functions called after every Apply (see OnApply)
+	onApply []func()
+
+	// mu guards Sections, Scenarios and onApply against Apply swapping them while requests are matched
+	mu sync.RWMutex
 }
 
 // ScenarioConfig represents a scenario definition in configuration
@@ -70,6 +93,52 @@ type ScenarioConfig struct {
 
 	// Headers contains additional HTTP headers to include in the response
 	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// MatchContentLength restricts the scenario to requests with a body size in the given range
+	MatchContentLength *model.ContentLengthRange `yaml:"match_content_length,omitempty" json:"match_content_length,omitempty"` //nolint:revive // struct tags cannot be wrapped
+
+	// RequireFlag restricts the scenario to requests listing this flag in the X-Feature-Flags header
+	RequireFlag string `yaml:"require_flag,omitempty" json:"require_flag,omitempty"`
+
+	// CookieMatch restricts the scenario to requests carrying these cookie values, "*" for any value
+	CookieMatch map[string]string `yaml:"cookie_match,omitempty" json:"cookie_match,omitempty"`
+
+	// Priority decides between scenarios matching the same request; the highest wins (default: 0)
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// PadToBytes pads the response body up to the given size in bytes
+	PadToBytes int `yaml:"pad_to_bytes,omitempty" json:"pad_to_bytes,omitempty"`
+
+	// RandomBytes replaces the response body with the given number of random bytes
+	RandomBytes int `yaml:"random_bytes,omitempty" json:"random_bytes,omitempty"`
+
+	// ThrottleBytesPerSec limits the response write rate to simulate slow links (0 = unthrottled)
+	ThrottleBytesPerSec int `yaml:"throttle_bytes_per_sec,omitempty" json:"throttle_bytes_per_sec,omitempty"`
+
+	// Enabled switches the scenario on or off (default: enabled)
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// ActiveFrom and ActiveUntil bound the time window in which the scenario matches
+	ActiveFrom  *time.Time `yaml:"active_from,omitempty" json:"active_from,omitempty"`
+	ActiveUntil *time.Time `yaml:"active_until,omitempty" json:"active_until,omitempty"`
+
+	// ThresholdResponses switch the response after a number of hits, e.g. to model quota exhaustion
+	ThresholdResponses []model.ThresholdResponse `yaml:"threshold_responses,omitempty" json:"threshold_responses,omitempty"` //nolint:revive // struct tags cannot be wrapped
+
+	// ExpireAfterHits stops the scenario from matching after this many hits (0 = never expires)
+	ExpireAfterHits int `yaml:"expire_after_hits,omitempty" json:"expire_after_hits,omitempty"`
+
+	// CloseConnection closes the connection after the scenario response
+	CloseConnection bool `yaml:"close_connection,omitempty" json:"close_connection,omitempty"`
+
+	// MatchRequestLine is a regular expression over "METHOD /path?query" that replaces method and path matching
+	MatchRequestLine string `yaml:"match_request_line,omitempty" json:"match_request_line,omitempty"`
+
+	// ProtoMatch restricts the scenario to requests of an HTTP protocol version, e.g. "HTTP/1.1" or "HTTP/2"
+	ProtoMatch string `yaml:"proto_match,omitempty" json:"proto_match,omitempty"`
+
+	// ResponseSchema is a JSON Schema of the response body, from which scenarios without data generate an example
+	ResponseSchema map[string]any `yaml:"response_schema,omitempty" json:"response_schema,omitempty"`
 }
 
 // ToModelScenario converts a ScenarioConfig to a model.Scenario
@@ -98,6 +167,9 @@ func (sf *ScenarioConfig) ToModelScenario(fixtureResolver *FixtureResolver) mode
 
 	// Combine method and path into RequestPath format
 	requestPath := fmt.Sprintf("%s %s", strings.ToUpper(sf.Method), sf.Path)
+	if sf.MatchRequestLine != "" && sf.Method == "" && sf.Path == "" {
+		requestPath = ""
+	}
 
 	return model.Scenario{
 		UUID:        sf.UUID, // Will be auto-generated by scenario service if empty
@@ -107,6 +179,26 @@ func (sf *ScenarioConfig) ToModelScenario(fixtureResolver *FixtureResolver) mode
 		Location:    sf.Location,
 		Data:        data,
 		Headers:     sf.Headers,
+
+		MatchContentLength: sf.MatchContentLength,
+		RequireFlag:        sf.RequireFlag,
+		CookieMatch:        sf.CookieMatch,
+		Priority:           sf.Priority,
+		PadToBytes:         sf.PadToBytes,
+		RandomBytes:        sf.RandomBytes,
+
+		ThrottleBytesPerSec: sf.ThrottleBytesPerSec,
+
+		Enabled:     sf.Enabled,
+		ActiveFrom:  sf.ActiveFrom,
+		ActiveUntil: sf.ActiveUntil,
+
+		ThresholdResponses: sf.ThresholdResponses,
+		ExpireAfterHits:    sf.ExpireAfterHits,
+		CloseConnection:    sf.CloseConnection,
+		MatchRequestLine:   sf.MatchRequestLine,
+		ProtoMatch:         sf.ProtoMatch,
+		ResponseSchema:     sf.responseSchemaJSON(),
 	}
 }
 
@@ -126,6 +218,10 @@ type Section struct {
 	// Use ** as a wildcard for multiple path segments recursively, e.g. "/api/**"
 	PathPattern string `yaml:"path_pattern" json:"path_pattern"`
 
+	// PathPatterns lists further patterns served by the same section, e.g. "/v1/users/*" and "/v2/users/*".
+	// They are tried after PathPattern in order; the first matching pattern's base path applies to the request.
+	PathPatterns []string `yaml:"path_patterns,omitempty" json:"path_patterns,omitempty"`
+
 	// StrictPath determines whether GET/PUT/DELETE operations require path structure compatibility.
 	// When true:
 	//   - Resources are only accessible via paths that extend their creation path
@@ -173,6 +269,10 @@ type Section struct {
 	// If empty, no header-based ID extraction will be performed.
 	HeaderIDNames []string `yaml:"header_id_names,omitempty" json:"header_id_names,omitempty"`
 
+	// AllowHeaderIDForReads lets GET, HEAD, PUT and DELETE address a resource by the HeaderIDNames
+	// headers when the path carries no ID, e.g. GET /users with X-Resource-ID: 123 reads resource 123.
+	AllowHeaderIDForReads bool `yaml:"allow_header_id_for_reads,omitempty" json:"allow_header_id_for_reads,omitempty"`
+
 	// IDExtraction provides a simplified way to configure ID extraction in unified config
 	IDExtraction *IDExtractionConfig `yaml:"id_extraction,omitempty" json:"id_extraction,omitempty"`
 
@@ -187,10 +287,244 @@ type Section struct {
 	// This flag provides simple control over response body behavior without requiring transformations.
 	ReturnBody bool `yaml:"return_body" json:"return_body"`
 
+	// GraphQLResponse wraps GET responses in a GraphQL-shaped envelope when set.
+	// The value is the field name used under "data", e.g. "user" yields {"data": {"user": <body>}}.
+	// Failed lookups are returned as {"data": {"user": null}, "errors": [{"message": "..."}]}.
+	GraphQLResponse string `yaml:"graphql_response,omitempty" json:"graphql_response,omitempty"`
+
+	// FieldScopes projects GET responses to the top-level JSON fields visible to the request's auth scopes.
+	// Keys are scope names, values are the visible field names. Scopes are read from the bearer token
+	// ("Authorization: Bearer admin") or the X-Auth-Scope header (space or comma separated).
+	// Requests without a configured scope see the fields of the "default" scope, if any.
+	FieldScopes map[string][]string `yaml:"field_scopes,omitempty" json:"field_scopes,omitempty"`
+
+	// ThrottleBytesPerSec limits the response write rate to simulate slow links (0 = unthrottled)
+	ThrottleBytesPerSec int `yaml:"throttle_bytes_per_sec,omitempty" json:"throttle_bytes_per_sec,omitempty"`
+
+	// PathIDSegments lists the zero-based path pattern segments that together form a resource's composite ID,
+	// e.g. [1, 3] for "/tenants/*/users/*". Collection requests then only list resources under the given parent.
+	PathIDSegments []int `yaml:"path_id_segments,omitempty" json:"path_id_segments,omitempty"`
+
+	// LocaleFormatFields maps top-level JSON fields of GET responses to a format type ("number" or "date").
+	// Matching values are rendered as strings formatted for the request's Accept-Language (default: en).
+	LocaleFormatFields map[string]string `yaml:"locale_format_fields,omitempty" json:"locale_format_fields,omitempty"`
+
+	// Embeddable lists sub-collections that GET of an individual resource embeds on request,
+	// e.g. ["orders"] lets GET /users/123?embed=orders include /users/123/orders under an "orders" key.
+	Embeddable []string `yaml:"embeddable,omitempty" json:"embeddable,omitempty"`
+
+	// CoalesceRequests shares the response of an in-flight GET with identical concurrent GETs,
+	// simulating a backend that computes a response once under a cache stampede.
+	// Shared responses carry the "X-Coalesced: true" header.
+	CoalesceRequests bool `yaml:"coalesce_requests,omitempty" json:"coalesce_requests,omitempty"`
+
+	// Transactions enables two-phase creation via POST <collection>/tx/prepare, /tx/commit and /tx/rollback.
+	// Prepared resources stay invisible to GET until their transaction is committed.
+	Transactions bool `yaml:"transactions,omitempty" json:"transactions,omitempty"`
+
+	// FullTextSearch lets collection GETs filter resources with a "q" query parameter,
+	// returning only resources whose body contains the term (case-insensitive).
+	FullTextSearch bool `yaml:"full_text_search,omitempty" json:"full_text_search,omitempty"`
+
+	// ReadFrom names a command section whose storage this (query) section reads, for CQRS setups
+	// where writes go to one endpoint and reads to another.
+	ReadFrom string `yaml:"read_from,omitempty" json:"read_from,omitempty"`
+
+	// ReadDelay hides resources from GET until this long after they were written, simulating
+	// eventual consistency between a command section and a query section (e.g. "500ms").
+	ReadDelay time.Duration `yaml:"read_delay,omitempty" json:"read_delay,omitempty"`
+
+	// Auth requires HTTP Basic credentials or a bearer token on every request to the section.
+	// Requests without valid credentials get 401 with a WWW-Authenticate challenge.
+	Auth *AuthConfig `yaml:"auth,omitempty" json:"auth,omitempty"`
+
+	// SignResponses adds an HMAC-SHA256 signature of every response body as a header,
+	// so clients can exercise webhook signature verification.
+	SignResponses *SignConfig `yaml:"sign_responses,omitempty" json:"sign_responses,omitempty"`
+
+	// StaticDir serves GET/HEAD requests matching the section from files in this directory,
+	// e.g. GET /assets/logo.png returns <static_dir>/assets/logo.png. Relative directories are
+	// resolved against the configuration file's directory.
+	StaticDir string `yaml:"static_dir,omitempty" json:"static_dir,omitempty"`
+
+	// Protocol selects how the section is served: plain HTTP resources by default, or "grpc-web" to
+	// answer unary gRPC-Web calls (POST /package.Service/Method) with the GRPCWeb response, framed as
+	// gRPC-Web length-prefixed messages with the trailers in the body. Storage is not involved.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+
+	// GRPCWeb is the response message, gRPC status and trailers of grpc-web sections
+	GRPCWeb *GRPCWebConfig `yaml:"grpc_web,omitempty" json:"grpc_web,omitempty"`
+
+	// SequentialIDs assigns POSTs without an ID the next integer of a per-section sequence
+	// ("1", "2", "3", ...) instead of a UUID.
+	SequentialIDs bool `yaml:"sequential_ids,omitempty" json:"sequential_ids,omitempty"`
+
+	// ArrayMode splits POSTs of a JSON array into one resource per element, each stored under the ID
+	// extracted from it, and answers with {"locations": [...]} like a bulk-create endpoint
+	ArrayMode bool `yaml:"array_mode,omitempty" json:"array_mode,omitempty"`
+
+	// ErrorTemplate formats the section's error responses (404, 400, ...) instead of plain text
+	ErrorTemplate *ErrorTemplate `yaml:"error_template,omitempty" json:"error_template,omitempty"`
+
+	// CursorPagination pages collection GETs with opaque cursors (?limit=N&cursor=...) anchored on
+	// resource IDs, so deleting resources between page requests neither skips nor repeats items.
+	CursorPagination bool `yaml:"cursor_pagination,omitempty" json:"cursor_pagination,omitempty"`
+
+	// PageSize is the default page length for cursor pagination (default: 20)
+	PageSize int `yaml:"page_size,omitempty" json:"page_size,omitempty"`
+
+	// PartialCollectionSize caps collection GETs at this many resources (ordered by ID), returned as
+	// {"items": [...], "hasMore": true|false} instead of a bare array (0 = return every resource)
+	PartialCollectionSize int `yaml:"partial_collection_size,omitempty" json:"partial_collection_size,omitempty"`
+
+	// AllowGroupBy lets collection GETs group resources by a top-level field with a "groupBy" query
+	// parameter, e.g. ?groupBy=department returns {"engineering": [...], "sales": [...]}
+	AllowGroupBy bool `yaml:"allow_group_by,omitempty" json:"allow_group_by,omitempty"`
+
+	// EchoQueryInBody adds the request's query parameters to JSON GET responses as a "_query" object,
+	// for testing clients that round-trip query parameters
+	EchoQueryInBody bool `yaml:"echo_query_in_body,omitempty" json:"echo_query_in_body,omitempty"`
+
+	// PollIntervalHeader is sent with successful GET and HEAD responses as X-Poll-Interval, in seconds,
+	// suggesting to polling clients how often to request the resource again (0 = no header)
+	PollIntervalHeader time.Duration `yaml:"poll_interval_header,omitempty" json:"poll_interval_header,omitempty"`
+
+	// Versioned keeps the previous versions of a resource on every update. They are listed by
+	// GET {resource}/history and a single one is read by GET {resource}?version=N, 1 being the original.
+	Versioned bool `yaml:"versioned,omitempty" json:"versioned,omitempty"`
+
+	// SoftDelete makes DELETE set "deleted": true on JSON resources instead of removing them. Collections
+	// leave soft-deleted resources out, while GET on one returns its tombstone with 410 Gone.
+	SoftDelete bool `yaml:"soft_delete,omitempty" json:"soft_delete,omitempty"`
+
+	// PutRequiresExisting makes PUT on a missing resource return 404 Not Found instead of creating it,
+	// so PUT only updates as in strict REST APIs
+	PutRequiresExisting bool `yaml:"put_requires_existing,omitempty" json:"put_requires_existing,omitempty"`
+
+	// StateTransitions maps a resource status to the actions allowed from it, e.g. "pending": ["approve",
+	// "reject"]. JSON resources with a "status" field are served with the allowed actions in "_next".
+	StateTransitions map[string][]string `yaml:"state_transitions,omitempty" json:"state_transitions,omitempty"`
+
+	// RedirectToCanonical answers GET/HEAD of non-canonical paths (duplicate slashes, literal
+	// segments in a different case) with a 301 to the canonical path, e.g. /Users//123 to /users/123.
+	RedirectToCanonical bool `yaml:"redirect_to_canonical,omitempty" json:"redirect_to_canonical,omitempty"`
+
+	// CloseConnection sends Connection: close with every response of the section, making the server
+	// close the connection afterwards so clients cannot reuse it
+	CloseConnection bool `yaml:"close_connection,omitempty" json:"close_connection,omitempty"`
+
+	// ContentTypeFromExtension serves successful GET and HEAD responses with the content type of the
+	// request path's extension (e.g. text/csv for /files/report.csv) instead of the stored one
+	ContentTypeFromExtension bool `yaml:"content_type_from_extension,omitempty" json:"content_type_from_extension,omitempty"` //nolint:revive // struct tags cannot be wrapped
+
+	// MultipleChoicesOnNoMatch answers GET and HEAD requests whose Accept header matches none of the
+	// available representations with 300 Multiple Choices listing them instead of the resource
+	MultipleChoicesOnNoMatch bool `yaml:"multiple_choices_on_no_match,omitempty" json:"multiple_choices_on_no_match,omitempty"` //nolint:revive // struct tags cannot be wrapped
+
+	// LatencySchedule delays responses depending on the time of day, e.g. slower during peak hours
+	LatencySchedule LatencySchedule `yaml:"latency_schedule,omitempty" json:"latency_schedule,omitempty"`
+
+	// ReportProcessingTime adds an X-Processing-Time-Ms header with the time taken to build the
+	// response, configured delays included
+	ReportProcessingTime bool `yaml:"report_processing_time,omitempty" json:"report_processing_time,omitempty"`
+
+	// NumericPrecision formats fractional numbers in JSON responses, collections included, with this
+	// many decimal places, e.g. 2 turns 3.14159 into 3.14 and 2.5 into 2.50 (default: 0, unchanged)
+	NumericPrecision int `yaml:"numeric_precision,omitempty" json:"numeric_precision,omitempty"`
+
+	// ChunkBoundaries lists byte offsets at which response bodies are flushed, so a chunked
+	// response is split exactly there (e.g. in the middle of a JSON token). Takes precedence over
+	// SimulateBandwidth and ThrottleBytesPerSec.
+	ChunkBoundaries []int `yaml:"chunk_boundaries,omitempty" json:"chunk_boundaries,omitempty"`
+
+	// SimulateBandwidth delivers response bodies as if over a link of this many bytes per second, so a
+	// body takes exactly len/SimulateBandwidth to arrive (0 = unlimited). Takes precedence over
+	// ThrottleBytesPerSec.
+	SimulateBandwidth int `yaml:"simulate_bandwidth,omitempty" json:"simulate_bandwidth,omitempty"`
+
+	// TruncateBeforeContentLength declares the full body length in Content-Length but sends only half of
+	// the body before closing the connection, simulating a transfer cut short. Takes precedence over
+	// ChunkBoundaries, SimulateBandwidth and ThrottleBytesPerSec.
+	TruncateBeforeContentLength bool `yaml:"truncate_before_content_length,omitempty" json:"truncate_before_content_length,omitempty"` //nolint:revive // struct tags cannot be wrapped
+
+	// SuppressLocation omits the Location header from 201 Created POST responses, as fire-and-forget
+	// APIs do. The resource is still stored and retrievable by its ID.
+	SuppressLocation bool `yaml:"suppress_location,omitempty" json:"suppress_location,omitempty"`
+
+	// LocationTemplate renders the Location header of POST responses from the JSON request body
+	// using Go template syntax, e.g. "/orders/{{.customerId}}/{{.orderId}}" (default: path/id).
+	LocationTemplate string `yaml:"location_template,omitempty" json:"location_template,omitempty"`
+
+	// DependsOnResourceAt is the path of a resource in another section (e.g. "/databases/primary") that
+	// must exist before this section serves requests; until then every request gets 503 Service Unavailable.
+	DependsOnResourceAt string `yaml:"depends_on_resource_at,omitempty" json:"depends_on_resource_at,omitempty"`
+
+	// DisableHTMLEscape keeps <, > and & literal in collection responses instead of the \u003c, \u003e
+	// and \u0026 escapes encoding/json produces when bodies are re-encoded, e.g. by response transforms.
+	DisableHTMLEscape bool `yaml:"disable_html_escape,omitempty" json:"disable_html_escape,omitempty"`
+
+	// ContentDisposition is a filename template for individual GET responses, e.g. "invoice-{{.ID}}.pdf",
+	// sent as Content-Disposition: attachment; filename="..." so clients treat them as downloads.
+	ContentDisposition string `yaml:"content_disposition,omitempty" json:"content_disposition,omitempty"`
+
+	// TTL makes resources written to the section expire this long after their last create or update.
+	// Expired resources are treated as not found and purged by a background sweeper (default: no expiry).
+	TTL time.Duration `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+
+	// ETagStrength selects the ETag returned for individual resources: "weak" (default) derives
+	// W/"..." tags from the modification time, "strong" hashes the stored bytes.
+	ETagStrength string `yaml:"etag_strength,omitempty" json:"etag_strength,omitempty"`
+
+	// RequireOrder lists path patterns of workflow steps that must complete in sequence: a write to a
+	// step answers 409 Conflict until the previous step has succeeded. Reads are not steps.
+	RequireOrder []string `yaml:"require_order,omitempty" json:"require_order,omitempty"`
+
+	// DigestHeader adds an RFC 3230 "Digest: sha-256=<base64>" header computed over each response body
+	DigestHeader bool `yaml:"digest_header,omitempty" json:"digest_header,omitempty"`
+
+	// MinInterval paces each client: after a served request, further requests from the same client
+	// address arriving sooner than this are answered with 425 Too Early (default: no pacing).
+	MinInterval time.Duration `yaml:"min_interval,omitempty" json:"min_interval,omitempty"`
+
+	// HeadHeaders are set on successful HEAD responses in place of the headers mirrored from GET,
+	// for APIs whose HEAD responses carry their own metadata (default: HEAD mirrors GET)
+	HeadHeaders map[string]string `yaml:"head_headers,omitempty" json:"head_headers,omitempty"`
+
+	// RateLimit enforces a request rate on the section, answering requests beyond it with 429 Too Many
+	// Requests (default: unlimited)
+	RateLimit *RateLimit `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"`
+
+	// DeleteResponse sets the status code and extra headers of successful DELETE responses
+	// (default: 204 No Content without extra headers)
+	DeleteResponse *DeleteResponse `yaml:"delete_response,omitempty" json:"delete_response,omitempty"`
+
+	// FixedResponses are scenarios kept with the section they belong to. LoadFromYAML moves them to the
+	// top-level scenarios (see ExpandFixedResponses); a response without a path gets the section's pattern.
+	FixedResponses []ScenarioConfig `yaml:"fixed_responses,omitempty" json:"fixed_responses,omitempty"`
+
+	// Variations randomly vary the status code and body size of successful responses, simulating a
+	// noisy real service in soak tests (default: none)
+	Variations *Variations `yaml:"variations,omitempty" json:"variations,omitempty"`
+
+	// ResponseTransforms declares built-in field transformations (remove, set, redact) applied to
+	// JSON response bodies. They are compiled into Transformations.ResponseTransforms when the
+	// configuration is loaded, so non-Go users can shape responses from YAML.
+	ResponseTransforms []FieldTransform `yaml:"response_transforms,omitempty" json:"response_transforms,omitempty"`
+
+	// RequestTransforms declares built-in field transformations (set, setTimestamp, generateUUID, ...)
+	// applied to JSON request bodies on POST/PUT before they are stored. They are compiled into
+	// Transformations.RequestTransforms when the configuration is loaded.
+	RequestTransforms []FieldTransform `yaml:"request_transforms,omitempty" json:"request_transforms,omitempty"`
+
 	// Transformations contains request/response transformation functions.
 	// This field is only available when using Unimock as a library and is excluded from YAML serialization.
 	// It allows programmatic modification of requests and responses for advanced testing scenarios.
 	Transformations *TransformationConfig `yaml:"-" json:"-"`
+
+	// declaredTransformations holds the Transformations set in code and compiledTransformations those
+	// plus the compiled declarative transforms, so compiling again starts over from the former
+	declaredTransformations *TransformationConfig
+	compiledTransformations *TransformationConfig
 }
 
 // NewUniConfig creates an empty UniConfig with an initialized Sections map
@@ -203,11 +537,30 @@ func NewUniConfig() *UniConfig {
 
 // LoadFromYAML loads a UniConfig from a YAML file at the given path
 // Supports both legacy format (sections at root) and unified format (sections nested)
-func LoadFromYAML(path string) (*UniConfig, error) {
+// ${VAR} and ${VAR:-default} references are replaced with environment variables before parsing.
+func LoadFromYAML(path string, opts ...LoadOption) (*UniConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return ParseYAML(data, filepath.Dir(path), opts...)
+}
+
+// ParseYAML parses a configuration like LoadFromYAML reads it from a file, resolving fixture
+// references relative to baseDir
+func ParseYAML(data []byte, baseDir string, opts ...LoadOption) (*UniConfig, error) {
+	var options loadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if !options.skipEnv {
+		expanded, err := expandEnv(data, options.lenientEnv)
+		if err != nil {
+			return nil, err
+		}
+		data = expanded
+	}
 
 	// Try to parse as unified format first (with explicit sections and scenarios)
 	config := NewUniConfig()
@@ -218,7 +571,23 @@ func LoadFromYAML(path string) (*UniConfig, error) {
 	if unifiedErr == nil && (len(config.Sections) > 0 || len(config.Scenarios) > 0) {
 		// Successfully parsed as unified format
 		config.Normalize()
-		config.initializeFixtureResolver(filepath.Dir(path))
+		if err := config.validateUnless(options.skipValidation); err != nil {
+			return nil, err
+		}
+		if err := config.CompileTransforms(); err != nil {
+			return nil, err
+		}
+		if err := config.ExpandFixedResponses(); err != nil {
+			return nil, err
+		}
+		if err := config.initializeFixtureResolver(baseDir, options); err != nil {
+			return nil, err
+		}
+		if !options.skipValidation {
+			if err := config.fetchURLFixtures(); err != nil {
+				return nil, err
+			}
+		}
 		return config, nil
 	}
 
@@ -244,14 +613,41 @@ func LoadFromYAML(path string) (*UniConfig, error) {
 	}
 
 	config.Sections = legacyConfig.Sections
-	config.initializeFixtureResolver(filepath.Dir(path))
+	if err := config.validateUnless(options.skipValidation); err != nil {
+		return nil, err
+	}
+	if err := config.CompileTransforms(); err != nil {
+		return nil, err
+	}
+	if err := config.ExpandFixedResponses(); err != nil {
+		return nil, err
+	}
+	if err := config.initializeFixtureResolver(baseDir, options); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
+// validateUnless runs Validate unless skip is set by WithoutValidation
+func (uc *UniConfig) validateUnless(skip bool) error {
+	if skip {
+		return nil
+	}
+	return uc.Validate()
+}
+
 // initializeFixtureResolver sets up the fixture resolver with the configuration file's directory
-func (uc *UniConfig) initializeFixtureResolver(baseDir string) {
+// and the URL fixture settings of the load options. WithoutFixtures leaves the configuration without
+// a resolver and fails when a scenario references a fixture.
+func (uc *UniConfig) initializeFixtureResolver(baseDir string, options loadOptions) error {
 	uc.baseDir = baseDir
+	if options.skipFixtures {
+		return uc.rejectFixtures()
+	}
 	uc.fixtureResolver = NewFixtureResolver(baseDir)
+	uc.fixtureResolver.fetchTimeout = options.fixtureFetchTimeout
+	uc.fixtureResolver.cacheDir = options.fixtureCacheDir
+	return nil
 }
 
 // GetFixtureResolver returns the fixture resolver for this configuration
@@ -267,15 +663,67 @@ func (uc *UniConfig) Normalize() {
 	}
 }
 
+// CompileTransforms translates declarative transforms of every section into transformation functions.
+// It is called by LoadFromYAML; library users building UniConfig in code should call it themselves.
+// Calling it again recompiles the declarative transforms instead of adding them a second time.
+func (uc *UniConfig) CompileTransforms() error {
+	for name, section := range uc.Sections {
+		if err := section.compileTransforms(uc.generateID); err != nil {
+			return fmt.Errorf("section %s: %w", name, err)
+		}
+		uc.Sections[name] = section
+	}
+	return nil
+}
+
+// compileTransforms sets the section's Transformations to those set in code followed by the compiled
+// declarative transforms, taking the IDs of generateUUID transforms from newID
+func (s *Section) compileTransforms(newID func() string) error {
+	declared := s.Transformations
+	if declared != nil && declared == s.compiledTransformations {
+		declared = s.declaredTransformations
+	}
+	if len(s.RequestTransforms) == 0 && len(s.ResponseTransforms) == 0 {
+		s.Transformations = declared
+		return nil
+	}
+
+	compiled := NewTransformationConfig()
+	if declared != nil {
+		compiled.RequestTransforms = append(compiled.RequestTransforms, declared.RequestTransforms...)
+		compiled.ResponseTransforms = append(compiled.ResponseTransforms, declared.ResponseTransforms...)
+	}
+	for _, ft := range s.RequestTransforms {
+		transform, err := newRequestFieldTransform(ft, newID)
+		if err != nil {
+			return err
+		}
+		compiled.AddRequestTransform(transform)
+	}
+	for _, ft := range s.ResponseTransforms {
+		transform, err := newResponseFieldTransform(ft, newID)
+		if err != nil {
+			return err
+		}
+		compiled.AddResponseTransform(transform)
+	}
+	s.Transformations = compiled
+	s.declaredTransformations = declared
+	s.compiledTransformations = compiled
+	return nil
+}
+
 // Normalize ensures consistent field values for different configuration formats
 func (s *Section) Normalize() {
 	s.normalizePathFields()
 	s.normalizeIDExtractionFields()
 }
 
-// normalizePathFields handles path field variants (no longer needed - only PathPattern supported)
-func (*Section) normalizePathFields() {
-	// No normalization needed - only PathPattern is supported
+// normalizePathFields makes the first of PathPatterns the PathPattern of sections that only list PathPatterns
+func (s *Section) normalizePathFields() {
+	if s.PathPattern == "" && len(s.PathPatterns) > 0 {
+		s.PathPattern = s.PathPatterns[0]
+	}
 }
 
 // normalizeIDExtractionFields handles ID extraction field variants
@@ -297,197 +745,101 @@ func (s *Section) GetPathPattern() string {
 	return s.PathPattern
 }
 
-// isPatternMatch checks if a path matches a pattern with wildcards
-func isPatternMatch(pattern, path string, caseSensitive bool) bool {
-	matcher := pathMatcher{caseSensitive: caseSensitive}
-	patternParts := strings.Split(strings.Trim(pattern, PathSeparator), PathSeparator)
-	pathParts := strings.Split(strings.Trim(path, PathSeparator), PathSeparator)
-
-	if !strings.Contains(pattern, WildcardChar) {
-		return matcher.matchExactPath(pattern, path)
+// Patterns returns PathPattern followed by PathPatterns, in matching order and without duplicates
+func (s *Section) Patterns() []string {
+	patterns := make([]string, 0, 1+len(s.PathPatterns))
+	if s.PathPattern != "" {
+		patterns = append(patterns, s.PathPattern)
 	}
-
-	// Check for recursive wildcard patterns
-	if strings.Contains(pattern, RecursiveWildcard) {
-		return matcher.matchRecursivePattern(patternParts, pathParts)
-	}
-
-	return matcher.matchWildcardPattern(patternParts, pathParts)
-}
-
-// pathMatcher handles path matching with configurable case sensitivity
-type pathMatcher struct {
-	caseSensitive bool
-}
-
-// matchExactPath performs exact path matching
-func (pm pathMatcher) matchExactPath(pattern, path string) bool {
-	if pm.caseSensitive {
-		return pattern == path
-	}
-	return strings.EqualFold(pattern, path)
-}
-
-// matchWildcardPattern performs wildcard pattern matching
-func (pm pathMatcher) matchWildcardPattern(patternParts, pathParts []string) bool {
-	if !isValidSegmentCount(patternParts, pathParts) {
-		return false
-	}
-
-	return pm.matchSegments(patternParts, pathParts)
-}
-
-// isValidSegmentCount checks if segment counts are compatible for single wildcards
-func isValidSegmentCount(patternParts, pathParts []string) bool {
-	// For single wildcard patterns, the segment count must match exactly
-	// OR for collection access, allow one less segment (e.g., /users/* matches /users)
-	return len(patternParts) == len(pathParts) ||
-		(len(patternParts) > 0 && len(pathParts) == len(patternParts)-1 &&
-			patternParts[len(patternParts)-1] == WildcardChar)
-}
-
-// matchSegments compares pattern segments with path segments
-func (pm pathMatcher) matchSegments(patternParts, pathParts []string) bool {
-	// Handle collection access case: /users/* matches /users
-	if pm.isCollectionAccess(patternParts, pathParts) {
-		return pm.matchCollectionSegments(patternParts, pathParts)
-	}
-
-	return pm.matchNormalSegments(patternParts, pathParts)
-}
-
-// isCollectionAccess checks if this is a collection access pattern
-func (*pathMatcher) isCollectionAccess(patternParts, pathParts []string) bool {
-	return len(pathParts) == len(patternParts)-1 && len(patternParts) > 0 &&
-		patternParts[len(patternParts)-1] == WildcardChar
-}
-
-// matchCollectionSegments matches collection access patterns
-func (pm pathMatcher) matchCollectionSegments(patternParts, pathParts []string) bool {
-	for i := 0; i < len(pathParts); i++ {
-		if !pm.segmentMatches(patternParts[i], pathParts[i]) {
-			return false
+	for _, pattern := range s.PathPatterns {
+		if pattern != s.PathPattern {
+			patterns = append(patterns, pattern)
 		}
 	}
-	return true
+	return patterns
 }
 
-// matchNormalSegments matches normal patterns with exact segment counts
-func (pm pathMatcher) matchNormalSegments(patternParts, pathParts []string) bool {
-	maxLen := len(patternParts)
-	if len(pathParts) < maxLen {
-		maxLen = len(pathParts)
-	}
-
-	for i := 0; i < maxLen; i++ {
-		if patternParts[i] == WildcardChar {
-			continue
-		}
-		if !pm.segmentMatches(patternParts[i], pathParts[i]) {
-			return false
+// OrderStep returns the index of the RequireOrder step matching the path, or -1 if it is not a step
+func (s *Section) OrderStep(path string) int {
+	for i, pattern := range s.RequireOrder {
+		if isPatternMatch(pattern, path, true) {
+			return i
 		}
 	}
-	return true
+	return -1
 }
 
-// segmentMatches checks if a single segment matches
-func (pm pathMatcher) segmentMatches(pattern, path string) bool {
-	if pm.caseSensitive {
-		return pattern == path
-	}
-	return strings.EqualFold(pattern, path)
-}
-
-// matchRecursivePattern handles patterns with ** recursive wildcards
-func (pm pathMatcher) matchRecursivePattern(patternParts, pathParts []string) bool {
-	return pm.matchRecursiveSegments(patternParts, pathParts, 0, 0)
-}
-
-// matchRecursiveSegments recursively matches pattern segments with path segments
-func (pm pathMatcher) matchRecursiveSegments(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
-	// Check if all patterns consumed
-	if patternIdx >= len(patternParts) {
-		return pathIdx >= len(pathParts)
-	}
-
-	// Check if all paths consumed but patterns remain
-	if pathIdx >= len(pathParts) {
-		return allRemainingAreRecursiveWildcards(patternParts, patternIdx)
+// isCompositeCollectionMatch checks if a path addresses the collection of a section with composite IDs,
+// e.g. "/tenants/t1/users" for pattern "/tenants/*/users/*" with PathIDSegments set
+func isCompositeCollectionMatch(section Section, pattern, path string) bool {
+	if len(section.PathIDSegments) == 0 {
+		return false
 	}
-
-	currentPattern := patternParts[patternIdx]
-
-	switch currentPattern {
-	case RecursiveWildcard:
-		return pm.handleRecursiveWildcard(patternParts, pathParts, patternIdx, pathIdx)
-	case WildcardChar:
-		return pm.handleSingleWildcard(patternParts, pathParts, patternIdx, pathIdx)
-	default:
-		return pm.handleExactMatch(patternParts, pathParts, patternIdx, pathIdx, currentPattern)
+	patternParts := strings.Split(strings.Trim(pattern, PathSeparator), PathSeparator)
+	pathParts := strings.Split(strings.Trim(path, PathSeparator), PathSeparator)
+	if len(pathParts) != len(patternParts)-1 || patternParts[len(patternParts)-1] != WildcardChar {
+		return false
 	}
+	return pathMatcher{caseSensitive: section.CaseSensitive}.matchNormalSegments(patternParts, pathParts)
 }
 
-// allRemainingAreRecursiveWildcards checks if remaining pattern parts are all ** wildcards
-func allRemainingAreRecursiveWildcards(patternParts []string, patternIdx int) bool {
-	for i := patternIdx; i < len(patternParts); i++ {
-		if patternParts[i] != RecursiveWildcard {
-			return false
-		}
+// HistoryResourcePath returns the resource path whose history the path addresses ("/users/1" for
+// "/users/1/history"), or false if the section is not versioned or the path is no history path
+func (s *Section) HistoryResourcePath(path string) (string, bool) {
+	if !isHistoryMatch(*s, s.PathPattern, path) {
+		return "", false
 	}
-	return true
+	resourcePath, _ := strings.CutSuffix(strings.TrimRight(path, PathSeparator), PathSeparator+HistorySegment)
+	return resourcePath, true
 }
 
-// handleRecursiveWildcard processes ** wildcards
-func (pm pathMatcher) handleRecursiveWildcard(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
-	// ** can match zero or more segments
-	for i := pathIdx; i <= len(pathParts); i++ {
-		if pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, i) {
-			return true
-		}
+// isHistoryMatch checks if the path addresses the history of an individual resource matching the pattern
+func isHistoryMatch(section Section, pattern, path string) bool {
+	if !section.Versioned {
+		return false
 	}
-	return false
-}
-
-// handleSingleWildcard processes * wildcards
-func (pm pathMatcher) handleSingleWildcard(patternParts, pathParts []string, patternIdx, pathIdx int) bool {
-	// * matches exactly one segment
-	return pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, pathIdx+1)
-}
-
-// handleExactMatch processes exact segment matches
-func (pm pathMatcher) handleExactMatch(
-	patternParts, pathParts []string, patternIdx, pathIdx int, currentPattern string,
-) bool {
-	if pm.segmentMatches(currentPattern, pathParts[pathIdx]) {
-		return pm.matchRecursiveSegments(patternParts, pathParts, patternIdx+1, pathIdx+1)
+	resourcePath, ok := strings.CutSuffix(strings.Trim(path, PathSeparator), PathSeparator+HistorySegment)
+	if !ok {
+		return false
 	}
-	return false
+	patternParts := strings.Split(strings.Trim(pattern, PathSeparator), PathSeparator)
+	resourceParts := strings.Split(resourcePath, PathSeparator)
+	return len(resourceParts) == len(patternParts) && isPatternMatch(pattern, resourcePath, section.CaseSensitive)
 }
 
-// MatchPath finds the section that matches the given path
+// MatchPath finds the section that matches the given path.
+// Of a section with several patterns the first matching one wins and becomes the PathPattern of the
+// returned copy, so that the base path and wildcard handling of that pattern apply to the request.
 func (uc *UniConfig) MatchPath(path string) (string, *Section, error) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
 	normalizedPath := strings.Trim(path, PathSeparator)
+	accepts := uc.patternFilter(path)
 
 	// First try exact matches (no wildcards)
-	if name, section := uc.findExactMatch(normalizedPath); section != nil {
+	if name, section := uc.findExactMatch(normalizedPath, accepts); section != nil {
 		return name, section, nil
 	}
 
 	// Then try wildcard matches, prioritizing longer patterns
-	if name, section := uc.findBestWildcardMatch(normalizedPath); section != nil {
+	if name, section := uc.findBestWildcardMatch(normalizedPath, accepts); section != nil {
 		return name, section, nil
 	}
 
 	return "", nil, nil // No match found
 }
 
-// findExactMatch looks for exact pattern matches (no wildcards)
-func (uc *UniConfig) findExactMatch(normalizedPath string) (string, *Section) {
+// findExactMatch looks for exact pattern matches (no wildcards) among the accepted patterns
+func (uc *UniConfig) findExactMatch(normalizedPath string, accepts func(string) bool) (string, *Section) {
 	for name, section := range uc.Sections {
-		pattern := strings.Trim(section.PathPattern, PathSeparator)
-		if !strings.Contains(pattern, WildcardChar) {
-			if isPatternMatch(pattern, normalizedPath, section.CaseSensitive) {
+		for _, rawPattern := range section.Patterns() {
+			if !accepts(rawPattern) {
+				continue
+			}
+			pattern := strings.Trim(rawPattern, PathSeparator)
+			if !strings.Contains(pattern, WildcardChar) && isPatternMatch(pattern, normalizedPath, section.CaseSensitive) {
 				s := section // Create a local copy
+				s.PathPattern = rawPattern
 				return name, &s
 			}
 		}
@@ -495,12 +847,12 @@ func (uc *UniConfig) findExactMatch(normalizedPath string) (string, *Section) {
 	return "", nil
 }
 
-// findBestWildcardMatch finds the best wildcard match by prioritizing longer patterns
-func (uc *UniConfig) findBestWildcardMatch(normalizedPath string) (string, *Section) {
+// findBestWildcardMatch finds the best wildcard match among the accepted patterns by prioritizing longer patterns
+func (uc *UniConfig) findBestWildcardMatch(normalizedPath string, accepts func(string) bool) (string, *Section) {
 	bestMatch := wildcardMatch{name: "", numSegments: noMatch}
 
 	for name, section := range uc.Sections {
-		if match := uc.evaluateWildcardSection(name, section, normalizedPath); match.isValid() {
+		if match := uc.evaluateWildcardSection(name, section, normalizedPath, accepts); match.isValid() {
 			if match.isBetterThan(bestMatch) {
 				bestMatch = match
 			}
@@ -513,6 +865,7 @@ func (uc *UniConfig) findBestWildcardMatch(normalizedPath string) (string, *Sect
 // wildcardMatch represents a potential wildcard match
 type wildcardMatch struct {
 	name        string
+	pattern     string
 	numSegments int
 }
 
@@ -532,18 +885,38 @@ func (m wildcardMatch) getResult(uc *UniConfig) (string, *Section) {
 		return "", nil
 	}
 	matchedSection := uc.Sections[m.name]
+	matchedSection.PathPattern = m.pattern
 	return m.name, &matchedSection
 }
 
-// evaluateWildcardSection checks if a section matches and returns match info
-func (*UniConfig) evaluateWildcardSection(name string, section Section, normalizedPath string) wildcardMatch {
-	pattern := strings.Trim(section.PathPattern, PathSeparator)
+// evaluateWildcardSection checks if one of the section's accepted patterns matches and returns info on the first match
+func (uc *UniConfig) evaluateWildcardSection(
+	name string, section Section, normalizedPath string, accepts func(string) bool,
+) wildcardMatch {
+	for _, pattern := range section.Patterns() {
+		if !accepts(pattern) {
+			continue
+		}
+		if match := uc.evaluateWildcardPattern(name, section, pattern, normalizedPath); match.isValid() {
+			return match
+		}
+	}
+	return wildcardMatch{}
+}
+
+// evaluateWildcardPattern checks if a wildcard pattern of the section matches and returns match info
+func (*UniConfig) evaluateWildcardPattern(
+	name string, section Section, rawPattern, normalizedPath string,
+) wildcardMatch {
+	pattern := strings.Trim(rawPattern, PathSeparator)
 
 	if !strings.Contains(pattern, WildcardChar) {
 		return wildcardMatch{}
 	}
 
-	if !isPatternMatch(pattern, normalizedPath, section.CaseSensitive) {
+	if !isPatternMatch(pattern, normalizedPath, section.CaseSensitive) &&
+		!isCompositeCollectionMatch(section, pattern, normalizedPath) &&
+		!isHistoryMatch(section, pattern, normalizedPath) {
 		return wildcardMatch{}
 	}
 
@@ -565,5 +938,5 @@ func (*UniConfig) evaluateWildcardSection(name string, section Section, normaliz
 		}
 	}
 
-	return wildcardMatch{name: name, numSegments: score}
+	return wildcardMatch{name: name, pattern: rawPattern, numSegments: score}
 }
@@ -369,3 +369,21 @@ func createTempYAMLFile(content string) (string, error) {
 func cleanupTempFile(filename string) {
 	os.Remove(filename)
 }
+
+func TestUniConfig_MatchPath_CompositeCollection(t *testing.T) {
+	cfg := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"tenant_users": {PathPattern: "/tenants/*/users/*", PathIDSegments: []int{1, 3}},
+		},
+	}
+
+	sectionName, section, err := cfg.MatchPath("/tenants/t1/users")
+	if err != nil || section == nil || sectionName != "tenant_users" {
+		t.Fatalf("expected composite collection path to match tenant_users, got %q (err: %v)", sectionName, err)
+	}
+
+	_, section, _ = cfg.MatchPath("/tenants/t1/orders")
+	if section != nil {
+		t.Errorf("expected no match for a different collection name")
+	}
+}
@@ -324,3 +324,76 @@ func validateUnifiedSection(t *testing.T, section config.Section) {
 		t.Errorf("Expected ReturnBody to be true, got %v", section.ReturnBody)
 	}
 }
+
+func TestUniConfig_MatchPath_MultiplePatterns(t *testing.T) {
+	cfg := &config.UniConfig{Sections: map[string]config.Section{
+		"users": {
+			PathPattern:  "/v1/users/*",
+			PathPatterns: []string{"/v2/users/*", "/legacy/users"},
+		},
+		"orders": {PathPattern: "/v1/orders/*"},
+	}}
+
+	tests := []struct {
+		path            string
+		expectedSection string
+		expectedPattern string
+	}{
+		{"/v1/users/1", "users", "/v1/users/*"},
+		{"/v2/users/1", "users", "/v2/users/*"},
+		{"/v2/users", "users", "/v2/users/*"},
+		{"/legacy/users", "users", "/legacy/users"},
+		{"/v1/orders/7", "orders", "/v1/orders/*"},
+	}
+	for _, tt := range tests {
+		name, section, err := cfg.MatchPath(tt.path)
+		if err != nil || section == nil {
+			t.Fatalf("Expected %s to match, got section %v and error %v", tt.path, section, err)
+		}
+		if name != tt.expectedSection || section.PathPattern != tt.expectedPattern {
+			t.Errorf("Expected %s to match %s via %s, got %s via %s",
+				tt.path, tt.expectedSection, tt.expectedPattern, name, section.PathPattern)
+		}
+	}
+
+	if _, section, _ := cfg.MatchPath("/v3/users/1"); section != nil {
+		t.Errorf("Expected /v3/users/1 not to match, got %s", section.PathPattern)
+	}
+	if cfg.Sections["users"].PathPattern != "/v1/users/*" {
+		t.Error("MatchPath must not modify the configured section")
+	}
+}
+
+func TestUniConfig_MatchPath_VersionedHistory(t *testing.T) {
+	cfg := &config.UniConfig{Sections: map[string]config.Section{
+		"docs":  {PathPattern: "/docs/*", Versioned: true},
+		"notes": {PathPattern: "/notes/*"},
+	}}
+
+	name, section, err := cfg.MatchPath("/docs/1/history")
+	if err != nil || name != "docs" {
+		t.Fatalf("Expected /docs/1/history to match docs, got %q and error %v", name, err)
+	}
+	if resourcePath, ok := section.HistoryResourcePath("/docs/1/history"); !ok || resourcePath != "/docs/1" {
+		t.Errorf("Expected history of /docs/1, got %q (%v)", resourcePath, ok)
+	}
+	if _, ok := section.HistoryResourcePath("/docs/history"); ok {
+		t.Error("Expected /docs/history not to be a history path")
+	}
+
+	if _, section, _ := cfg.MatchPath("/notes/1/history"); section != nil {
+		t.Error("Expected history paths of unversioned sections not to match")
+	}
+}
+
+func TestSection_Normalize_PathPatternsOnly(t *testing.T) {
+	section := config.Section{PathPatterns: []string{"/v1/users/*", "/v2/users/*"}}
+	section.Normalize()
+
+	if section.PathPattern != "/v1/users/*" {
+		t.Errorf("Expected PathPattern to default to the first of PathPatterns, got '%s'", section.PathPattern)
+	}
+	if patterns := section.Patterns(); len(patterns) != 2 {
+		t.Errorf("Expected 2 distinct patterns, got %v", patterns)
+	}
+}
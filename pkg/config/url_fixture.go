@@ -0,0 +1,120 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultFixtureFetchTimeout bounds how long fetching a URL fixture (@https://...) may take
+const DefaultFixtureFetchTimeout = 30 * time.Second
+
+// WithFixtureFetchTimeout sets how long fetching a URL fixture may take (default: DefaultFixtureFetchTimeout)
+func WithFixtureFetchTimeout(timeout time.Duration) LoadOption {
+	return func(o *loadOptions) {
+		o.fixtureFetchTimeout = timeout
+	}
+}
+
+// WithFixtureCacheDir stores fetched URL fixtures in dir, keyed by a hash of the URL, and reads them
+// from there on later loads instead of fetching them again. Delete a file to refresh its fixture.
+func WithFixtureCacheDir(dir string) LoadOption {
+	return func(o *loadOptions) {
+		o.fixtureCacheDir = dir
+	}
+}
+
+// urlFixture returns the URL of a @http://... or @https://... fixture reference
+func urlFixture(data string) (string, bool) {
+	reference, ok := strings.CutPrefix(strings.TrimSpace(data), "@")
+	if !ok || !(strings.HasPrefix(reference, "http://") || strings.HasPrefix(reference, "https://")) {
+		return "", false
+	}
+	return strings.TrimSpace(reference), true
+}
+
+// fetchURLFixtures loads the URL fixtures of all scenarios, failing on the first that cannot be fetched
+func (uc *UniConfig) fetchURLFixtures() error {
+	for i, scenario := range uc.Scenarios {
+		url, ok := urlFixture(scenario.Data)
+		if !ok {
+			continue
+		}
+		if _, err := uc.fixtureResolver.loadURLFixture(url); err != nil {
+			return fmt.Errorf("scenario %d (%s %s): %w", i+1, scenario.Method, scenario.Path, err)
+		}
+	}
+	return nil
+}
+
+// loadURLFixture returns the body served at the URL, from the resolver's cache, the cache directory
+// or by fetching it
+func (fr *FixtureResolver) loadURLFixture(url string) (string, error) {
+	fr.mutex.RLock()
+	cached, exists := fr.cache[url]
+	fr.mutex.RUnlock()
+	if exists {
+		return cached, nil
+	}
+
+	content, err := fr.readOrFetch(url)
+	if err != nil {
+		return "", err
+	}
+
+	fr.mutex.Lock()
+	fr.cache[url] = string(content)
+	fr.mutex.Unlock()
+	return string(content), nil
+}
+
+// readOrFetch reads the fixture from the cache directory, fetching and storing it there when missing
+func (fr *FixtureResolver) readOrFetch(url string) ([]byte, error) {
+	if fr.cacheDir == "" {
+		return fr.fetchURL(url)
+	}
+	sum := sha256.Sum256([]byte(url))
+	cacheFile := filepath.Join(fr.cacheDir, hex.EncodeToString(sum[:]))
+	if content, err := os.ReadFile(cacheFile); err == nil {
+		return content, nil
+	}
+	content, err := fr.fetchURL(url)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(fr.cacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to cache fixture %s: %w", url, err)
+	}
+	if err := os.WriteFile(cacheFile, content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to cache fixture %s: %w", url, err)
+	}
+	return content, nil
+}
+
+// fetchURL downloads a fixture, treating responses other than 2xx as errors
+func (fr *FixtureResolver) fetchURL(url string) ([]byte, error) {
+	timeout := fr.fetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultFixtureFetchTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fixture: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to fetch fixture %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fixture %s: %w", url, err)
+	}
+	return body, nil
+}
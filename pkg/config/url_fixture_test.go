@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeURLFixtureConfig(t *testing.T, url string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+scenarios:
+  - method: GET
+    path: /api/catalog
+    data: "@` + url + `"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+	return configPath
+}
+
+func newFixtureServer(t *testing.T, fetches *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if r.URL.Path != "/catalog.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":["a","b"]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoadFromYAML_URLFixture(t *testing.T) {
+	var fetches atomic.Int32
+	server := newFixtureServer(t, &fetches)
+
+	uniConfig, err := config.LoadFromYAML(writeURLFixtureConfig(t, server.URL+"/catalog.json"))
+	require.NoError(t, err)
+
+	scenario := uniConfig.Scenarios[0].ToModelScenario(uniConfig.GetFixtureResolver())
+	assert.Equal(t, `{"items":["a","b"]}`, scenario.Data)
+	assert.EqualValues(t, 1, fetches.Load(), "the fixture is fetched once at load time")
+}
+
+func TestLoadFromYAML_URLFixtureFailure(t *testing.T) {
+	var fetches atomic.Int32
+	server := newFixtureServer(t, &fetches)
+
+	_, err := config.LoadFromYAML(writeURLFixtureConfig(t, server.URL+"/missing.json"))
+	require.ErrorContains(t, err, "scenario 1 (GET /api/catalog)")
+	assert.ErrorContains(t, err, "404")
+
+	slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(slow.Close)
+	_, err = config.LoadFromYAML(writeURLFixtureConfig(t, slow.URL+"/catalog.json"),
+		config.WithFixtureFetchTimeout(50*time.Millisecond))
+	assert.ErrorContains(t, err, "failed to fetch fixture")
+}
+
+func TestLoadFromYAML_URLFixtureCacheDir(t *testing.T) {
+	var fetches atomic.Int32
+	server := newFixtureServer(t, &fetches)
+	configPath := writeURLFixtureConfig(t, server.URL+"/catalog.json")
+	cacheDir := filepath.Join(t.TempDir(), "fixtures")
+
+	_, err := config.LoadFromYAML(configPath, config.WithFixtureCacheDir(cacheDir))
+	require.NoError(t, err)
+	server.Close()
+
+	uniConfig, err := config.LoadFromYAML(configPath, config.WithFixtureCacheDir(cacheDir))
+	require.NoError(t, err, "later loads read the fixture from the cache directory")
+	scenario := uniConfig.Scenarios[0].ToModelScenario(uniConfig.GetFixtureResolver())
+	assert.Equal(t, `{"items":["a","b"]}`, scenario.Data)
+	assert.EqualValues(t, 1, fetches.Load())
+}
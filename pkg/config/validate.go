@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/antchfx/xpath"
+)
+
+// FormFieldPrefix marks body ID paths that name multipart/form-data fields instead of path expressions
+const FormFieldPrefix = "form:"
+
+// ETagWeak and ETagStrong are the accepted values of Section.ETagStrength
+const (
+	ETagWeak   = "weak"
+	ETagStrong = "strong"
+)
+
+// MaxNumericPrecision is the largest accepted Section.NumericPrecision
+const MaxNumericPrecision = 20
+
+// Validate checks every section, including read_from and depends_on_resource_at references, and
+// reports the first invalid one by name. It is called by LoadFromYAML so that configuration mistakes
+// fail startup instead of producing silently wrong behavior at request time.
+func (uc *UniConfig) Validate() error {
+	if problems := uc.sectionErrors(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// ValidationErrors reports every problem Validate would fail on instead of only the first, followed
+// by the problems of scenarios: unknown methods, relative paths, invalid status codes and fixture
+// files that cannot be read. The server loads such scenarios leniently, skipping or serving them as is.
+func (uc *UniConfig) ValidationErrors() []error {
+	problems := uc.sectionErrors()
+	for i, scenario := range uc.Scenarios {
+		if err := scenario.Validate(uc.GetFixtureResolver()); err != nil {
+			problems = append(problems, fmt.Errorf("scenario %d (%s %s): %w", i+1, scenario.Method, scenario.Path, err))
+		}
+	}
+	return problems
+}
+
+// WithoutValidation makes LoadFromYAML skip Validate, e.g. to report all problems of a configuration
+// with ValidationErrors instead of failing on the first
+func WithoutValidation() LoadOption {
+	return func(o *loadOptions) {
+		o.skipValidation = true
+	}
+}
+
+// sectionErrors checks the sections in name order and returns their problems
+func (uc *UniConfig) sectionErrors() []error {
+	names := make([]string, 0, len(uc.Sections))
+	for name := range uc.Sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []error
+	for _, name := range names {
+		section := uc.Sections[name]
+		if err := section.Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("section %s: %w", name, err))
+		}
+		if _, ok := uc.Sections[section.ReadFrom]; section.ReadFrom != "" && !ok {
+			problems = append(problems,
+				fmt.Errorf("section %s: read_from references unknown section %q", name, section.ReadFrom))
+		}
+		if section.DependsOnResourceAt != "" {
+			if _, dependency, err := uc.MatchPath(section.DependsOnResourceAt); err != nil || dependency == nil {
+				problems = append(problems, fmt.Errorf("section %s: depends_on_resource_at %q matches no section",
+					name, section.DependsOnResourceAt))
+			}
+		}
+	}
+	return problems
+}
+
+// Validate checks the scenario's method and path or request line expression, protocol version, status code,
+// hit limit and response schema and that its data, when it references a fixture file, can be resolved
+func (sf *ScenarioConfig) Validate(fixtureResolver *FixtureResolver) error {
+	if err := sf.validateRequestTarget(); err != nil {
+		return err
+	}
+	if sf.ProtoMatch != "" {
+		if _, _, err := ParseProtoMatch(sf.ProtoMatch); err != nil {
+			return fmt.Errorf("invalid proto_match: %w", err)
+		}
+	}
+	if sf.StatusCode != 0 && (sf.StatusCode < 100 || sf.StatusCode > 599) {
+		return fmt.Errorf("status_code must be between 100 and 599, got %d", sf.StatusCode)
+	}
+	if sf.ExpireAfterHits < 0 {
+		return fmt.Errorf("expire_after_hits must not be negative, got %d", sf.ExpireAfterHits)
+	}
+	if sf.ResponseSchema != nil {
+		if _, err := SchemaExample(sf.responseSchemaJSON()); err != nil {
+			return fmt.Errorf("invalid response_schema: %w", err)
+		}
+	}
+	if fixtureResolver != nil {
+		if err := fixtureResolver.CheckFixture(sf.Data); err != nil {
+			return fmt.Errorf("invalid data: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateRequestTarget checks the method and path, which a request line expression makes optional
+func (sf *ScenarioConfig) validateRequestTarget() error {
+	if sf.MatchRequestLine != "" {
+		if _, err := CompileRequestLine(sf.MatchRequestLine); err != nil {
+			return fmt.Errorf("invalid match_request_line %q: %w", sf.MatchRequestLine, err)
+		}
+		if sf.Method == "" && sf.Path == "" {
+			return nil
+		}
+	}
+	switch strings.ToUpper(sf.Method) {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+	default:
+		return fmt.Errorf("invalid method %q", sf.Method)
+	}
+	if !strings.HasPrefix(sf.Path, PathSeparator) {
+		return fmt.Errorf("path %q must start with /", sf.Path)
+	}
+	return nil
+}
+
+// Validate checks that path patterns are absolute, pre-compiles the section's body ID path expressions
+// so malformed ones are reported up front instead of silently extracting no IDs, and checks the TTL,
+// minimum and poll intervals, partial collection size, numeric precision, ETag strength, location and
+// content disposition templates, the protocol, the auth, signing, error template, gRPC-Web,
+// variations, delete response and rate limit blocks and the latency schedule.
+func (s *Section) Validate() error {
+	for _, pattern := range s.Patterns() {
+		if !strings.HasPrefix(pattern, PathSeparator) {
+			return fmt.Errorf("path pattern %q must start with /", pattern)
+		}
+	}
+	for _, idPath := range s.BodyIDPaths {
+		if strings.HasPrefix(idPath, FormFieldPrefix) {
+			continue
+		}
+		if _, err := xpath.Compile(idPath); err != nil {
+			return fmt.Errorf("invalid body_id_paths expression %q: %w", idPath, err)
+		}
+	}
+	if s.TTL < 0 {
+		return fmt.Errorf("ttl must not be negative, got %s", s.TTL)
+	}
+	if s.MinInterval < 0 {
+		return fmt.Errorf("min_interval must not be negative, got %s", s.MinInterval)
+	}
+	if s.PollIntervalHeader < 0 {
+		return fmt.Errorf("poll_interval_header must not be negative, got %s", s.PollIntervalHeader)
+	}
+	if s.PartialCollectionSize < 0 {
+		return fmt.Errorf("partial_collection_size must not be negative, got %d", s.PartialCollectionSize)
+	}
+	if s.NumericPrecision < 0 || s.NumericPrecision > MaxNumericPrecision {
+		return fmt.Errorf("numeric_precision must be between 0 and %d, got %d", MaxNumericPrecision, s.NumericPrecision)
+	}
+	if s.ETagStrength != "" && s.ETagStrength != ETagWeak && s.ETagStrength != ETagStrong {
+		return fmt.Errorf("etag_strength must be %q or %q, got %q", ETagWeak, ETagStrong, s.ETagStrength)
+	}
+	if s.LocationTemplate != "" {
+		if _, err := template.New("location").Parse(s.LocationTemplate); err != nil {
+			return fmt.Errorf("invalid location_template: %w", err)
+		}
+	}
+	if s.ContentDisposition != "" {
+		if _, err := template.New("content_disposition").Parse(s.ContentDisposition); err != nil {
+			return fmt.Errorf("invalid content_disposition: %w", err)
+		}
+	}
+	if s.Auth != nil {
+		if err := s.Auth.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.SignResponses != nil {
+		if err := s.SignResponses.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.ErrorTemplate != nil {
+		if err := s.ErrorTemplate.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.Protocol != ProtocolHTTP && s.Protocol != ProtocolGRPCWeb {
+		return fmt.Errorf("protocol must be empty or %q, got %q", ProtocolGRPCWeb, s.Protocol)
+	}
+	if s.GRPCWeb != nil {
+		if err := s.GRPCWeb.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.Variations != nil {
+		if err := s.Variations.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.DeleteResponse != nil {
+		if err := s.DeleteResponse.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.RateLimit != nil {
+		if err := s.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	return s.LatencySchedule.Validate()
+}
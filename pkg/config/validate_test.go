@@ -0,0 +1,380 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSection_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		idPaths []string
+		badPath string
+	}{
+		{name: "valid paths", idPaths: []string{"/id", "//id", "/user/id", "form:userId"}},
+		{name: "unbalanced bracket", idPaths: []string{"/id", "/items[0"}, badPath: "/items[0"},
+		{name: "JSONPath notation", idPaths: []string{"$.user.id"}, badPath: "$.user.id"},
+		{name: "trailing separator", idPaths: []string{"/user/"}, badPath: "/user/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := config.Section{PathPattern: "/users/*", BodyIDPaths: tt.idPaths}
+
+			err := section.Validate()
+
+			if tt.badPath != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.badPath)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromYAML_InvalidBodyIDPath(t *testing.T) {
+	yamlContent := `sections:
+  users:
+    path_pattern: "/users/*"
+    body_id_paths: ["/items[0"]
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	_, err := config.LoadFromYAML(configPath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "section users")
+	assert.Contains(t, err.Error(), `"/items[0"`)
+}
+
+func TestLoadFromYAML_ReadFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		readFrom string
+		wantErr  bool
+	}{
+		{name: "known command section", readFrom: "orders"},
+		{name: "unknown command section", readFrom: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := `sections:
+  orders:
+    path_pattern: "/orders/*"
+  order-views:
+    path_pattern: "/order-views/*"
+    read_from: "` + tt.readFrom + `"
+    read_delay: 250ms
+`
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+			cfg, err := config.LoadFromYAML(configPath)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `read_from references unknown section "missing"`)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 250*time.Millisecond, cfg.Sections["order-views"].ReadDelay)
+		})
+	}
+}
+
+func TestAuthConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    config.AuthConfig
+		wantErr bool
+	}{
+		{name: "basic", auth: config.AuthConfig{Username: "alice", Password: "secret"}},
+		{name: "bearer", auth: config.AuthConfig{BearerToken: "token"}},
+		{name: "empty", auth: config.AuthConfig{}, wantErr: true},
+		{name: "both kinds", auth: config.AuthConfig{Username: "alice", BearerToken: "token"}, wantErr: true},
+		{name: "password without username", auth: config.AuthConfig{Password: "secret"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.auth.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSection_Validate_ETagStrength(t *testing.T) {
+	for _, strength := range []string{"", config.ETagWeak, config.ETagStrong} {
+		section := config.Section{PathPattern: "/users/*", ETagStrength: strength}
+		assert.NoError(t, section.Validate(), strength)
+	}
+
+	section := config.Section{PathPattern: "/users/*", ETagStrength: "medium"}
+	assert.ErrorContains(t, section.Validate(), "etag_strength")
+}
+
+func TestSection_Validate_Variations(t *testing.T) {
+	half, tooLikely := 0.5, 1.5
+	tests := []struct {
+		name       string
+		variations config.Variations
+		problem    string
+	}{
+		{
+			name: "valid",
+			variations: config.Variations{
+				Probability: &half,
+				StatusCodes: []config.WeightedStatus{{Code: 200, Weight: 8}, {Code: 202}},
+				BodySize:    &config.SizeRange{Min: 100, Max: 200},
+			},
+		},
+		{name: "probability", variations: config.Variations{Probability: &tooLikely}, problem: "probability"},
+		{
+			name:       "error status",
+			variations: config.Variations{StatusCodes: []config.WeightedStatus{{Code: 500}}},
+			problem:    "500",
+		},
+		{
+			name:       "negative weight",
+			variations: config.Variations{StatusCodes: []config.WeightedStatus{{Code: 201, Weight: -1}}},
+			problem:    "weight",
+		},
+		{
+			name:       "reversed body size",
+			variations: config.Variations{BodySize: &config.SizeRange{Min: 200, Max: 100}},
+			problem:    "body_size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := config.Section{PathPattern: "/users/*", Variations: &tt.variations}
+
+			err := section.Validate()
+
+			if tt.problem != "" {
+				assert.ErrorContains(t, err, tt.problem)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSection_Validate_DeleteResponse(t *testing.T) {
+	section := config.Section{PathPattern: "/users/*", DeleteResponse: &config.DeleteResponse{StatusCode: 202}}
+	assert.NoError(t, section.Validate())
+
+	section.DeleteResponse.StatusCode = 404
+	assert.ErrorContains(t, section.Validate(), "delete_response")
+}
+
+func TestSection_Validate_RateLimit(t *testing.T) {
+	section := config.Section{PathPattern: "/users/*", RateLimit: &config.RateLimit{Requests: 10, Window: time.Minute}}
+	assert.NoError(t, section.Validate())
+
+	section.RateLimit.Per = "tenant"
+	assert.ErrorContains(t, section.Validate(), "rate_limit per")
+
+	section.RateLimit = &config.RateLimit{Requests: 10}
+	assert.ErrorContains(t, section.Validate(), "rate_limit window")
+}
+
+func TestSection_Validate_GRPCWeb(t *testing.T) {
+	section := config.Section{
+		PathPattern: "/acme.Greeter/*",
+		Protocol:    config.ProtocolGRPCWeb,
+		GRPCWeb:     &config.GRPCWebConfig{Response: `{"message":"hi"}`, Status: 0},
+	}
+	assert.NoError(t, section.Validate())
+
+	section.Protocol = "grpc"
+	assert.ErrorContains(t, section.Validate(), "protocol")
+
+	section.Protocol = config.ProtocolGRPCWeb
+	section.GRPCWeb = &config.GRPCWebConfig{Status: 17}
+	assert.ErrorContains(t, section.Validate(), "status")
+
+	section.GRPCWeb = &config.GRPCWebConfig{Response: "hi"}
+	assert.ErrorContains(t, section.Validate(), "JSON")
+}
+
+func TestSection_Validate_MinInterval(t *testing.T) {
+	section := config.Section{PathPattern: "/users/*", MinInterval: 100 * time.Millisecond}
+	assert.NoError(t, section.Validate())
+
+	section.MinInterval = -time.Second
+	assert.ErrorContains(t, section.Validate(), "min_interval")
+}
+
+func TestSection_Validate_LatencySchedule(t *testing.T) {
+	section := config.Section{PathPattern: "/users/*", LatencySchedule: config.LatencySchedule{
+		"09:00-17:00": 800 * time.Millisecond,
+		"22:00-24:00": 0,
+	}}
+	assert.NoError(t, section.Validate())
+
+	for window, problem := range map[string]string{"9-17": "9", "09:00": "HH:MM-HH:MM", "10:00-10:00": "empty"} {
+		section.LatencySchedule = config.LatencySchedule{window: time.Second}
+		assert.ErrorContains(t, section.Validate(), problem, window)
+	}
+
+	section.LatencySchedule = config.LatencySchedule{"09:00-17:00": -time.Second}
+	assert.ErrorContains(t, section.Validate(), "negative")
+}
+
+func TestSection_Validate_PartialCollectionSize(t *testing.T) {
+	section := config.Section{PathPattern: "/items/*", PartialCollectionSize: 10}
+	assert.NoError(t, section.Validate())
+
+	section.PartialCollectionSize = -1
+	assert.ErrorContains(t, section.Validate(), "partial_collection_size")
+}
+
+func TestSection_Validate_NumericPrecision(t *testing.T) {
+	section := config.Section{PathPattern: "/items/*", NumericPrecision: 2}
+	assert.NoError(t, section.Validate())
+
+	for _, precision := range []int{-1, config.MaxNumericPrecision + 1} {
+		section.NumericPrecision = precision
+		assert.ErrorContains(t, section.Validate(), "numeric_precision")
+	}
+}
+
+func TestSection_Validate_PollIntervalHeader(t *testing.T) {
+	section := config.Section{PathPattern: "/jobs/*", PollIntervalHeader: 5 * time.Second}
+	assert.NoError(t, section.Validate())
+
+	section.PollIntervalHeader = -time.Second
+	assert.ErrorContains(t, section.Validate(), "poll_interval_header")
+}
+
+func TestSection_Validate_LocationTemplate(t *testing.T) {
+	section := config.Section{PathPattern: "/orders/*", LocationTemplate: "/orders/{{.customerId}}/{{.orderId}}"}
+	assert.NoError(t, section.Validate())
+
+	section.LocationTemplate = "/orders/{{.customerId"
+	assert.ErrorContains(t, section.Validate(), "location_template")
+}
+
+func TestSection_Validate_ContentDisposition(t *testing.T) {
+	section := config.Section{PathPattern: "/invoices/*", ContentDisposition: "invoice-{{.ID}}.pdf"}
+	assert.NoError(t, section.Validate())
+
+	section.ContentDisposition = "invoice-{{.ID.pdf"
+	assert.ErrorContains(t, section.Validate(), "content_disposition")
+}
+
+func TestUniConfig_Validate_DependsOnResourceAt(t *testing.T) {
+	uc := config.UniConfig{Sections: map[string]config.Section{
+		"databases": {PathPattern: "/databases/*"},
+		"orders":    {PathPattern: "/orders/*", DependsOnResourceAt: "/databases/primary"},
+	}}
+	assert.NoError(t, uc.Validate())
+
+	uc.Sections["orders"] = config.Section{PathPattern: "/orders/*", DependsOnResourceAt: "/queues/main"}
+	assert.ErrorContains(t, uc.Validate(), "depends_on_resource_at")
+}
+
+func TestSection_Validate_PathPattern(t *testing.T) {
+	section := config.Section{PathPattern: "/users/*", PathPatterns: []string{"/v2/users/*"}}
+	assert.NoError(t, section.Validate())
+
+	section.PathPatterns = []string{"v2/users/*"}
+	assert.ErrorContains(t, section.Validate(), "v2/users/*")
+}
+
+func TestScenarioConfig_Validate(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "fixtures"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "fixtures", "user.json"), []byte(`{}`), 0o600))
+	resolver := config.NewFixtureResolver(tempDir)
+
+	tests := []struct {
+		name     string
+		scenario config.ScenarioConfig
+		problem  string
+	}{
+		{name: "valid", scenario: config.ScenarioConfig{Method: "get", Path: "/users", StatusCode: 204}},
+		{name: "existing fixture", scenario: config.ScenarioConfig{Method: "GET", Path: "/u", Data: "@fixtures/user.json"}},
+		{name: "XML data", scenario: config.ScenarioConfig{Method: "GET", Path: "/u", Data: "<user><id>1</id></user>"}},
+		{name: "unknown method", scenario: config.ScenarioConfig{Method: "FETCH", Path: "/u"}, problem: "FETCH"},
+		{name: "relative path", scenario: config.ScenarioConfig{Method: "GET", Path: "u"}, problem: "must start with /"},
+		{name: "status code", scenario: config.ScenarioConfig{Method: "GET", Path: "/u", StatusCode: 42}, problem: "42"},
+		{
+			name:     "negative hit limit",
+			scenario: config.ScenarioConfig{Method: "GET", Path: "/u", ExpireAfterHits: -1},
+			problem:  "expire_after_hits",
+		},
+		{name: "request line only", scenario: config.ScenarioConfig{MatchRequestLine: `^GET /u\?q=\w+$`}},
+		{
+			name:     "invalid request line",
+			scenario: config.ScenarioConfig{MatchRequestLine: "^GET /u/("},
+			problem:  "match_request_line",
+		},
+		{name: "protocol", scenario: config.ScenarioConfig{Method: "GET", Path: "/u", ProtoMatch: "HTTP/2"}},
+		{
+			name:     "invalid protocol",
+			scenario: config.ScenarioConfig{Method: "GET", Path: "/u", ProtoMatch: "SPDY/3"},
+			problem:  "proto_match",
+		},
+		{
+			name:     "missing fixture",
+			scenario: config.ScenarioConfig{Method: "GET", Path: "/u", Data: "< ./fixtures/missing.json"},
+			problem:  "missing.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scenario.Validate(resolver)
+
+			if tt.problem != "" {
+				assert.ErrorContains(t, err, tt.problem)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromYAML_WithoutValidation_ReportsAllProblems(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+sections:
+  orders:
+    path_pattern: "/orders/*"
+    read_from: "missing"
+  users:
+    path_pattern: "users/*"
+scenarios:
+  - method: "GET"
+    path: "/health"
+    status_code: 1000
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	_, err := config.LoadFromYAML(configPath)
+	require.Error(t, err)
+
+	uniConfig, err := config.LoadFromYAML(configPath, config.WithoutValidation())
+	require.NoError(t, err)
+	problems := uniConfig.ValidationErrors()
+	require.Len(t, problems, 3)
+	assert.ErrorContains(t, problems[0], "section orders")
+	assert.ErrorContains(t, problems[1], "section users")
+	assert.ErrorContains(t, problems[2], "scenario 1 (GET /health)")
+}
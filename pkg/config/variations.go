@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Variations make a section's successful responses vary like those of a noisy real service, for
+// soak and load tests: each 2xx response is varied with the given probability by answering with a
+// status code drawn from StatusCodes and padding the body up to a size drawn from BodySize.
+type Variations struct {
+	// Probability is the chance, between 0 and 1, that a successful response is varied (default: 1)
+	Probability *float64 `yaml:"probability,omitempty" json:"probability,omitempty"`
+
+	// StatusCodes are the 2xx codes varied responses are answered with, drawn by weight
+	StatusCodes []WeightedStatus `yaml:"status_codes,omitempty" json:"status_codes,omitempty"`
+
+	// BodySize is the range of sizes in bytes varied bodies are padded up to; larger bodies are kept
+	BodySize *SizeRange `yaml:"body_size,omitempty" json:"body_size,omitempty"`
+
+	// Seed fixes the random sequence so that runs vary the same way (default: 0, a new sequence per run)
+	Seed int64 `yaml:"seed,omitempty" json:"seed,omitempty"`
+}
+
+// WeightedStatus is a status code with its relative weight among the variations' status codes
+type WeightedStatus struct {
+	Code   int `yaml:"code" json:"code"`
+	Weight int `yaml:"weight,omitempty" json:"weight,omitempty"` // default: 1
+}
+
+// SizeRange is an inclusive range of sizes in bytes
+type SizeRange struct {
+	Min int `yaml:"min" json:"min"`
+	Max int `yaml:"max" json:"max"`
+}
+
+// Chance returns the probability that a response is varied
+func (v *Variations) Chance() float64 {
+	if v.Probability == nil {
+		return 1
+	}
+	return *v.Probability
+}
+
+// Validate checks the probability, that status codes are 2xx with non-negative weights and that
+// the body size range is ordered and non-negative
+func (v *Variations) Validate() error {
+	if chance := v.Chance(); chance < 0 || chance > 1 {
+		return fmt.Errorf("variations probability must be between 0 and 1, got %g", chance)
+	}
+	for _, status := range v.StatusCodes {
+		if status.Code < 200 || status.Code > 299 {
+			return fmt.Errorf("variations status codes must be between 200 and 299, got %d", status.Code)
+		}
+		if status.Weight < 0 {
+			return fmt.Errorf("variations status code %d has negative weight %d", status.Code, status.Weight)
+		}
+	}
+	if v.BodySize != nil && (v.BodySize.Min < 0 || v.BodySize.Min > v.BodySize.Max) {
+		return errors.New("variations body_size needs 0 <= min <= max")
+	}
+	return nil
+}
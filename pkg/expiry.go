@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bmcszk/unimock/internal/storage"
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+// expirySweeper runs the expiry sweeper once a section has a TTL, whether the configuration the server
+// started with has one or a configuration applied at runtime adds it
+type expirySweeper struct {
+	store     storage.UniStorage
+	uniConfig *config.UniConfig
+	interval  time.Duration
+	logger    *slog.Logger
+
+	mu      sync.Mutex
+	stop    func() // nil until the sweeper is started
+	stopped bool   // set by Stop, after which the sweeper is not started again
+}
+
+// startIfNeeded starts the sweeper when a section has a TTL and the sweeper is not running yet
+func (s *expirySweeper) startIfNeeded() {
+	if !s.uniConfig.HasTTLSections() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil && !s.stopped {
+		s.stop = startExpirySweeper(s.store, s.interval, s.logger)
+	}
+}
+
+// Stop stops the sweeper if it runs and keeps it from being started later
+func (s *expirySweeper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	if s.stop != nil {
+		s.stop()
+	}
+}
+
+// startExpirySweeper purges expired resources from store every interval until the returned stop function is called
+func startExpirySweeper(store storage.UniStorage, interval time.Duration, logger *slog.Logger) func() {
+	if interval <= 0 {
+		interval = config.DefaultExpirySweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if purged := store.PurgeExpired(now); purged > 0 {
+					logger.Debug("purged expired resources", "count", purged)
+				}
+			}
+		}
+	}()
+
+	logger.Info("expiry sweeper started", "interval", interval)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package pkg_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer_SweepsSectionsWithTTLAppliedAtRuntime(t *testing.T) {
+	server, err := pkg.NewServer(&config.ServerConfig{
+		Port: "0", LogLevel: "error", ExpirySweepInterval: 20 * time.Millisecond,
+	}, &config.UniConfig{Sections: map[string]config.Section{"users": {PathPattern: "/users/*"}}})
+	require.NoError(t, err)
+	defer func() { _ = server.Shutdown(context.Background()) }()
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/_uni/config?mode=merge", `
+sections:
+  sessions:
+    path_pattern: "/sessions/*"
+    body_id_paths: ["/id"]
+    versioned: true
+    ttl: 50ms
+`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, http.StatusCreated, serve(http.MethodPost, "/sessions", `{"id":"s1"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPut, "/sessions/s1", `{"id":"s1","v":2}`).Code)
+
+	// Stats skip expired resources but count history until the sweeper purges it with them
+	assert.Eventually(t, func() bool {
+		var stats struct {
+			MemoryBytes int64 `json:"memory_bytes"`
+		}
+		return json.Unmarshal(serve(http.MethodGet, "/_uni/stats", "").Body.Bytes(), &stats) == nil &&
+			stats.MemoryBytes == 0
+	}, time.Second, 10*time.Millisecond, "expired resources are purged without further writes")
+}
@@ -0,0 +1,24 @@
+package pkg
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/bmcszk/unimock/pkg/config"
+	"golang.org/x/net/netutil"
+)
+
+// Listen opens the TCP listener for a server created by NewServer. When serverConfig.MaxConnections
+// is set, at most that many connections are served at once; further clients complete the TCP
+// handshake but wait in the accept backlog, without a response, until another connection closes.
+// Serve on it with srv.Serve(ln), or srv.ServeTLS(ln, "", "") when srv.TLSConfig is set.
+func Listen(serverConfig *config.ServerConfig, srv *http.Server) (net.Listener, error) {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if serverConfig != nil && serverConfig.MaxConnections > 0 {
+		ln = netutil.LimitListener(ln, serverConfig.MaxConnections)
+	}
+	return ln, nil
+}
@@ -0,0 +1,78 @@
+package pkg_test
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen_MaxConnections(t *testing.T) {
+	serverConfig := &config.ServerConfig{MaxConnections: 2}
+	srv := &http.Server{
+		Addr:              "127.0.0.1:0",
+		ReadHeaderTimeout: time.Minute,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	ln, err := pkg.Listen(serverConfig, srv)
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	// Fill the limit with idle connections, then open one more
+	var held []net.Conn
+	for i := 0; i < serverConfig.MaxConnections; i++ {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		held = append(held, conn)
+	}
+	excess, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = excess.Close() })
+
+	_, err = excess.Write([]byte("GET / HTTP/1.1\r\nHost: unimock\r\n\r\n"))
+	require.NoError(t, err)
+	reader := bufio.NewReader(excess)
+
+	require.NoError(t, excess.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+	_, err = reader.ReadByte()
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr, "the excess connection must not be served while the limit is reached")
+	assert.True(t, netErr.Timeout())
+
+	require.NoError(t, held[0].Close())
+
+	require.NoError(t, excess.SetReadDeadline(time.Now().Add(5*time.Second)))
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestListen_Unlimited(t *testing.T) {
+	ln, err := pkg.Listen(config.NewDefaultServerConfig(), &http.Server{Addr: "127.0.0.1:0"})
+	require.NoError(t, err)
+	defer ln.Close()
+
+	var conns []net.Conn
+	for i := 0; i < 5; i++ {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+	for range conns {
+		accepted, err := ln.Accept()
+		require.NoError(t, err)
+		_ = accepted.Close()
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+// Failure forces every request with the given method and path to fail with an error response,
+// regardless of scenarios and stored resources. Failures are managed via /_uni/failures.
+type Failure struct {
+	// ID is the unique identifier of the failure
+	// If not provided when creating, a UUID will be generated automatically
+	ID string `json:"id,omitempty"`
+
+	// Method is the HTTP method that fails (e.g., "GET" or "POST")
+	Method string `json:"method"`
+
+	// Path is the request path that fails; a trailing "/*" matches every path below it
+	Path string `json:"path"`
+
+	// StatusCode is the 4xx or 5xx status code returned
+	StatusCode int `json:"statusCode"`
+
+	// Message is the plain-text response body (default: the status text)
+	Message string `json:"message,omitempty"`
+}
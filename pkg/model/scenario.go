@@ -1,5 +1,10 @@
 package model
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Scenario represents a predefined mock scenario for specific API requests
 // Scenarios allow bypassing the normal mocking behavior for certain paths,
 // enabling precise control over specific API responses.
@@ -8,6 +13,10 @@ type Scenario struct {
 	// If not provided when creating, a UUID will be generated automatically
 	UUID string `json:"uuid,omitempty"`
 
+	// Priority decides between scenarios matching the same request: the highest wins,
+	// ties go to the most specific path and then to the earliest created scenario (default: 0)
+	Priority int `json:"priority,omitempty"`
+
 	// RequestPath defines which requests this scenario handles
 	// Format: "METHOD /path" (e.g., "GET /api/users" or "POST /orders")
 	// The path portion can contain wildcards (e.g., "GET /users/*")
@@ -29,4 +38,130 @@ type Scenario struct {
 
 	// Headers is a map of HTTP headers to return with the scenario response
 	Headers map[string]string `json:"headers,omitempty"`
+
+	// MatchContentLength restricts the scenario to requests whose body size is within the range
+	// If nil, the scenario matches regardless of the request body size
+	MatchContentLength *ContentLengthRange `json:"matchContentLength,omitempty"`
+
+	// RequireFlag restricts the scenario to requests whose X-Feature-Flags header
+	// (a comma-separated list) contains this flag. Flagged scenarios win over unflagged ones.
+	RequireFlag string `json:"requireFlag,omitempty"`
+
+	// CookieMatch restricts the scenario to requests carrying these cookies with these values,
+	// "*" matching any value of a present cookie. Cookie-matched scenarios win over unmatched ones.
+	CookieMatch map[string]string `json:"cookieMatch,omitempty"`
+
+	// PadToBytes pads the response body up to the given size in bytes
+	// JSON bodies are padded with whitespace before the closing bracket, other bodies with trailing spaces
+	PadToBytes int `json:"padToBytes,omitempty"`
+
+	// RandomBytes replaces the response body with the given number of random bytes
+	RandomBytes int `json:"randomBytes,omitempty"`
+
+	// ThrottleBytesPerSec limits the response write rate to simulate slow links (0 = unthrottled)
+	ThrottleBytesPerSec int `json:"throttleBytesPerSec,omitempty"`
+
+	// Enabled switches the scenario on or off without deleting it
+	// If nil, the scenario is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ActiveFrom and ActiveUntil optionally bound the time window in which the scenario matches
+	// ActiveFrom is inclusive, ActiveUntil exclusive; nil leaves that side of the window open
+	ActiveFrom  *time.Time `json:"activeFrom,omitempty"`
+	ActiveUntil *time.Time `json:"activeUntil,omitempty"`
+
+	// ThresholdResponses switch the response once the scenario has been hit often enough,
+	// e.g. to answer 429 after 100 calls. Hits are counted from creation or the last reset.
+	ThresholdResponses []ThresholdResponse `json:"thresholdResponses,omitempty"`
+
+	// ExpireAfterHits stops the scenario from matching once it has served this many hits, so later
+	// requests fall through to the mock storage, e.g. to model a warm-up period (0 = never expires).
+	// Like threshold responses, hits are counted from creation or the last reset.
+	ExpireAfterHits int `json:"expireAfterHits,omitempty"`
+
+	// CloseConnection sends Connection: close, making the server close the connection after the response
+	CloseConnection bool `json:"closeConnection,omitempty"`
+
+	// MatchRequestLine is a regular expression matched against the raw request line "METHOD /path?query"
+	// instead of RequestPath, which may then be left empty. Its groups fill the templates in Data:
+	// {{.Line1}}, {{.Line2}}, ... in order and named groups by name.
+	MatchRequestLine string `json:"matchRequestLine,omitempty"`
+
+	// ProtoMatch restricts the scenario to requests of an HTTP protocol version, e.g. "HTTP/1.1",
+	// or "HTTP/2" for any 2.x (see config.ParseProtoMatch). If empty, any protocol matches.
+	ProtoMatch string `json:"protoMatch,omitempty"`
+
+	// ResponseSchema is a JSON Schema of the response body. Scenarios without Data respond with an
+	// example generated from it (see config.SchemaExample).
+	ResponseSchema json.RawMessage `json:"responseSchema,omitempty"`
+}
+
+// ThresholdResponse replaces the scenario response for every hit after the first AfterHits hits.
+// Empty ContentType and Headers keep the scenario's own values.
+type ThresholdResponse struct {
+	// AfterHits is how many hits are answered before this response takes over
+	AfterHits int64 `json:"afterHits" yaml:"after_hits"`
+
+	// StatusCode, ContentType, Data and Headers make up the response
+	StatusCode  int               `json:"statusCode" yaml:"status_code"`
+	ContentType string            `json:"contentType,omitempty" yaml:"content_type,omitempty"`
+	Data        string            `json:"data,omitempty" yaml:"data,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// ResponseForHit returns the scenario with its response replaced by the threshold response with the
+// highest AfterHits below the given 1-based hit number; without one the scenario is returned as is
+func (s *Scenario) ResponseForHit(hit int64) Scenario {
+	var selected *ThresholdResponse
+	for i := range s.ThresholdResponses {
+		threshold := &s.ThresholdResponses[i]
+		if hit > threshold.AfterHits && (selected == nil || threshold.AfterHits > selected.AfterHits) {
+			selected = threshold
+		}
+	}
+	response := *s
+	if selected == nil {
+		return response
+	}
+
+	response.StatusCode = selected.StatusCode
+	response.Data = selected.Data
+	if selected.ContentType != "" {
+		response.ContentType = selected.ContentType
+	}
+	if selected.Headers != nil {
+		response.Headers = selected.Headers
+	}
+	return response
+}
+
+// IsActive reports whether the scenario is enabled and within its activation window at the given time
+func (s *Scenario) IsActive(now time.Time) bool {
+	if s.Enabled != nil && !*s.Enabled {
+		return false
+	}
+	if s.ActiveFrom != nil && now.Before(*s.ActiveFrom) {
+		return false
+	}
+	return s.ActiveUntil == nil || now.Before(*s.ActiveUntil)
+}
+
+// ContentLengthRange defines inclusive bounds for a request body size in bytes
+type ContentLengthRange struct {
+	// Min is the smallest accepted body size (default: 0)
+	Min int64 `json:"min" yaml:"min"`
+
+	// Max is the largest accepted body size; nil means unbounded
+	Max *int64 `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
+// Contains reports whether the given body size falls within the range
+func (r *ContentLengthRange) Contains(length int64) bool {
+	if r == nil {
+		return true
+	}
+	if length < r.Min {
+		return false
+	}
+	return r.Max == nil || length <= *r.Max
 }
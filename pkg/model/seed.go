@@ -0,0 +1,17 @@
+package model
+
+import "encoding/json"
+
+// SeedItem is a resource inserted directly into the mock storage via /_uni/seed, skipping ID extraction:
+// it is stored under the last segment of its path as ID, in the section matching the path.
+type SeedItem struct {
+	// Path is the resource path, e.g. "/users/123"
+	Path string `json:"path"`
+
+	// ContentType of the resource (default: "application/json")
+	ContentType string `json:"contentType,omitempty"`
+
+	// Body is the resource body: any JSON value for JSON resources, a JSON string holding the raw body
+	// for other content types
+	Body json.RawMessage `json:"body"`
+}
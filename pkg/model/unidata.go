@@ -1,6 +1,8 @@
 // Package model provides data structures for Unimock's HTTP mocking and scenarios.
 package model
 
+import "time"
+
 // UniData represents the data stored for a uni HTTP resource
 type UniData struct {
 	// Path of the resource (e.g., "/users/123")
@@ -20,4 +22,25 @@ type UniData struct {
 	// Body contains the raw response data to be returned
 	// For JSON responses, this is the serialized JSON body
 	Body []byte `json:"body"`
+
+	// WrittenAt records when the resource was last created or updated through the HTTP API.
+	// Sections with a read delay use it to decide when the resource becomes visible.
+	WrittenAt time.Time `json:"written_at"`
+
+	// ExpiresAt is when the resource expires in sections with a TTL; zero means it never expires.
+	// Expired resources are treated as not found and purged from storage.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// Seq is the storage-assigned creation sequence number. It increases with every created resource
+	// and is kept across updates, so ordering by it lists resources in creation order.
+	Seq uint64 `json:"seq,omitempty"`
+
+	// Version is the storage-assigned version of the resource: 1 when created and increased by every
+	// update, so conditional updates can detect changes made in the meantime.
+	Version int `json:"version,omitempty"`
+}
+
+// IsExpired reports whether the resource has an expiry that has passed at the given time
+func (d *UniData) IsExpired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && !now.Before(d.ExpiresAt)
 }
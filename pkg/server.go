@@ -15,12 +15,6 @@ import (
 	"github.com/bmcszk/unimock/pkg/model"
 )
 
-const (
-	readTimeout  = 10 * time.Second
-	writeTimeout = 10 * time.Second
-	idleTimeout  = 120 * time.Second
-)
-
 // ConfigError represents a configuration error
 type ConfigError struct {
 	Message string
@@ -41,22 +35,43 @@ func validateConfiguration(
 		return &ConfigError{Message: err}
 	}
 
-	sectionCount := len(uniConfig.Sections)
-	scenarioCount := len(uniConfig.Scenarios)
-
-	if sectionCount == 0 && scenarioCount == 0 {
+	if !hasMocks(uniConfig) {
 		err := "no sections or scenarios defined in configuration"
 		logger.Error(err)
 		return &ConfigError{Message: err}
 	}
 
-	if sectionCount == 0 {
+	if len(uniConfig.Sections) == 0 {
 		logger.Info("running in scenarios-only mode - no sections configured")
 	}
 
+	if err := uniConfig.Validate(); err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return &ConfigError{Message: err.Error()}
+	}
+
 	return nil
 }
 
+// hasMocks reports whether the configuration defines at least one section or scenario
+func hasMocks(uniConfig *config.UniConfig) bool {
+	return len(uniConfig.Sections) > 0 || len(uniConfig.Scenarios) > 0
+}
+
+// ValidateConfig checks a configuration the way NewServer does at startup, but reports every problem
+// instead of the first, including scenarios the server would skip or whose fixture files are missing.
+// Load the configuration with config.WithoutValidation to see all section problems as well.
+func ValidateConfig(uniConfig *config.UniConfig) []error {
+	if uniConfig == nil {
+		return []error{&ConfigError{Message: "uni configuration is nil"}}
+	}
+	var problems []error
+	if !hasMocks(uniConfig) {
+		problems = append(problems, &ConfigError{Message: "no sections or scenarios defined in configuration"})
+	}
+	return append(problems, uniConfig.ValidationErrors()...)
+}
+
 // setupLogger creates a new logger with the specified level
 func setupLogger(level string) *slog.Logger {
 	var logLevel slog.Level
@@ -146,6 +161,10 @@ func setupLogger(level string) *slog.Logger {
 //	if err := srv.ListenAndServe(); err != nil {
 //	    log.Fatal(err)
 //	}
+//
+// When serverConfig enables TLS (TLSCertFile/TLSKeyFile or TLSAutoCert), the returned
+// server carries a TLSConfig with the certificate loaded; start it with
+// srv.ListenAndServeTLS("", "") and use serverConfig.Scheme() to build the base URL.
 func NewServer(serverConfig *config.ServerConfig, uniConfig *config.UniConfig) (*http.Server, error) {
 	if serverConfig == nil {
 		serverConfig = config.NewDefaultServerConfig()
@@ -156,15 +175,43 @@ func NewServer(serverConfig *config.ServerConfig, uniConfig *config.UniConfig) (
 
 	logger.Info("initializing server",
 		"port", serverConfig.Port,
-		"log_level", serverConfig.LogLevel)
+		"log_level", serverConfig.LogLevel,
+		"scheme", serverConfig.Scheme())
 
 	// Validate uni configuration
 	if err := validateConfiguration(uniConfig, logger); err != nil {
 		return nil, err
 	}
 
-	// Create a new storage
-	store := storage.NewUniStorage()
+	if err := config.ValidateEvictionPolicy(serverConfig.EvictionPolicy); err != nil {
+		logger.Error("invalid eviction policy", "error", err)
+		return nil, err
+	}
+
+	if err := config.ValidateTrailingSlash(serverConfig.TrailingSlash); err != nil {
+		logger.Error("invalid trailing slash mode", "error", err)
+		return nil, err
+	}
+	if serverConfig.TrailingSlash == config.TrailingSlashPreserve {
+		uniConfig.PreserveTrailingSlash()
+	}
+
+	tlsConfig, err := buildTLSConfig(serverConfig)
+	if err != nil {
+		logger.Error("invalid TLS configuration", "error", err)
+		return nil, err
+	}
+
+	// Create a new storage, generating sequential IDs when deterministic IDs are requested
+	idGen := storage.NewRandomIDGenerator()
+	if serverConfig.DeterministicIDs {
+		idGen = storage.NewSequentialIDGenerator(serverConfig.IDSeed)
+	}
+	store := storage.NewUniStorageWithIDGenerator(idGen)
+	uniConfig.UseIDGenerator(idGen.NewID)
+	if serverConfig.MaxResources > 0 {
+		store.LimitResources(serverConfig.MaxResources, serverConfig.EvictionPolicy == config.EvictionReject)
+	}
 
 	// Create a new scenario storage
 	scenarioStore := storage.NewScenarioStorage()
@@ -180,22 +227,67 @@ func NewServer(serverConfig *config.ServerConfig, uniConfig *config.UniConfig) (
 	// Create handlers with services
 	uniHandler := handler.NewUniHandler(uniService, scenarioService, logger, uniConfig)
 	scenarioHandler := handler.NewScenarioHandler(scenarioService, logger)
-	techHandler := handler.NewTechHandler(techService, logger)
+	failureService := service.NewFailureService()
+	failureHandler := handler.NewFailureHandler(failureService, logger)
+	techHandler := handler.NewTechHandler(techService, uniService, scenarioService, logger, uniConfig)
 
 	// Create a router
 	appRouter := router.NewRouter(
-		uniHandler, techHandler, scenarioHandler,
-		scenarioService, techService, logger, uniConfig,
+		uniHandler, techHandler, scenarioHandler, failureHandler,
+		scenarioService, failureService, techService, logger, uniConfig,
 	)
+	appRouter.RequireAdminKey(serverConfig.AdminAPIKey)
+	if serverConfig.PrettyJSON {
+		appRouter.EnablePrettyJSON()
+	}
+	if serverConfig.Gzip {
+		appRouter.EnableGzip()
+	}
+	if serverConfig.RejectUnsafeHeaders {
+		appRouter.EnableHeaderRejection()
+	}
+	if serverConfig.SniffContentType {
+		appRouter.EnableContentTypeSniffing()
+	}
+	if serverConfig.TrailingSlash == config.TrailingSlashRedirect {
+		appRouter.EnableTrailingSlashRedirect()
+	}
+	if serverConfig.MaxRequestsPerConnection > 0 {
+		appRouter.EnableMaxRequestsPerConnection(serverConfig.MaxRequestsPerConnection)
+	}
+	if serverConfig.StartupDelay > 0 {
+		appRouter.EnableStartupDelay(serverConfig.StartupDelay)
+	}
+	appRouter.SetDrainPeriod(durationOrDefault(serverConfig.DrainPeriod, config.DefaultDrainPeriod))
+	if serverConfig.LogBodies {
+		if err := appRouter.EnableBodyLogging(serverConfig.LogRedactPaths, serverConfig.LogBodyMaxBytes); err != nil {
+			logger.Error("invalid body logging configuration", "error", err)
+			return nil, err
+		}
+	}
 
 	// Create server
 	srv := &http.Server{
-		Addr:         ":" + serverConfig.Port,
-		Handler:      appRouter,
-		ReadTimeout:  readTimeout,
-		WriteTimeout: writeTimeout,
-		IdleTimeout:  idleTimeout,
+		Addr:              ":" + serverConfig.Port,
+		Handler:           appRouter,
+		ReadTimeout:       durationOrDefault(serverConfig.ReadTimeout, config.DefaultReadTimeout),
+		ReadHeaderTimeout: durationOrDefault(serverConfig.ReadHeaderTimeout, config.DefaultReadHeaderTimeout),
+		WriteTimeout:      durationOrDefault(serverConfig.WriteTimeout, config.DefaultWriteTimeout),
+		IdleTimeout:       durationOrDefault(serverConfig.IdleTimeout, config.DefaultIdleTimeout),
+		TLSConfig:         tlsConfig,
+	}
+	if serverConfig.MaxRequestsPerConnection > 0 {
+		srv.ConnContext = router.CountConnectionRequests
 	}
+	srv.SetKeepAlivesEnabled(!serverConfig.DisableKeepAlives)
+
+	// Purge expired resources in the background while the server runs, once a section has a TTL
+	sweeper := &expirySweeper{
+		store: store, uniConfig: uniConfig, interval: serverConfig.ExpirySweepInterval, logger: logger,
+	}
+	sweeper.startIfNeeded()
+	uniConfig.OnApply(sweeper.startIfNeeded)
+	srv.RegisterOnShutdown(sweeper.Stop)
 
 	// Return the created server
 	logger.Info("server initialization complete, ready to start")
@@ -242,3 +334,11 @@ func loadScenariosFromUniConfig(
 		"loaded_scenarios", loadedCount,
 		"failed_scenarios", len(modelScenarios)-loadedCount)
 }
+
+// durationOrDefault returns d, or def when d is not positive
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
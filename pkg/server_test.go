@@ -2,12 +2,15 @@ package pkg_test
 
 import (
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httptrace"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bmcszk/unimock/pkg"
 	"github.com/bmcszk/unimock/pkg/config"
@@ -218,3 +221,222 @@ func createTempConfigFile(t *testing.T, content string) string {
 
 	return configFile
 }
+
+func TestNewServer_DeterministicIDs(t *testing.T) {
+	serverConfig := &config.ServerConfig{Port: "0", LogLevel: "error", DeterministicIDs: true}
+	uniConfig := &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+
+	server, err := pkg.NewServer(serverConfig, uniConfig)
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		"/users/00000000-0000-0000-0000-000000000001",
+		"/users/00000000-0000-0000-0000-000000000002",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Alice"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, want, w.Header().Get("Location"))
+	}
+}
+
+func TestNewServer_Timeouts(t *testing.T) {
+	uniConfig := &config.UniConfig{
+		Sections: map[string]config.Section{"users": {PathPattern: "/users/*"}},
+	}
+
+	server, err := pkg.NewServer(&config.ServerConfig{Port: "0", LogLevel: "error"}, uniConfig)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultReadTimeout, server.ReadTimeout)
+	assert.Equal(t, config.DefaultReadHeaderTimeout, server.ReadHeaderTimeout)
+	assert.Equal(t, config.DefaultWriteTimeout, server.WriteTimeout)
+	assert.Equal(t, config.DefaultIdleTimeout, server.IdleTimeout)
+
+	server, err = pkg.NewServer(&config.ServerConfig{
+		Port:              "0",
+		LogLevel:          "error",
+		ReadTimeout:       time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      3 * time.Second,
+		IdleTimeout:       4 * time.Second,
+	}, uniConfig)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, server.ReadTimeout)
+	assert.Equal(t, 2*time.Second, server.ReadHeaderTimeout)
+	assert.Equal(t, 3*time.Second, server.WriteTimeout)
+	assert.Equal(t, 4*time.Second, server.IdleTimeout)
+}
+
+func TestNewServer_ReadHeaderTimeoutClosesSlowClients(t *testing.T) {
+	server, err := pkg.NewServer(&config.ServerConfig{
+		Port:              "0",
+		LogLevel:          "error",
+		ReadHeaderTimeout: 100 * time.Millisecond,
+	}, &config.UniConfig{Sections: map[string]config.Section{"users": {PathPattern: "/users/*"}}})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Send an incomplete request line and wait for the server to give up
+	_, err = conn.Write([]byte("GET /_uni/health HTTP/1.1\r\nHost: localhost\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+	start := time.Now()
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err, "server should close the connection before the read deadline")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// connectionReuse serves the server on a local listener, sends the requests over one keep-alive
+// client and reports for each whether it reused a previous connection
+func connectionReuse(t *testing.T, serverConfig *config.ServerConfig, paths ...string) []bool {
+	t.Helper()
+	uniConfig := &config.UniConfig{Sections: map[string]config.Section{
+		"users":  {PathPattern: "/users/*"},
+		"legacy": {PathPattern: "/legacy/*", CloseConnection: true},
+	}}
+	serverConfig.Port, serverConfig.LogLevel = "0", "error"
+	server, err := pkg.NewServer(serverConfig, uniConfig)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{}}
+	defer client.CloseIdleConnections()
+	reused := make([]bool, 0, len(paths))
+	for _, path := range paths {
+		var connInfo httptrace.GotConnInfo
+		trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) { connInfo = info }}
+		req, err := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String()+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		reused = append(reused, connInfo.Reused)
+	}
+	return reused
+}
+
+func TestNewServer_KeepAlive(t *testing.T) {
+	reused := connectionReuse(t, &config.ServerConfig{}, "/users", "/users", "/users")
+	assert.Equal(t, []bool{false, true, true}, reused)
+}
+
+func TestNewServer_DisableKeepAlives(t *testing.T) {
+	reused := connectionReuse(t, &config.ServerConfig{DisableKeepAlives: true}, "/users", "/users", "/users")
+	assert.Equal(t, []bool{false, false, false}, reused)
+}
+
+func TestNewServer_MaxRequestsPerConnection(t *testing.T) {
+	reused := connectionReuse(t, &config.ServerConfig{MaxRequestsPerConnection: 2},
+		"/users", "/users", "/users", "/users", "/users")
+	assert.Equal(t, []bool{false, true, false, true, false}, reused)
+}
+
+func TestNewServer_SectionCloseConnection(t *testing.T) {
+	reused := connectionReuse(t, &config.ServerConfig{}, "/users", "/legacy", "/users", "/users")
+	assert.Equal(t, []bool{false, true, false, true}, reused)
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := &config.UniConfig{
+		Sections:  map[string]config.Section{"users": {PathPattern: "/users/*"}},
+		Scenarios: []config.ScenarioConfig{{Method: "GET", Path: "/health"}},
+	}
+	assert.Empty(t, pkg.ValidateConfig(valid))
+
+	assert.Len(t, pkg.ValidateConfig(config.NewUniConfig()), 1)
+
+	invalid := &config.UniConfig{
+		Sections:  map[string]config.Section{"users": {PathPattern: "/users/*", ReadFrom: "missing"}},
+		Scenarios: []config.ScenarioConfig{{Method: "GET", Path: "health"}},
+	}
+	problems := pkg.ValidateConfig(invalid)
+	require.Len(t, problems, 2)
+	assert.ErrorContains(t, problems[0], "read_from")
+	assert.ErrorContains(t, problems[1], "scenario 1")
+}
+
+func TestNewServer_TrailingSlash(t *testing.T) {
+	uniConfig := func() *config.UniConfig {
+		return &config.UniConfig{
+			Sections: map[string]config.Section{
+				"users":   {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+				"folders": {PathPattern: "/folders/*/", BodyIDPaths: []string{"/id"}},
+			},
+		}
+	}
+	serve := func(t *testing.T, mode, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		server, err := pkg.NewServer(&config.ServerConfig{LogLevel: "error", TrailingSlash: mode}, uniConfig())
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		server.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("strip ignores the slash", func(t *testing.T) {
+		w := serve(t, config.TrailingSlashStrip, http.MethodPost, "/users/", `{"id":"1"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("redirect answers 301 to the canonical path", func(t *testing.T) {
+		w := serve(t, config.TrailingSlashRedirect, http.MethodGet, "/users/1/?fields=name", "")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/users/1?fields=name", w.Header().Get("Location"))
+	})
+
+	t.Run("redirect stays on the host", func(t *testing.T) {
+		w := serve(t, config.TrailingSlashRedirect, http.MethodGet, "//example.com/", "")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/example.com", w.Header().Get("Location"))
+	})
+
+	t.Run("preserve matches patterns by trailing slash", func(t *testing.T) {
+		server, err := pkg.NewServer(
+			&config.ServerConfig{LogLevel: "error", TrailingSlash: config.TrailingSlashPreserve}, uniConfig())
+		require.NoError(t, err)
+		do := func(method, path, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			server.Handler.ServeHTTP(w, req)
+			return w
+		}
+
+		created := do(http.MethodPost, "/folders/", `{"id":"docs"}`)
+		require.Equal(t, http.StatusCreated, created.Code)
+		assert.Equal(t, "/folders/docs/", created.Header().Get("Location"))
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/folders/docs/", "").Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/folders/docs", "").Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/users/1/", "").Code)
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		_, err := pkg.NewServer(&config.ServerConfig{LogLevel: "error", TrailingSlash: "keep"}, uniConfig())
+		assert.Error(t, err)
+	})
+}
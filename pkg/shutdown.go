@@ -0,0 +1,17 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+)
+
+// Shutdown gracefully stops a server created by NewServer. For the configured drain period it keeps
+// accepting connections but answers every request except the /_uni/health liveness probe with
+// 503 Service Unavailable and Retry-After, the way a backend behaves during a rolling restart;
+// then it calls srv.Shutdown, which stops accepting connections and waits for in-flight requests.
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	if drainer, ok := srv.Handler.(interface{ Drain(context.Context) }); ok {
+		drainer.Drain(ctx)
+	}
+	return srv.Shutdown(ctx)
+}
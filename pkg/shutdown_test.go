@@ -0,0 +1,61 @@
+package pkg_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdown_DrainsBeforeClosing(t *testing.T) {
+	const drainPeriod = 300 * time.Millisecond
+	uniConfig := &config.UniConfig{
+		Sections: map[string]config.Section{"users": {PathPattern: "/users/*"}},
+		Scenarios: []config.ScenarioConfig{
+			{Method: http.MethodGet, Path: "/status", StatusCode: http.StatusOK, Data: `{"ok":true}`},
+		},
+	}
+	server, err := pkg.NewServer(&config.ServerConfig{
+		Port: "0", LogLevel: "error", DrainPeriod: drainPeriod,
+	}, uniConfig)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	defer server.Close()
+
+	baseURL := "http://" + listener.Addr().String()
+	get := func(path string) *http.Response {
+		resp, err := http.Get(baseURL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+	require.Equal(t, http.StatusOK, get("/status").StatusCode)
+
+	start := time.Now()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- pkg.Shutdown(context.Background(), server) }()
+	require.Eventually(t, func() bool {
+		return get("/status").StatusCode == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond, "scenarios are turned away while draining")
+
+	for _, path := range []string{"/status", "/users/1", "/_uni/ready"} {
+		resp := get(path)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, path)
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"), path)
+	}
+	assert.Equal(t, http.StatusOK, get("/_uni/health").StatusCode, "liveness probe keeps answering")
+
+	require.NoError(t, <-shutdown)
+	assert.GreaterOrEqual(t, time.Since(start), drainPeriod, "connections are accepted for the drain period")
+	assert.ErrorIs(t, <-served, http.ErrServerClosed)
+}
@@ -0,0 +1,126 @@
+package pkg_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveSection serves a server with the single section users on a local listener, stores the
+// resource body under /users/1 and returns the listener address
+func serveSection(t *testing.T, section config.Section, body string) string {
+	t.Helper()
+	section.PathPattern, section.BodyIDPaths = "/users/*", []string{"/id"}
+	server, err := pkg.NewServer(&config.ServerConfig{Port: "0", LogLevel: "error"},
+		&config.UniConfig{Sections: map[string]config.Section{"users": section}})
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	addr := listener.Addr().String()
+	resp, err := http.Post("http://"+addr+"/users", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	return addr
+}
+
+// readChunks sends a GET over a plain TCP connection and returns the chunks of the chunked
+// response body exactly as they arrived on the wire
+func readChunks(t *testing.T, addr, path string) []string {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", path, addr)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	chunked := false
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		chunked = chunked || strings.EqualFold(strings.TrimSpace(line), "Transfer-Encoding: chunked")
+		if line == "\r\n" {
+			break
+		}
+	}
+	require.True(t, chunked, "response is not chunked")
+
+	var chunks []string
+	for {
+		sizeLine, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		size, err := strconv.ParseInt(strings.TrimSpace(sizeLine), 16, 64)
+		require.NoError(t, err)
+		if size == 0 {
+			return chunks
+		}
+		chunk := make([]byte, size+2) // chunk data followed by CRLF
+		_, err = io.ReadFull(reader, chunk)
+		require.NoError(t, err)
+		chunks = append(chunks, string(chunk[:size]))
+	}
+}
+
+func TestNewServer_ChunkBoundaries(t *testing.T) {
+	addr := serveSection(t, config.Section{ChunkBoundaries: []int{5, 12}}, `{"id":"1","name":"streaming parser"}`)
+
+	chunks := readChunks(t, addr, "/users/1")
+
+	body := strings.Join(chunks, "")
+	require.Len(t, chunks, 3, "the router passes flushes through to the connection")
+	assert.Equal(t, body[:5], chunks[0])
+	assert.Equal(t, body[5:12], chunks[1])
+	assert.JSONEq(t, `{"id":"1","name":"streaming parser"}`, body)
+}
+
+// timeDelivery GETs the path and returns how long the first body byte and the whole body took
+func timeDelivery(t *testing.T, addr, path string) (firstByte, total time.Duration) {
+	t.Helper()
+	start := time.Now()
+	resp, err := http.Get("http://" + addr + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	first := make([]byte, 1)
+	_, err = io.ReadFull(resp.Body, first)
+	require.NoError(t, err)
+	firstByte = time.Since(start)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	return firstByte, time.Since(start)
+}
+
+func TestNewServer_ThrottlePacesDelivery(t *testing.T) {
+	// 36 bytes at 100 bytes per second go out in 10-byte chunks every 100ms
+	addr := serveSection(t, config.Section{ThrottleBytesPerSec: 100}, `{"id":"1","name":"streaming parser"}`)
+
+	firstByte, total := timeDelivery(t, addr, "/users/1")
+
+	assert.GreaterOrEqual(t, total, 250*time.Millisecond)
+	assert.Less(t, firstByte, 150*time.Millisecond, "the first chunk arrives before the rest is written")
+}
+
+func TestNewServer_SimulateBandwidthPacesDelivery(t *testing.T) {
+	// 36 bytes over a 100 bytes per second link arrive in 10-byte chunks, the last after 360ms
+	addr := serveSection(t, config.Section{SimulateBandwidth: 100}, `{"id":"1","name":"streaming parser"}`)
+
+	firstByte, total := timeDelivery(t, addr, "/users/1")
+
+	assert.GreaterOrEqual(t, total, 350*time.Millisecond)
+	assert.Less(t, firstByte, 250*time.Millisecond, "the first chunk arrives before the rest is written")
+}
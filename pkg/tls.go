@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/bmcszk/unimock/pkg/config"
+)
+
+const (
+	// selfSignedValidity is how long an auto-generated certificate stays valid
+	selfSignedValidity = 365 * 24 * time.Hour
+	// serialNumberBits bounds the random certificate serial number
+	serialNumberBits = 128
+)
+
+// buildTLSConfig returns the TLS configuration for the server, or nil when TLS is not enabled.
+// Certificate files take precedence over an auto-generated self-signed certificate.
+func buildTLSConfig(serverConfig *config.ServerConfig) (*tls.Config, error) {
+	if !serverConfig.TLSEnabled() {
+		return nil, nil
+	}
+
+	var (
+		cert tls.Certificate
+		err  error
+	)
+	switch {
+	case serverConfig.TLSCertFile != "" || serverConfig.TLSKeyFile != "":
+		if serverConfig.TLSCertFile == "" || serverConfig.TLSKeyFile == "" {
+			return nil, &ConfigError{Message: "both TLS certificate and key files must be set"}
+		}
+		cert, err = tls.LoadX509KeyPair(serverConfig.TLSCertFile, serverConfig.TLSKeyFile)
+		if err != nil {
+			return nil, &ConfigError{Message: fmt.Sprintf("failed to load TLS certificate: %v", err)}
+		}
+	default:
+		cert, err = generateSelfSignedCert()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// generateSelfSignedCert creates a self-signed certificate valid for localhost and 127.0.0.1
+func generateSelfSignedCert() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate TLS key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate certificate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Unimock"}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create self-signed certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to parse self-signed certificate: %w", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, nil
+}
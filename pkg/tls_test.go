@@ -0,0 +1,88 @@
+package pkg_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/bmcszk/unimock/pkg"
+	"github.com/bmcszk/unimock/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tlsTestUniConfig() *config.UniConfig {
+	return &config.UniConfig{
+		Sections: map[string]config.Section{
+			"users": {PathPattern: "/users/*", BodyIDPaths: []string{"/id"}},
+		},
+	}
+}
+
+func TestNewServer_TLSAutoCert(t *testing.T) {
+	serverConfig := &config.ServerConfig{Port: "0", LogLevel: "error", TLSAutoCert: true}
+
+	srv, err := pkg.NewServer(serverConfig, tlsTestUniConfig())
+	require.NoError(t, err)
+	require.NotNil(t, srv.TLSConfig)
+	require.Len(t, srv.TLSConfig.Certificates, 1)
+	assert.Equal(t, "https", serverConfig.Scheme())
+
+	leaf := srv.TLSConfig.Certificates[0].Leaf
+	require.NotNil(t, leaf)
+	require.NoError(t, leaf.VerifyHostname("localhost"))
+	require.NoError(t, leaf.VerifyHostname("127.0.0.1"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	resp, err := client.Get("https://" + listener.Addr().String() + "/_uni/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewServer_TLSDisabledByDefault(t *testing.T) {
+	serverConfig := &config.ServerConfig{Port: "0", LogLevel: "error"}
+
+	srv, err := pkg.NewServer(serverConfig, tlsTestUniConfig())
+	require.NoError(t, err)
+	assert.Nil(t, srv.TLSConfig)
+	assert.Equal(t, "http", serverConfig.Scheme())
+}
+
+func TestNewServer_TLSInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		expected string
+	}{
+		{name: "cert without key", certFile: "cert.pem", expected: "both TLS certificate and key files"},
+		{name: "missing files", certFile: "missing.pem", keyFile: "missing.key", expected: "failed to load TLS certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig := &config.ServerConfig{
+				Port: "0", LogLevel: "error", TLSCertFile: tt.certFile, TLSKeyFile: tt.keyFile,
+			}
+
+			srv, err := pkg.NewServer(serverConfig, tlsTestUniConfig())
+			require.Error(t, err)
+			assert.Nil(t, srv)
+
+			var configErr *pkg.ConfigError
+			require.ErrorAs(t, err, &configErr)
+			assert.Contains(t, configErr.Message, tt.expected)
+		})
+	}
+}